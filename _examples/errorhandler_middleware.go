@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	httplib "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// errorhandler_middleware.go
+//
+// Demonstrates error-returning handlers wrapped by the Errorhandler middleware.
+// The handler returns a sentinel error which is classified by an error category
+// and rendered as text, JSON or RFC 7807 problem details depending on the Accept header.
+//
+// How to run:
+//   go run ./_examples/errorhandler_middleware.go
+
+var ErrUserNotFound = errors.New("user not found")
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	notFound := httplib.NewErrorCategory(http.StatusNotFound).DisableLogging()
+	notFound.AddSentinelError(ErrUserNotFound)
+
+	handler := middleware.NewErrorhandler(
+		middleware.CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				return ErrUserNotFound
+			},
+		),
+		context.Background(),
+		logger,
+		middleware.ErrorhandlerOptions{
+			Format:          middleware.ErrorFormatNegotiate,
+			ErrorCategories: []*httplib.ErrorCategory{notFound},
+		},
+	)
+
+	for _, accept := range []string{"text/plain", "application/json", "application/problem+json"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		fmt.Printf("Accept: %s\nStatus: %d\nBody: %s\n\n", accept, rec.Code, rec.Body.String())
+	}
+}
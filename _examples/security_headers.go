@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golibry/go-http/http/router"
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// security_headers.go
+//
+// Demonstrates security headers with per-route overrides. The shared configuration is
+// registered as a named middleware; routes that need a relaxed policy override the same
+// name with the shared options merged with only the fields that differ.
+//
+// How to run:
+//   go run ./_examples/security_headers.go
+
+func main() {
+	base := middleware.SecurityHeadersOptions{
+		ContentSecurityPolicy:   "default-src 'self'",
+		StrictTransportSecurity: "max-age=63072000; includeSubDomains",
+	}
+
+	securityHeaders := func(options middleware.SecurityHeadersOptions) router.NamedMiddleware {
+		return router.NamedMiddleware{
+			Name: "security",
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewSecurityHeaders(next, options)
+			},
+		}
+	}
+
+	mux := router.NewServerMuxWrapper([]router.NamedMiddleware{securityHeaders(base)})
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) })
+	mux.Handle("/", ok)
+
+	// The admin SPA needs eval for its bundler runtime, everything else stays as configured
+	mux.HandleWithCustomMiddlewares(
+		"/admin/", ok, []router.NamedMiddleware{
+			securityHeaders(
+				base.Merge(
+					middleware.SecurityHeadersOptions{
+						ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'unsafe-eval'",
+					},
+				),
+			),
+		},
+	)
+
+	// The widget is embedded by third-party pages, so framing must be allowed
+	mux.HandleWithCustomMiddlewares(
+		"/widget", ok, []router.NamedMiddleware{
+			securityHeaders(base.Merge(middleware.SecurityHeadersOptions{FrameOptions: middleware.SecurityHeaderOmit})),
+		},
+	)
+
+	for _, path := range []string{"/", "/admin/", "/widget"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Printf(
+			"%s\n  CSP: %s\n  X-Frame-Options: %s\n",
+			path, rec.Header().Get("Content-Security-Policy"), rec.Header().Get("X-Frame-Options"),
+		)
+	}
+}
@@ -109,22 +109,8 @@ func main() {
 	mux.Handle("/fast", fastHandler)
 	mux.Handle("/slow", verySlowHandler)
 
-	// Register route with custom timeout (longer timeout)
-	customTimeoutWrapper := func(next http.Handler) http.Handler {
-		return middleware.NewTimeoutMiddleware(
-			next,
-			logger,
-			middleware.TimeoutOptions{
-				Timeout:      10 * time.Second,
-				ErrorMessage: "Extended timeout reached",
-			},
-		)
-	}
-
-	overrides := []router.NamedMiddleware{
-		{Name: "timeout", Middleware: customTimeoutWrapper},
-	}
-	mux.HandleWithCustomMiddlewares("/slow-with-long-timeout", verySlowHandler, overrides)
+	// Register route with a longer timeout declared at registration
+	mux.Handle("/slow-with-long-timeout", verySlowHandler, router.WithTimeout(10*time.Second))
 
 	// Example 3: Different timeout configurations
 	log.Println("\n=== Example 3: Different Timeout Configurations ===")
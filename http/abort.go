@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// abortCheckSize is the amount of body bytes written between two context checks
+const abortCheckSize = 32 * 1024
+
+// WriteAbortedError is returned by Send when the context got done while the response
+// was being written, typically because the client disconnected. The response is left
+// truncated. It unwraps to the context error.
+type WriteAbortedError struct {
+	Err error
+}
+
+func (e *WriteAbortedError) Error() string {
+	return "response write aborted: " + e.Err.Error()
+}
+
+func (e *WriteAbortedError) Unwrap() error {
+	return e.Err
+}
+
+// aborted returns a WriteAbortedError when the context is done, nil otherwise
+func aborted(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return &WriteAbortedError{Err: err}
+	}
+	return nil
+}
+
+// writeContext returns the context bounding body writes, the request context when the
+// request is known
+func (rb *ResponseBuilder) writeContext() context.Context {
+	if rb.request != nil {
+		return rb.request.Context()
+	}
+	return context.Background()
+}
+
+// contextWriter writes bodies in chunks, stopping with a WriteAbortedError as soon as
+// the context is done
+type contextWriter struct {
+	http.ResponseWriter
+	ctx context.Context
+	err error
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *contextWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if cw.err = aborted(cw.ctx); cw.err != nil {
+			return written, cw.err
+		}
+
+		chunk := p[:min(len(p), abortCheckSize)]
+		n, err := cw.ResponseWriter.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+// ReadFrom copies the reader through the underlying writer io.ReaderFrom implementation
+// when it has one, keeping the sendfile path of plain connections. As such a copy cannot
+// be interrupted, the context is only checked before it starts and once it failed.
+func (cw *contextWriter) ReadFrom(r io.Reader) (int64, error) {
+	if cw.err = aborted(cw.ctx); cw.err != nil {
+		return 0, cw.err
+	}
+
+	readerFrom, ok := cw.ResponseWriter.(io.ReaderFrom)
+	if !ok {
+		return io.Copy(WriterOnly{cw}, r)
+	}
+	n, err := readerFrom.ReadFrom(r)
+	if err != nil {
+		if abortErr := aborted(cw.ctx); abortErr != nil {
+			cw.err = abortErr
+			return n, abortErr
+		}
+	}
+	return n, err
+}
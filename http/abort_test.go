@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AbortSuite struct {
+	suite.Suite
+}
+
+func TestAbortSuite(t *testing.T) {
+	suite.Run(t, new(AbortSuite))
+}
+
+// disconnectingRecorder cancels the request context after the first body write, like a
+// client going away mid-response
+type disconnectingRecorder struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+}
+
+func (dr *disconnectingRecorder) Write(p []byte) (int, error) {
+	defer dr.cancel()
+	return dr.ResponseRecorder.Write(p)
+}
+
+func (suite *AbortSuite) TestItStopsWritingOnceTheClientIsGone() {
+	large := bytes.Repeat([]byte("x"), 3*abortCheckSize)
+
+	testCases := map[string]struct {
+		send func(builder *ResponseBuilder) error
+	}{
+		"text": {
+			send: func(builder *ResponseBuilder) error { return builder.Text().Content(large).Send() },
+		},
+		"binary reader": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Binary().Reader(bytes.NewBuffer(large), int64(len(large))).Send()
+			},
+		},
+		"text reader": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Text().ContentReader(bytes.NewReader(large), -1).Send()
+			},
+		},
+		"binary seeker": {
+			send: func(builder *ResponseBuilder) error { return builder.Binary().Bytes(large).Send() },
+		},
+		"file": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.File().Reader("large.txt", bytes.NewReader(large), time.Time{}).Send()
+			},
+		},
+		"ndjson": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.NDJSON().Values(SeqValues(slices.Values([]int{1, 2, 3}))).Send()
+			},
+		},
+		"json stream": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.JSON().Stream(SeqValues(slices.Values([]int{1, 2, 3}))).Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				recorder := &disconnectingRecorder{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+				request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+				err := testCase.send(NewResponseBuilder(recorder).WithRequest(request))
+
+				var abortErr *WriteAbortedError
+				suite.Require().ErrorAs(err, &abortErr)
+				suite.ErrorIs(err, context.Canceled)
+				suite.LessOrEqual(recorder.Body.Len(), abortCheckSize)
+			},
+		)
+	}
+}
+
+func (suite *AbortSuite) TestItDoesNotCommitForGoneClients() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	builder := NewResponseBuilder(recorder).WithRequest(request)
+
+	err := builder.Binary().Reader(strings.NewReader("ok"), 2).Send()
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.False(builder.Committed())
+	suite.Empty(recorder.Header())
+	suite.EqualError(&WriteAbortedError{Err: errors.New("gone")}, "response write aborted: gone")
+}
+
+// readerFromRecorder records the bodies copied through ReadFrom, like the server
+// response writer offering sendfile
+type readerFromRecorder struct {
+	*httptest.ResponseRecorder
+	readFrom int64
+}
+
+func (rr *readerFromRecorder) ReadFrom(r io.Reader) (int64, error) {
+	n, err := io.Copy(rr.ResponseRecorder, r)
+	rr.readFrom += n
+	return n, err
+}
+
+func (suite *AbortSuite) TestItKeepsTheReaderFromPath() {
+	content := bytes.Repeat([]byte("x"), 3*abortCheckSize)
+
+	testCases := map[string]struct {
+		send func(builder *ResponseBuilder) error
+	}{
+		"file": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.File().Reader("hello.txt", bytes.NewReader(content), time.Time{}).Send()
+			},
+		},
+		"binary seeker": {
+			send: func(builder *ResponseBuilder) error { return builder.Binary().Bytes(content).Send() },
+		},
+		"text reader": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Text().ContentReader(bytes.NewReader(content), -1).Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := &readerFromRecorder{ResponseRecorder: httptest.NewRecorder()}
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				err := testCase.send(NewResponseBuilder(recorder).WithRequest(request))
+
+				suite.NoError(err)
+				suite.Equal(len(content), recorder.Body.Len())
+				suite.Positive(recorder.readFrom)
+			},
+		)
+	}
+}
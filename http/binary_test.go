@@ -0,0 +1,128 @@
+package http
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BinarySuite struct {
+	suite.Suite
+}
+
+func TestBinarySuite(t *testing.T) {
+	suite.Run(t, new(BinarySuite))
+}
+
+type closeTrackingReader struct {
+	io.ReadSeeker
+	closed bool
+}
+
+func (reader *closeTrackingReader) Close() error {
+	reader.closed = true
+	return nil
+}
+
+func (suite *BinarySuite) TestItCanSendBinaryContent() {
+	testCases := map[string]struct {
+		configure            func(builder *BinaryResponseBuilder) *BinaryResponseBuilder
+		rangeHeader          string
+		expectedCode         int
+		expectedType         string
+		expectedLength       string
+		expectedAcceptRanges string
+		expectedContentRange string
+		expectedBody         string
+	}{
+		"bytes": {
+			configure: func(builder *BinaryResponseBuilder) *BinaryResponseBuilder {
+				return builder.Bytes([]byte("0123456789"))
+			},
+			expectedCode:         http.StatusOK,
+			expectedType:         "application/octet-stream",
+			expectedLength:       "10",
+			expectedAcceptRanges: "bytes",
+			expectedBody:         "0123456789",
+		},
+		"range": {
+			configure: func(builder *BinaryResponseBuilder) *BinaryResponseBuilder {
+				return builder.ReadSeeker(strings.NewReader("0123456789")).ContentType("application/x-custom")
+			},
+			rangeHeader:          "bytes=7-",
+			expectedCode:         http.StatusPartialContent,
+			expectedType:         "application/x-custom",
+			expectedLength:       "3",
+			expectedAcceptRanges: "bytes",
+			expectedContentRange: "bytes 7-9/10",
+			expectedBody:         "789",
+		},
+		"reader with size": {
+			configure: func(builder *BinaryResponseBuilder) *BinaryResponseBuilder {
+				return builder.Reader(strings.NewReader("0123456789"), 10)
+			},
+			rangeHeader:    "bytes=7-",
+			expectedCode:   http.StatusOK,
+			expectedType:   "application/octet-stream",
+			expectedLength: "10",
+			expectedBody:   "0123456789",
+		},
+		"reader without size": {
+			configure: func(builder *BinaryResponseBuilder) *BinaryResponseBuilder {
+				return builder.Reader(strings.NewReader("abc"), -1)
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "application/octet-stream",
+			expectedBody: "abc",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/blob", nil)
+				if testCase.rangeHeader != "" {
+					request.Header.Set("Range", testCase.rangeHeader)
+				}
+				recorder := httptest.NewRecorder()
+
+				builder := testCase.configure(NewResponseBuilder(recorder).Binary().WithRequest(request))
+				suite.Require().NoError(builder.Send())
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(testCase.expectedType, recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedLength, recorder.Header().Get("Content-Length"))
+				suite.Equal(testCase.expectedAcceptRanges, recorder.Header().Get("Accept-Ranges"))
+				suite.Equal(testCase.expectedContentRange, recorder.Header().Get("Content-Range"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *BinarySuite) TestItClosesContent() {
+	for _, seekable := range []bool{true, false} {
+		content := &closeTrackingReader{ReadSeeker: strings.NewReader("abc")}
+		builder := NewResponseBuilder(httptest.NewRecorder()).Binary()
+		if seekable {
+			builder.ReadSeeker(content)
+		} else {
+			builder.Reader(content, 3)
+		}
+
+		suite.Require().NoError(builder.Send())
+		suite.True(content.closed)
+	}
+}
+
+func (suite *BinarySuite) TestItRequiresContent() {
+	recorder := httptest.NewRecorder()
+
+	suite.ErrorIs(NewResponseBuilder(recorder).Binary().Send(), fs.ErrInvalid)
+	suite.Zero(recorder.Body.Len())
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Buffered sets the Content-Length header of bodies written in one piece, which are
+// held in memory until Send, for clients and proxies requiring the length. It applies to
+// JSON, text, HTML, template, negotiated and error responses; streamed, file and binary
+// responses manage their length themselves.
+func (rb *ResponseBuilder) Buffered() *ResponseBuilder {
+	rb.buffered = true
+	return rb
+}
+
+// BodySize returns the size in bytes of the body written by Send, after compression,
+// for bodies written in one piece (see Buffered). It is 0 before Send, for bodiless
+// 304 Not Modified answers and for streamed responses.
+func (rb *ResponseBuilder) BodySize() int {
+	return rb.bodySize
+}
+
+// sendJSON encodes the value in memory, then writes it as the response body
+func (rb *ResponseBuilder) sendJSON(value interface{}) error {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(value); err != nil {
+		return err
+	}
+	return rb.writeBody(body.Bytes())
+}
+
+// setContentLength announces the size of a buffered body
+func (rb *ResponseBuilder) setContentLength(size int) {
+	if rb.buffered {
+		rb.Header("Content-Length", strconv.Itoa(size))
+	}
+}
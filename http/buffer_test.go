@@ -0,0 +1,111 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BufferSuite struct {
+	suite.Suite
+}
+
+func TestBufferSuite(t *testing.T) {
+	suite.Run(t, new(BufferSuite))
+}
+
+func (suite *BufferSuite) TestItCanSetTheContentLength() {
+	testCases := map[string]struct {
+		configure      func(builder *ResponseBuilder) *ResponseBuilder
+		send           func(builder *ResponseBuilder) error
+		expectedLength bool
+	}{
+		"json": {
+			configure: (*ResponseBuilder).Buffered,
+			send: func(builder *ResponseBuilder) error {
+				return builder.JSON().Data(map[string]string{"status": "ok"}).Send()
+			},
+			expectedLength: true,
+		},
+		"text": {
+			configure: (*ResponseBuilder).Buffered,
+			send: func(builder *ResponseBuilder) error {
+				return builder.Text().ContentString("ok").Send()
+			},
+			expectedLength: true,
+		},
+		"json error": {
+			configure: (*ResponseBuilder).Buffered,
+			send: func(builder *ResponseBuilder) error {
+				return builder.Error().WithError(errors.New("failed")).DisableLogging().AsProblem().Send()
+			},
+			expectedLength: true,
+		},
+		"text error": {
+			configure: (*ResponseBuilder).Buffered,
+			send: func(builder *ResponseBuilder) error {
+				return builder.Error().WithError(errors.New("failed")).DisableLogging().Send()
+			},
+			expectedLength: true,
+		},
+		"compressed": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("Accept-Encoding", "gzip")
+				return builder.WithRequest(request).CompressAbove(0).Buffered()
+			},
+			send: func(builder *ResponseBuilder) error {
+				return builder.Text().ContentString(strings.Repeat("compressible ", 50)).Send()
+			},
+			expectedLength: true,
+		},
+		"unbuffered json": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder { return builder },
+			send: func(builder *ResponseBuilder) error {
+				return builder.JSON().Data(map[string]string{"status": "ok"}).Send()
+			},
+		},
+		"unbuffered json error": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder { return builder },
+			send: func(builder *ResponseBuilder) error {
+				return builder.Error().WithError(errors.New("failed")).DisableLogging().AsJSON().Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := testCase.configure(NewResponseBuilder(recorder))
+
+				suite.Require().NoError(testCase.send(builder))
+
+				suite.Equal(recorder.Body.Len(), builder.BodySize())
+				if testCase.expectedLength {
+					suite.Equal(strconv.Itoa(recorder.Body.Len()), recorder.Header().Get("Content-Length"))
+				} else {
+					suite.Empty(recorder.Header().Get("Content-Length"))
+				}
+			},
+		)
+	}
+}
+
+func (suite *BufferSuite) TestItReportsNoBodyForNotModifiedAnswers() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("If-None-Match", `"v1"`)
+	recorder := httptest.NewRecorder()
+	builder := NewResponseBuilder(recorder).WithRequest(request).ETag("v1").Buffered()
+
+	suite.Require().NoError(builder.Text().ContentString("ok").Send())
+
+	suite.Equal(http.StatusNotModified, recorder.Code)
+	suite.Empty(recorder.Header().Get("Content-Length"))
+	suite.Zero(builder.BodySize())
+}
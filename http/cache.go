@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl adds Cache-Control directives (e.g. "public", "s-maxage=60"), replacing
+// any directive of the same name already set. A Cache-Control header set by the builder
+// takes precedence over the middleware.CacheControl policies.
+func (rb *ResponseBuilder) CacheControl(directives ...string) *ResponseBuilder {
+	current := splitHeaderList(rb.headers["Cache-Control"])
+	for _, directive := range directives {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+		current = removeDirective(current, directiveName(directive))
+		current = append(current, directive)
+	}
+
+	if len(current) > 0 {
+		rb.headers["Cache-Control"] = strings.Join(current, ", ")
+	}
+	return rb
+}
+
+// NoStore forbids caching the response, dropping the other Cache-Control directives
+func (rb *ResponseBuilder) NoStore() *ResponseBuilder {
+	rb.headers["Cache-Control"] = "no-store"
+	return rb
+}
+
+// MaxAge sets the freshness lifetime of the response, in whole seconds, lifting a
+// previous NoStore
+func (rb *ResponseBuilder) MaxAge(maxAge time.Duration) *ResponseBuilder {
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	rb.headers["Cache-Control"] = strings.Join(
+		removeDirective(splitHeaderList(rb.headers["Cache-Control"]), "no-store"), ", ",
+	)
+	return rb.CacheControl("max-age=" + strconv.FormatInt(int64(maxAge/time.Second), 10))
+}
+
+// Immutable tells caches the response never changes for the URL, typically for
+// fingerprinted assets served with a long MaxAge
+func (rb *ResponseBuilder) Immutable() *ResponseBuilder {
+	return rb.CacheControl("immutable")
+}
+
+// Expires sets the legacy Expires header
+func (rb *ResponseBuilder) Expires(expires time.Time) *ResponseBuilder {
+	return rb.Header("Expires", expires.UTC().Format(http.TimeFormat))
+}
+
+// Vary adds request headers the response depends on, skipping those already listed
+func (rb *ResponseBuilder) Vary(headers ...string) *ResponseBuilder {
+	current := splitHeaderList(rb.headers["Vary"])
+	for _, header := range headers {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header != "" && !containsFold(current, header) {
+			current = append(current, header)
+		}
+	}
+
+	if len(current) > 0 {
+		rb.headers["Vary"] = strings.Join(current, ", ")
+	}
+	return rb
+}
+
+// splitHeaderList splits a comma separated header value, dropping empty elements
+func splitHeaderList(value string) []string {
+	var elements []string
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// directiveName returns the lower-cased name of a Cache-Control directive
+func directiveName(directive string) string {
+	name, _, _ := strings.Cut(directive, "=")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// removeDirective drops the directives with the given name
+func removeDirective(directives []string, name string) []string {
+	kept := directives[:0]
+	for _, directive := range directives {
+		if directiveName(directive) != name {
+			kept = append(kept, directive)
+		}
+	}
+	return kept
+}
+
+// containsFold reports whether values holds value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
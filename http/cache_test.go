@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheSuite struct {
+	suite.Suite
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (suite *CacheSuite) TestItCanComposeCacheHeaders() {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	testCases := map[string]struct {
+		configure            func(builder *ResponseBuilder) *ResponseBuilder
+		expectedCacheControl string
+		expectedExpires      string
+		expectedVary         string
+	}{
+		"fingerprinted asset": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.CacheControl("public").MaxAge(365 * 24 * time.Hour).Immutable()
+			},
+			expectedCacheControl: "public, max-age=31536000, immutable",
+		},
+		"replaced directive": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.MaxAge(time.Minute).CacheControl("private", "MAX-AGE=10").MaxAge(90 * time.Second)
+			},
+			expectedCacheControl: "private, max-age=90",
+		},
+		"no store drops directives": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.CacheControl("public").MaxAge(time.Hour).NoStore()
+			},
+			expectedCacheControl: "no-store",
+		},
+		"max age lifts no store": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.NoStore().CacheControl("private").MaxAge(-time.Second)
+			},
+			expectedCacheControl: "private, max-age=0",
+		},
+		"expires": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.Expires(expires)
+			},
+			expectedExpires: "Wed, 02 Jan 2030 02:04:05 GMT",
+		},
+		"vary": {
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.Vary("accept-encoding", "Origin").Vary("Accept-Encoding", " cookie ", "")
+			},
+			expectedVary: "Accept-Encoding, Origin, Cookie",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := testCase.configure(NewResponseBuilder(recorder))
+				suite.Require().NoError(builder.Text().ContentString("ok").Send())
+
+				suite.Equal(testCase.expectedCacheControl, recorder.Header().Get("Cache-Control"))
+				suite.Equal(testCase.expectedExpires, recorder.Header().Get("Expires"))
+				suite.Equal(testCase.expectedVary, recorder.Header().Get("Vary"))
+			},
+		)
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"sync"
+)
+
+// DefaultCompressThreshold is the body size, in bytes, from which Compress gzips a body.
+// Smaller bodies gain little and cost CPU time.
+const DefaultCompressThreshold = 1024
+
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// Compress gzips bodies of at least DefaultCompressThreshold bytes when the request (see
+// WithRequest) accepts it, for handlers not behind a compression middleware. It applies
+// to buffered responses (JSON, text, HTML, templates, negotiated and error responses).
+// Bodies already carrying a Content-Encoding are sent as is.
+func (rb *ResponseBuilder) Compress() *ResponseBuilder {
+	return rb.CompressAbove(DefaultCompressThreshold)
+}
+
+// CompressAbove is like Compress with a custom minimal body size
+func (rb *ResponseBuilder) CompressAbove(threshold int) *ResponseBuilder {
+	rb.compress = true
+	rb.compressThreshold = threshold
+	return rb
+}
+
+// negotiateCompression tells whether a body of the given size is gzipped, adding
+// Accept-Encoding to Vary whenever the answer depends on it
+func (rb *ResponseBuilder) negotiateCompression(size int) bool {
+	if !rb.compress || size < rb.compressThreshold || rb.headers["Content-Encoding"] != "" {
+		return false
+	}
+	rb.Vary("Accept-Encoding")
+	return rb.request != nil &&
+		acceptsEncoding(rb.request.Header.Values("Accept-Encoding"), "gzip")
+}
+
+// gzipBody compresses the body with a pooled gzip writer
+func gzipBody(body []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(writer)
+
+	writer.Reset(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// acceptsEncoding tells whether the Accept-Encoding header lines accept the content
+// coding, either by name or through a "*" entry, with a non-zero quality
+func acceptsEncoding(header []string, coding string) bool {
+	accepted, wildcard := -1.0, -1.0
+	for _, entry := range parseQualityValues(header) {
+		switch strings.ToLower(entry.value) {
+		case coding, "x-" + coding:
+			accepted = entry.quality
+		case "*":
+			wildcard = entry.quality
+		}
+	}
+
+	if accepted >= 0 {
+		return accepted > 0
+	}
+	return wildcard > 0
+}
+
+// encodedETag marks an entity tag as belonging to an encoded representation, since
+// representations differing in their content coding must not share a strong tag
+func encodedETag(etag, coding string) string {
+	return strings.TrimSuffix(etag, `"`) + "-" + coding + `"`
+}
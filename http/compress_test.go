@@ -0,0 +1,148 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CompressSuite struct {
+	suite.Suite
+}
+
+func TestCompressSuite(t *testing.T) {
+	suite.Run(t, new(CompressSuite))
+}
+
+func (suite *CompressSuite) TestItCanCompressBodies() {
+	large := strings.Repeat("compressible ", 100)
+
+	testCases := map[string]struct {
+		acceptEncoding   string
+		body             string
+		configure        func(builder *ResponseBuilder)
+		expectCompressed bool
+		expectedVary     string
+	}{
+		"gzip accepted": {
+			acceptEncoding:   "br, gzip",
+			body:             large,
+			expectCompressed: true,
+			expectedVary:     "Accept-Encoding",
+		},
+		"wildcard accepted": {
+			acceptEncoding:   "*",
+			body:             large,
+			expectCompressed: true,
+			expectedVary:     "Accept-Encoding",
+		},
+		"gzip refused": {
+			acceptEncoding: "gzip;q=0, *",
+			body:           large,
+			expectedVary:   "Accept-Encoding",
+		},
+		"no accept encoding": {
+			body:         large,
+			expectedVary: "Accept-Encoding",
+		},
+		"below threshold": {
+			acceptEncoding: "gzip",
+			body:           "small",
+		},
+		"already encoded": {
+			acceptEncoding: "gzip",
+			body:           large,
+			configure: func(builder *ResponseBuilder) {
+				builder.Header("Content-Encoding", "br")
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				if testCase.acceptEncoding != "" {
+					request.Header.Set("Accept-Encoding", testCase.acceptEncoding)
+				}
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).WithRequest(request).Compress()
+				if testCase.configure != nil {
+					testCase.configure(builder)
+				}
+
+				suite.Require().NoError(builder.Text().ContentString(testCase.body).Send())
+
+				suite.Equal(testCase.expectedVary, recorder.Header().Get("Vary"))
+				if !testCase.expectCompressed {
+					suite.NotEqual("gzip", recorder.Header().Get("Content-Encoding"))
+					suite.Equal(testCase.body, recorder.Body.String())
+					return
+				}
+
+				suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+				suite.Equal(testCase.body, suite.gunzip(recorder.Body))
+			},
+		)
+	}
+}
+
+func (suite *CompressSuite) TestItCanCompressJSON() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		WithRequest(request).
+		CompressAbove(0).
+		JSON().
+		Data(map[string]string{"status": "ok"}).
+		Send()
+
+	suite.Require().NoError(err)
+	suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal("{\"status\":\"ok\"}\n", suite.gunzip(recorder.Body))
+}
+
+func (suite *CompressSuite) TestItTagsCompressedRepresentations() {
+	body := strings.Repeat("compressible ", 100)
+	send := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		request.Header.Set("If-None-Match", ifNoneMatch)
+		recorder := httptest.NewRecorder()
+
+		err := NewResponseBuilder(recorder).
+			WithRequest(request).
+			ETag("v1").
+			Compress().
+			Text().
+			ContentString(body).
+			Send()
+		suite.Require().NoError(err)
+		return recorder
+	}
+
+	recorder := send(`"v1"`)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`"v1-gzip"`, recorder.Header().Get("ETag"))
+
+	recorder = send(`"v1-gzip"`)
+	suite.Equal(http.StatusNotModified, recorder.Code)
+	suite.Equal("Accept-Encoding", recorder.Header().Get("Vary"))
+	suite.Zero(recorder.Body.Len())
+}
+
+func (suite *CompressSuite) gunzip(body io.Reader) string {
+	reader, err := gzip.NewReader(body)
+	suite.Require().NoError(err)
+	decompressed, err := io.ReadAll(reader)
+	suite.Require().NoError(err)
+	return string(decompressed)
+}
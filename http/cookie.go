@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// Cookie adds a Set-Cookie header for the cookie, sent with the response headers
+func (rb *ResponseBuilder) Cookie(cookie *http.Cookie) *ResponseBuilder {
+	rb.cookies = append(rb.cookies, cookie)
+	return rb
+}
+
+// DeleteCookie expires the named cookie of the "/" path. Cookies set for another path or
+// domain are deleted with Cookie, passing them with a negative MaxAge.
+func (rb *ResponseBuilder) DeleteCookie(name string) *ResponseBuilder {
+	return rb.Cookie(
+		&http.Cookie{
+			Name:    name,
+			Path:    "/",
+			MaxAge:  -1,
+			Expires: time.Unix(0, 0),
+		},
+	)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CookieSuite struct {
+	suite.Suite
+}
+
+func TestCookieSuite(t *testing.T) {
+	suite.Run(t, new(CookieSuite))
+}
+
+func (suite *CookieSuite) TestItCanSetAndDeleteCookies() {
+	testCases := map[string]struct {
+		send func(builder *ResponseBuilder) error
+	}{
+		"json": {
+			send: func(builder *ResponseBuilder) error { return builder.JSON().Data("ok").Send() },
+		},
+		"text": {
+			send: func(builder *ResponseBuilder) error { return builder.Text().ContentString("ok").Send() },
+		},
+		"error": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Error().WithError(errors.New("failed")).DisableLogging().Send()
+			},
+		},
+		"file": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.File().Reader("a.txt", strings.NewReader("ok"), time.Time{}).Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).
+					Cookie(&http.Cookie{Name: "theme", Value: "dark", Path: "/", HttpOnly: true}).
+					DeleteCookie("session")
+
+				suite.Require().NoError(testCase.send(builder))
+
+				cookies := recorder.Result().Cookies()
+				suite.Require().Len(cookies, 2)
+				suite.Equal("theme", cookies[0].Name)
+				suite.Equal("dark", cookies[0].Value)
+				suite.True(cookies[0].HttpOnly)
+				suite.Equal("session", cookies[1].Name)
+				suite.Equal("/", cookies[1].Path)
+				suite.Equal(-1, cookies[1].MaxAge)
+			},
+		)
+	}
+}
@@ -0,0 +1,174 @@
+// Package debug serves the runtime internals of a service (expvar, build information,
+// route table, session counts and garbage collector statistics) on a handler meant for
+// a private admin listener; see the router/pprof package for the profiling handlers.
+// It lives apart from the router as importing expvar registers its handler on
+// http.DefaultServeMux.
+package debug
+
+import (
+	"crypto/subtle"
+	"errors"
+	"expvar"
+	"net/http"
+	"runtime"
+	runtimedebug "runtime/debug"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/router"
+	"github.com/golibry/go-http/http/session"
+)
+
+// Paths served by the debug handler, relative to where it is mounted
+const (
+	VarsPath     = "/vars"
+	BuildPath    = "/build"
+	RoutesPath   = "/routes"
+	SessionsPath = "/sessions"
+	GCPath       = "/gc"
+)
+
+// ErrBuildInfoUnavailable is rendered when the binary carries no build information
+var ErrBuildInfoUnavailable = errors.New("build information unavailable")
+
+// Options configures the debug handler
+//
+// Authorize: gate of every debug request, e.g. BasicAuth (required; a gate returning
+// true is only acceptable when the admin listener is not reachable from outside)
+// Routes: route table handler, e.g. ServerMuxWrapper.RoutesHandler() (optional)
+// Sessions: storage counting the live sessions, e.g. storage.MemoryStorage (optional)
+type Options struct {
+	Authorize func(r *http.Request) bool
+	Routes    http.Handler
+	Sessions  session.Counter
+}
+
+// BasicAuth returns a gate accepting the credentials, compared in constant time, for
+// Options.Authorize or router.Authorize
+func BasicAuth(username string, password string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		user, pass, ok := r.BasicAuth()
+		return ok &&
+			subtle.ConstantTimeCompare([]byte(user), []byte(username)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+	}
+}
+
+// Handler returns the debug handler, serving VarsPath, BuildPath, GCPath and, when
+// configured, RoutesPath and SessionsPath. Mount it under a prefix with the router
+// Mount or http.StripPrefix, e.g. at "/debug" on the admin listener.
+// It panics without an Authorize gate, as the internals must not be served to anyone.
+func Handler(options Options) http.Handler {
+	if options.Authorize == nil {
+		panic("debug: Handler requires an Authorize gate")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+VarsPath, expvar.Handler())
+	mux.HandleFunc("GET "+BuildPath, serveBuildInfo)
+	mux.HandleFunc("GET "+GCPath, serveGCStats)
+	if options.Routes != nil {
+		mux.Handle("GET "+RoutesPath, options.Routes)
+	}
+	if options.Sessions != nil {
+		mux.HandleFunc(
+			"GET "+SessionsPath, func(w http.ResponseWriter, r *http.Request) {
+				serveSessionCount(w, r, options.Sessions)
+			},
+		)
+	}
+
+	return router.Authorize(options.Authorize).Middleware(mux)
+}
+
+// buildInfo is the build information of the running binary
+type buildInfo struct {
+	GoVersion    string            `json:"goVersion"`
+	Path         string            `json:"path"`
+	Version      string            `json:"version"`
+	Settings     map[string]string `json:"settings"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+func serveBuildInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := runtimedebug.ReadBuildInfo()
+	if !ok {
+		_ = httpInternal.NewResponseBuilder(w).
+			Error().
+			WithError(ErrBuildInfoUnavailable).
+			WithRequest(r).
+			AsJSON().
+			Send()
+		return
+	}
+
+	body := buildInfo{
+		GoVersion:    info.GoVersion,
+		Path:         info.Main.Path,
+		Version:      info.Main.Version,
+		Settings:     make(map[string]string, len(info.Settings)),
+		Dependencies: make(map[string]string, len(info.Deps)),
+	}
+	for _, setting := range info.Settings {
+		body.Settings[setting.Key] = setting.Value
+	}
+	for _, dependency := range info.Deps {
+		body.Dependencies[dependency.Path] = dependency.Version
+	}
+	_ = httpInternal.NewResponseBuilder(w).JSON().Data(body).Send()
+}
+
+// gcStats summarizes the garbage collector and heap state
+type gcStats struct {
+	Goroutines   int       `json:"goroutines"`
+	NumGC        int64     `json:"numGC"`
+	LastGC       time.Time `json:"lastGC"`
+	PauseTotalMs float64   `json:"pauseTotalMs"`
+	RecentMs     []float64 `json:"recentPausesMs"`
+	HeapAlloc    uint64    `json:"heapAlloc"`
+	HeapInuse    uint64    `json:"heapInuse"`
+	HeapObjects  uint64    `json:"heapObjects"`
+	NextGC       uint64    `json:"nextGC"`
+	Sys          uint64    `json:"sys"`
+}
+
+// recentPauses is the number of latest GC pauses reported
+const recentPauses = 10
+
+func serveGCStats(w http.ResponseWriter, _ *http.Request) {
+	var stats runtimedebug.GCStats
+	runtimedebug.ReadGCStats(&stats)
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+
+	body := gcStats{
+		Goroutines:   runtime.NumGoroutine(),
+		NumGC:        stats.NumGC,
+		LastGC:       stats.LastGC,
+		PauseTotalMs: milliseconds(stats.PauseTotal),
+		RecentMs:     make([]float64, 0, recentPauses),
+		HeapAlloc:    memory.HeapAlloc,
+		HeapInuse:    memory.HeapInuse,
+		HeapObjects:  memory.HeapObjects,
+		NextGC:       memory.NextGC,
+		Sys:          memory.Sys,
+	}
+	for _, pause := range stats.Pause[:min(len(stats.Pause), recentPauses)] {
+		body.RecentMs = append(body.RecentMs, milliseconds(pause))
+	}
+	_ = httpInternal.NewResponseBuilder(w).JSON().Data(body).Send()
+}
+
+func serveSessionCount(w http.ResponseWriter, r *http.Request, counter session.Counter) {
+	count, err := counter.Count(r.Context())
+	if err != nil {
+		_ = httpInternal.NewResponseBuilder(w).Error().WithError(err).WithRequest(r).AsJSON().Send()
+		return
+	}
+	_ = httpInternal.NewResponseBuilder(w).JSON().Data(map[string]int{"active": count}).Send()
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
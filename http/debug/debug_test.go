@@ -0,0 +1,157 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golibry/go-http/http/session/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugSuite struct {
+	suite.Suite
+}
+
+func TestDebugSuite(t *testing.T) {
+	suite.Run(t, new(DebugSuite))
+}
+
+// failingCounter fails to count the sessions
+type failingCounter struct{}
+
+func (failingCounter) Count(context.Context) (int, error) {
+	return 0, errors.New("storage unavailable")
+}
+
+// allowAll lets every request through
+func allowAll(*http.Request) bool {
+	return true
+}
+
+func (suite *DebugSuite) serve(handler http.Handler, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func (suite *DebugSuite) TestItServesTheRuntimeInternals() {
+	sessions := storage.NewMemoryStorage()
+	suite.Require().NoError(sessions.Set(context.Background(), "s1", []byte("data"), time.Hour))
+	handler := Handler(
+		Options{
+			Authorize: allowAll,
+			Routes: http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("GET /users")) },
+			),
+			Sessions: sessions,
+		},
+	)
+
+	testCases := map[string]struct {
+		path         string
+		expectedKeys []string
+		expectedBody string
+	}{
+		"expvar": {
+			path:         VarsPath,
+			expectedKeys: []string{"cmdline", "memstats"},
+		},
+		"build information": {
+			path:         BuildPath,
+			expectedKeys: []string{"goVersion", "path", "settings", "dependencies"},
+		},
+		"garbage collector": {
+			path:         GCPath,
+			expectedKeys: []string{"goroutines", "numGC", "pauseTotalMs", "heapAlloc"},
+		},
+		"routes": {
+			path:         RoutesPath,
+			expectedBody: "GET /users",
+		},
+		"sessions": {
+			path:         SessionsPath,
+			expectedBody: `{"active":1}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := suite.serve(handler, testCase.path)
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, strings.TrimSpace(recorder.Body.String()))
+				}
+				if len(testCase.expectedKeys) > 0 {
+					var body map[string]json.RawMessage
+					suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &body))
+					for _, key := range testCase.expectedKeys {
+						suite.Contains(body, key)
+					}
+				}
+			},
+		)
+	}
+}
+
+func (suite *DebugSuite) TestItOnlyServesConfiguredSections() {
+	handler := Handler(Options{Authorize: allowAll})
+
+	suite.Equal(http.StatusNotFound, suite.serve(handler, RoutesPath).Code)
+	suite.Equal(http.StatusNotFound, suite.serve(handler, SessionsPath).Code)
+}
+
+func (suite *DebugSuite) TestItReportsSessionCountFailures() {
+	recorder := suite.serve(Handler(Options{Authorize: allowAll, Sessions: failingCounter{}}), SessionsPath)
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+}
+
+func (suite *DebugSuite) TestItCanRequireAuthorization() {
+	handler := Handler(Options{Authorize: BasicAuth("admin", "secret")})
+
+	testCases := map[string]struct {
+		username     string
+		password     string
+		expectedCode int
+	}{
+		"no credentials":    {expectedCode: http.StatusUnauthorized},
+		"wrong password":    {username: "admin", password: "guess", expectedCode: http.StatusUnauthorized},
+		"valid credentials": {username: "admin", password: "secret", expectedCode: http.StatusOK},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, GCPath, nil)
+				if testCase.username != "" {
+					request.SetBasicAuth(testCase.username, testCase.password)
+				}
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				if testCase.expectedCode == http.StatusUnauthorized {
+					suite.Equal(`Basic realm="debug"`, recorder.Header().Get("WWW-Authenticate"))
+				}
+			},
+		)
+	}
+}
+
+func (suite *DebugSuite) TestItRequiresAnAuthorizeGate() {
+	suite.PanicsWithValue(
+		"debug: Handler requires an Authorize gate", func() {
+			Handler(Options{})
+		},
+	)
+}
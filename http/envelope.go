@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"maps"
+)
+
+// Envelope is the uniform body of enveloped JSON responses: successful responses carry
+// their payload in Data, error responses their error object in Error, and both carry
+// the response metadata in Meta
+type Envelope struct {
+	Data  interface{}            `json:"data"`
+	Error interface{}            `json:"error"`
+	Meta  map[string]interface{} `json:"meta"`
+}
+
+// DefaultEnvelope tells whether JSON responses are enveloped, see Envelope. It is meant
+// to be set once at startup.
+var DefaultEnvelope = false
+
+type envelopeContextKey struct{}
+
+// WithEnvelope returns a context enabling the envelope for responses to requests served
+// with it (see WithRequest), adding the metadata to their meta member. Metadata already
+// in the context is kept, the new values taking precedence.
+func WithEnvelope(ctx context.Context, meta map[string]interface{}) context.Context {
+	merged := maps.Clone(envelopeMetaFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]interface{}, len(meta))
+	}
+	maps.Copy(merged, meta)
+	return context.WithValue(ctx, envelopeContextKey{}, merged)
+}
+
+// envelopeMetaFromContext returns the envelope metadata stored in the context, nil when
+// the context does not enable the envelope
+func envelopeMetaFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	meta, _ := ctx.Value(envelopeContextKey{}).(map[string]interface{})
+	return meta
+}
+
+// Envelope overrides DefaultEnvelope and the request context setting for this response.
+// Enveloped JSON responses are sent as {"data": ..., "error": null, "meta": {...}} and
+// JSON error responses as {"data": null, "error": {"message": ..., "status": ...},
+// "meta": {...}}, with the request ID moved to meta. Text error responses become
+// enveloped JSON as well, while problem details, JSON:API and custom formatted errors
+// keep their own format. JSON streams are never enveloped.
+func (rb *ResponseBuilder) Envelope(enabled bool) *ResponseBuilder {
+	rb.envelope = &enabled
+	return rb
+}
+
+// Meta adds a member to the meta object of enveloped responses, taking precedence over
+// the request context metadata
+func (rb *ResponseBuilder) Meta(key string, value interface{}) *ResponseBuilder {
+	if rb.meta == nil {
+		rb.meta = make(map[string]interface{})
+	}
+	rb.meta[key] = value
+	return rb
+}
+
+// envelopes resolves whether the response is enveloped: the builder setting, then the
+// request context, then DefaultEnvelope
+func (rb *ResponseBuilder) envelopes() bool {
+	if rb.envelope != nil {
+		return *rb.envelope
+	}
+	if rb.request != nil && envelopeMetaFromContext(rb.request.Context()) != nil {
+		return true
+	}
+	return DefaultEnvelope
+}
+
+// envelopeMeta returns the meta member of the envelope, never nil so it encodes as {}
+func (rb *ResponseBuilder) envelopeMeta() map[string]interface{} {
+	meta := make(map[string]interface{}, len(rb.meta))
+	if rb.request != nil {
+		maps.Copy(meta, envelopeMetaFromContext(rb.request.Context()))
+	}
+	maps.Copy(meta, rb.meta)
+	return meta
+}
+
+// envelopeError wraps a JSON error body, renaming its error member to message and moving
+// the request ID to the meta member
+func (erb *ErrorResponseBuilder) envelopeError(body map[string]interface{}) Envelope {
+	meta := erb.envelopeMeta()
+	if requestID, ok := body["requestId"]; ok {
+		meta["requestId"] = requestID
+		delete(body, "requestId")
+	}
+	body["message"] = body["error"]
+	delete(body, "error")
+	return Envelope{Error: body, Meta: meta}
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EnvelopeSuite struct {
+	suite.Suite
+}
+
+func TestEnvelopeSuite(t *testing.T) {
+	suite.Run(t, new(EnvelopeSuite))
+}
+
+func (suite *EnvelopeSuite) TestItCanEnvelopeResponses() {
+	testCases := map[string]struct {
+		send                func(builder *ResponseBuilder) error
+		expectedContentType string
+		expectedBody        string
+	}{
+		"json": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Envelope(true).Meta("page", 2).JSON().Data(map[string]int{"id": 7}).Send()
+			},
+			expectedContentType: "application/json",
+			expectedBody:        `{"data":{"id":7},"error":null,"meta":{"page":2}}`,
+		},
+		"json without meta": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Envelope(true).JSON().Data(nil).Send()
+			},
+			expectedContentType: "application/json",
+			expectedBody:        `{"data":null,"error":null,"meta":{}}`,
+		},
+		"json error": {
+			send: func(builder *ResponseBuilder) error {
+				builder.Envelope(true).Meta("page", 2)
+				return builder.Error().WithError(errors.New("boom")).WithCode("boom").
+					WithRequestID("req-1").DisableLogging().AsJSON().Send()
+			},
+			expectedContentType: "application/json",
+			expectedBody: `{"data":null,"error":{"message":"boom","status":500,"code":"boom"},` +
+				`"meta":{"page":2,"requestId":"req-1"}}`,
+		},
+		"text error": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Envelope(true).Error().WithError(errors.New("boom")).DisableLogging().Send()
+			},
+			expectedContentType: "application/json",
+			expectedBody:        `{"data":null,"error":{"message":"boom","status":500},"meta":{}}`,
+		},
+		"problem error": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Envelope(true).Error().WithError(errors.New("boom")).DisableLogging().
+					AsProblem().Send()
+			},
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"boom"}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+
+				suite.Require().NoError(testCase.send(NewResponseBuilder(recorder)))
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+				suite.JSONEq(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *EnvelopeSuite) TestItResolvesTheEnvelopeSetting() {
+	defaultEnvelope := DefaultEnvelope
+	defer func() { DefaultEnvelope = defaultEnvelope }()
+
+	testCases := map[string]struct {
+		globalEnvelope bool
+		contextMeta    map[string]interface{}
+		builderDisable bool
+		expectedBody   string
+	}{
+		"disabled": {
+			expectedBody: `[1]`,
+		},
+		"global": {
+			globalEnvelope: true,
+			expectedBody:   `{"data":[1],"error":null,"meta":{}}`,
+		},
+		"request context": {
+			contextMeta:  map[string]interface{}{"version": "v2"},
+			expectedBody: `{"data":[1],"error":null,"meta":{"version":"v2"}}`,
+		},
+		"builder over request context": {
+			contextMeta:    map[string]interface{}{"version": "v2"},
+			builderDisable: true,
+			expectedBody:   `[1]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				DefaultEnvelope = testCase.globalEnvelope
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				if testCase.contextMeta != nil {
+					request = request.WithContext(WithEnvelope(request.Context(), testCase.contextMeta))
+				}
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).WithRequest(request)
+				if testCase.builderDisable {
+					builder.Envelope(false)
+				}
+
+				suite.Require().NoError(builder.JSON().Data([]int{1}).Send())
+				suite.JSONEq(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
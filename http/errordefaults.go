@@ -0,0 +1,68 @@
+package http
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// ErrorResponseDefaults is the error response setup shared by a whole service, so
+// Error().WithError(err).Send() behaves the same in every handler
+//
+// Format: body format of new error builders, overridden by AsJSON, AsProblem, ...
+// Categories: error categories checked after the ones set on the builder
+// Logger: logger used when the builder has none
+// Formatter: custom body formatter used when the builder has none
+// Translator: message translator used when the builder has none
+type ErrorResponseDefaults struct {
+	Format     ErrorBodyFormat
+	Categories []*ErrorCategory
+	Logger     *slog.Logger
+	Formatter  ErrorFormatter
+	Translator Translator
+}
+
+// DefaultErrorResponse holds the error response defaults. It is meant to be set once at
+// startup, before serving requests.
+var DefaultErrorResponse = ErrorResponseDefaults{}
+
+// WithFormat sets the body format of the error response
+func (erb *ErrorResponseBuilder) WithFormat(format ErrorBodyFormat) *ErrorResponseBuilder {
+	switch format {
+	case ErrorBodyJSON:
+		return erb.AsJSON()
+	case ErrorBodyProblem:
+		return erb.AsProblem()
+	case ErrorBodyJSONAPI:
+		return erb.AsJSONAPI()
+	default:
+		return erb.AsText()
+	}
+}
+
+// AsText configures the error response to be plain text
+func (erb *ErrorResponseBuilder) AsText() *ErrorResponseBuilder {
+	erb.Header("Content-Type", "text/plain; charset=utf-8")
+	erb.format = ErrorBodyText
+	return erb
+}
+
+// applyDefaults fills the settings left unset on the builder from DefaultErrorResponse
+func (erb *ErrorResponseBuilder) applyDefaults() {
+	if erb.logger == nil {
+		erb.logger = DefaultErrorResponse.Logger
+	}
+	if erb.formatter == nil {
+		erb.formatter = DefaultErrorResponse.Formatter
+	}
+	if erb.translator == nil {
+		erb.translator = DefaultErrorResponse.Translator
+	}
+}
+
+// errorCategories returns the builder categories followed by the default ones
+func (erb *ErrorResponseBuilder) errorCategories() []*ErrorCategory {
+	if len(DefaultErrorResponse.Categories) == 0 {
+		return erb.categories
+	}
+	return slices.Concat(erb.categories, DefaultErrorResponse.Categories)
+}
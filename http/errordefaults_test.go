@@ -0,0 +1,130 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorDefaultsSuite struct {
+	suite.Suite
+	defaults ErrorResponseDefaults
+}
+
+func TestErrorDefaultsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorDefaultsSuite))
+}
+
+func (suite *ErrorDefaultsSuite) SetupTest() {
+	suite.defaults = DefaultErrorResponse
+}
+
+func (suite *ErrorDefaultsSuite) TearDownTest() {
+	DefaultErrorResponse = suite.defaults
+}
+
+var errDefaultsNotFound = errors.New("not found")
+
+func (suite *ErrorDefaultsSuite) TestItCanRenderErrorsWithTheDefaults() {
+	notFound := NewErrorCategory(http.StatusNotFound).WithCode("not_found")
+	notFound.AddSentinelError(errDefaultsNotFound)
+	var logs bytes.Buffer
+	DefaultErrorResponse = ErrorResponseDefaults{
+		Format:     ErrorBodyJSON,
+		Categories: []*ErrorCategory{notFound},
+		Logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).Error().WithError(errDefaultsNotFound).Send()
+
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+	suite.JSONEq(
+		`{"error":"not found","status":404,"code":"not_found"}`,
+		recorder.Body.String(),
+	)
+	suite.Contains(logs.String(), "StatusCode=404")
+}
+
+func (suite *ErrorDefaultsSuite) TestItCanOverrideTheDefaults() {
+	defaultCategory := NewErrorCategory(http.StatusNotFound)
+	defaultCategory.AddSentinelError(errDefaultsNotFound)
+	builderCategory := NewErrorCategory(http.StatusGone)
+	builderCategory.AddSentinelError(errDefaultsNotFound)
+	defaultFormatter := ErrorFormatterFunc(
+		func(context.Context, error, int) ([]byte, string, error) {
+			return []byte("default formatter"), "text/plain", nil
+		},
+	)
+
+	testCases := map[string]struct {
+		formatter   ErrorFormatter
+		configure   func(builder *ErrorResponseBuilder)
+		status      int
+		contentType string
+		body        string
+	}{
+		"builder format": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsProblem()
+			},
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+		},
+		"builder categories first": {
+			formatter: defaultFormatter,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AddErrorCategory(builderCategory)
+			},
+			status:      http.StatusGone,
+			contentType: "text/plain",
+			body:        "default formatter",
+		},
+		"builder formatter": {
+			formatter: defaultFormatter,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithFormatter(
+					ErrorFormatterFunc(
+						func(context.Context, error, int) ([]byte, string, error) {
+							return []byte("builder formatter"), "text/plain", nil
+						},
+					),
+				)
+			},
+			status:      http.StatusNotFound,
+			contentType: "text/plain",
+			body:        "builder formatter",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				DefaultErrorResponse = ErrorResponseDefaults{
+					Format:     ErrorBodyJSON,
+					Categories: []*ErrorCategory{defaultCategory},
+					Formatter:  testCase.formatter,
+				}
+
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).Error().WithError(errDefaultsNotFound).DisableLogging()
+				testCase.configure(builder)
+
+				suite.Require().NoError(builder.Send())
+				suite.Equal(testCase.status, recorder.Code)
+				suite.Equal(testCase.contentType, recorder.Header().Get("Content-Type"))
+				if testCase.body != "" {
+					suite.Equal(testCase.body, recorder.Body.String())
+				}
+			},
+		)
+	}
+}
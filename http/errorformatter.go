@@ -0,0 +1,22 @@
+package http
+
+import "context"
+
+// ErrorFormatter renders the body of error responses, letting an organization enforce
+// a single error envelope. It receives the error being rendered (an error built from
+// the message when only a message was set) and the resolved status code.
+type ErrorFormatter interface {
+	FormatError(ctx context.Context, err error, statusCode int) (body []byte, contentType string, formatErr error)
+}
+
+// ErrorFormatterFunc adapts a function to the ErrorFormatter interface
+type ErrorFormatterFunc func(ctx context.Context, err error, statusCode int) ([]byte, string, error)
+
+// FormatError calls f(ctx, err, statusCode)
+func (f ErrorFormatterFunc) FormatError(
+	ctx context.Context,
+	err error,
+	statusCode int,
+) ([]byte, string, error) {
+	return f(ctx, err, statusCode)
+}
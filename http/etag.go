@@ -0,0 +1,135 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// etagMode tells how the entity tag of a response is obtained
+type etagMode int
+
+const (
+	etagNone etagMode = iota
+	etagFixed
+	etagStrong
+	etagWeak
+)
+
+// ETag sets a precomputed entity tag. Bare values are quoted, quoted and weak (W/"...")
+// values are used as is. Buffered responses answer 304 Not Modified without a body when
+// the request (see WithRequest) If-None-Match header matches it; file responses
+// delegate the comparison to http.ServeContent.
+func (rb *ResponseBuilder) ETag(etag string) *ResponseBuilder {
+	if !strings.HasPrefix(etag, `"`) && !strings.HasPrefix(etag, `W/"`) {
+		etag = `"` + etag + `"`
+	}
+	rb.etag = etag
+	rb.etagMode = etagFixed
+	return rb.Header("ETag", etag)
+}
+
+// AutoETag computes the entity tag from the response body, weak when asked to. It
+// applies to buffered responses (JSON, text, HTML and templates); streamed responses
+// are left untouched.
+func (rb *ResponseBuilder) AutoETag(weak bool) *ResponseBuilder {
+	rb.etagMode = etagStrong
+	if weak {
+		rb.etagMode = etagWeak
+	}
+	return rb
+}
+
+// writeBody writes the headers and the body, signed and gzipped when asked to, or only
+// the headers of a 304 Not Modified response when the entity tag matches the request
+// preconditions. Writing stops with a WriteAbortedError once the request context is done.
+func (rb *ResponseBuilder) writeBody(body []byte) error {
+	ctx := rb.writeContext()
+	if err := aborted(ctx); err != nil {
+		return err
+	}
+	if rb.signingKey != nil {
+		rb.Header(SignatureHeader, Signature(*rb.signingKey, body))
+	}
+	compress := rb.negotiateCompression(len(body))
+	if rb.etagMode != etagNone && rb.statusCode == http.StatusOK {
+		etag := rb.etag
+		if rb.etagMode != etagFixed {
+			etag = computeETag(body, rb.etagMode == etagWeak)
+		}
+		if compress {
+			etag = encodedETag(etag, "gzip")
+		}
+		rb.Header("ETag", etag)
+
+		if rb.notModified(etag) {
+			delete(rb.headers, "Content-Type")
+			rb.statusCode = http.StatusNotModified
+			rb.writeHeaders()
+			return nil
+		}
+	}
+
+	if compress {
+		var err error
+		if body, err = gzipBody(body); err != nil {
+			return err
+		}
+		rb.Header("Content-Encoding", "gzip")
+		delete(rb.headers, "Content-Length")
+	}
+
+	rb.setContentLength(len(body))
+	rb.writeHeaders()
+	n, err := (&contextWriter{ResponseWriter: rb.writer, ctx: ctx}).Write(body)
+	rb.bodySize = n
+	return err
+}
+
+// notModified tells whether the If-None-Match header of a GET or HEAD request matches
+// the entity tag
+func (rb *ResponseBuilder) notModified(etag string) bool {
+	if rb.request == nil || (rb.request.Method != http.MethodGet && rb.request.Method != http.MethodHead) {
+		return false
+	}
+	return etagMatches(strings.Join(rb.request.Header.Values("If-None-Match"), ","), etag)
+}
+
+// computeETag returns a quoted, base64 encoded truncated SHA-256 of the body
+func computeETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// etagMatches compares the entity tag with an If-None-Match list using the weak
+// comparison required for that header
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for {
+		ifNoneMatch = strings.TrimLeft(ifNoneMatch, " \t,")
+		if ifNoneMatch == "" {
+			return false
+		}
+		if ifNoneMatch[0] == '*' {
+			return true
+		}
+
+		candidate := strings.TrimPrefix(ifNoneMatch, "W/")
+		if len(candidate) < 2 || candidate[0] != '"' {
+			return false
+		}
+		end := strings.IndexByte(candidate[1:], '"')
+		if end < 0 {
+			return false
+		}
+		if candidate[:end+2] == etag {
+			return true
+		}
+		ifNoneMatch = candidate[end+2:]
+	}
+}
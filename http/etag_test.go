@@ -0,0 +1,159 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ETagSuite struct {
+	suite.Suite
+}
+
+func TestETagSuite(t *testing.T) {
+	suite.Run(t, new(ETagSuite))
+}
+
+func (suite *ETagSuite) TestItCanAnswerNotModified() {
+	body := "hello world"
+	strongETag := computeETag([]byte(body), false)
+
+	testCases := map[string]struct {
+		method       string
+		ifNoneMatch  string
+		configure    func(builder *ResponseBuilder) *ResponseBuilder
+		expectedCode int
+		expectedETag string
+		expectedBody string
+	}{
+		"computed strong etag without precondition": {
+			method:       http.MethodGet,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.AutoETag(false) },
+			expectedCode: http.StatusOK,
+			expectedETag: strongETag,
+			expectedBody: body,
+		},
+		"computed strong etag matching": {
+			method:       http.MethodGet,
+			ifNoneMatch:  `"other", ` + strongETag,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.AutoETag(false) },
+			expectedCode: http.StatusNotModified,
+			expectedETag: strongETag,
+		},
+		"computed weak etag matching strong validator": {
+			method:       http.MethodGet,
+			ifNoneMatch:  strongETag,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.AutoETag(true) },
+			expectedCode: http.StatusNotModified,
+			expectedETag: "W/" + strongETag,
+		},
+		"precomputed etag matching": {
+			method:       http.MethodHead,
+			ifNoneMatch:  `W/"v1"`,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.ETag("v1") },
+			expectedCode: http.StatusNotModified,
+			expectedETag: `"v1"`,
+		},
+		"precomputed etag not matching": {
+			method:       http.MethodGet,
+			ifNoneMatch:  `"v0"`,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.ETag(`W/"v1"`) },
+			expectedCode: http.StatusOK,
+			expectedETag: `W/"v1"`,
+			expectedBody: body,
+		},
+		"wildcard": {
+			method:       http.MethodGet,
+			ifNoneMatch:  "*",
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.ETag("v1") },
+			expectedCode: http.StatusNotModified,
+			expectedETag: `"v1"`,
+		},
+		"unsafe method": {
+			method:       http.MethodPost,
+			ifNoneMatch:  `"v1"`,
+			configure:    func(builder *ResponseBuilder) *ResponseBuilder { return builder.ETag("v1") },
+			expectedCode: http.StatusOK,
+			expectedETag: `"v1"`,
+			expectedBody: body,
+		},
+		"non-200 status": {
+			method:      http.MethodGet,
+			ifNoneMatch: `"v1"`,
+			configure: func(builder *ResponseBuilder) *ResponseBuilder {
+				return builder.ETag("v1").Status(http.StatusCreated)
+			},
+			expectedCode: http.StatusCreated,
+			expectedETag: `"v1"`,
+			expectedBody: body,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(testCase.method, "/", nil)
+				if testCase.ifNoneMatch != "" {
+					request.Header.Set("If-None-Match", testCase.ifNoneMatch)
+				}
+				recorder := httptest.NewRecorder()
+
+				builder := testCase.configure(NewResponseBuilder(recorder).WithRequest(request))
+				suite.Require().NoError(builder.Text().ContentString(body).Send())
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(testCase.expectedETag, recorder.Header().Get("ETag"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+				if testCase.expectedCode == http.StatusNotModified {
+					suite.Empty(recorder.Header().Get("Content-Type"))
+				}
+			},
+		)
+	}
+}
+
+func (suite *ETagSuite) TestItCanComputeETagOfJSONResponses() {
+	send := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("If-None-Match", ifNoneMatch)
+		recorder := httptest.NewRecorder()
+		err := NewResponseBuilder(recorder).
+			WithRequest(request).
+			AutoETag(false).
+			JSON().
+			Data(map[string]int{"id": 1}).
+			Send()
+		suite.Require().NoError(err)
+		return recorder
+	}
+
+	first := send("")
+	suite.Equal(http.StatusOK, first.Code)
+	suite.Equal("{\"id\":1}\n", first.Body.String())
+	suite.True(strings.HasPrefix(first.Header().Get("ETag"), `"`))
+
+	second := send(first.Header().Get("ETag"))
+	suite.Equal(http.StatusNotModified, second.Code)
+	suite.Zero(second.Body.Len())
+}
+
+func (suite *ETagSuite) TestItLetsFileResponsesHandlePreconditions() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("If-None-Match", `"v1"`)
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		ETag("v1").
+		File().
+		WithRequest(request).
+		Reader("notes.txt", strings.NewReader("content"), time.Time{}).
+		Send()
+
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusNotModified, recorder.Code)
+	suite.Equal(`"v1"`, recorder.Header().Get("ETag"))
+}
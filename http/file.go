@@ -0,0 +1,286 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// XAccelRedirect is the header offloading file responses to nginx, given an internal
+	// location URI
+	XAccelRedirect = "X-Accel-Redirect"
+	// XSendfile is the header offloading file responses to Apache (mod_xsendfile) and
+	// lighttpd, given the file path
+	XSendfile = "X-Sendfile"
+)
+
+var (
+	// ErrFileNotSeekable is returned when serving a file not implementing io.Seeker
+	ErrFileNotSeekable = errors.New("file does not implement io.Seeker")
+	// ErrFileIsDirectory is returned when serving a directory
+	ErrFileIsDirectory = errors.New("file is a directory")
+)
+
+// FileResponseBuilder serves files and downloads through http.ServeContent, which
+// answers range, conditional (If-Match, If-None-Match, If-Modified-Since, If-Range) and
+// HEAD requests. Files are given an entity tag derived from their size and modification
+// time unless one is set (see ETag). The Content-Type is the one set explicitly, else
+// guessed from the file name extension, else sniffed from the content. The status code
+// comes from http.ServeContent (200, 206, 304, 412 or 416).
+type FileResponseBuilder struct {
+	*ResponseBuilder
+	open          func() (servedFile, error)
+	disposition   string
+	filename      string
+	offloadHeader string
+	offloadTarget string
+}
+
+// servedFile is the content served by a file response; size is -1 when unknown
+type servedFile struct {
+	content io.ReadSeeker
+	name    string
+	modTime time.Time
+	size    int64
+}
+
+// SendFile serves the file at the given path of the local file system, see File
+func (rb *ResponseBuilder) SendFile(name string) error {
+	return rb.File().Path(name).Send()
+}
+
+// SendFileFS serves the named file of the file system, see File
+func (rb *ResponseBuilder) SendFileFS(fsys fs.FS, name string) error {
+	return rb.File().FS(fsys, name).Send()
+}
+
+// File creates a new file response builder
+func (rb *ResponseBuilder) File() *FileResponseBuilder {
+	return &FileResponseBuilder{ResponseBuilder: rb}
+}
+
+// WithRequest sets the request being answered, needed for ranges and conditional
+// requests. Without it, the file is served as to a plain GET request.
+func (frb *FileResponseBuilder) WithRequest(r *http.Request) *FileResponseBuilder {
+	frb.request = r
+	return frb
+}
+
+// Path serves the file at the given path of the local file system
+func (frb *FileResponseBuilder) Path(name string) *FileResponseBuilder {
+	frb.open = func() (servedFile, error) {
+		file, err := os.Open(name)
+		if err != nil {
+			return servedFile{}, err
+		}
+		return statFile(file)
+	}
+	return frb
+}
+
+// FS serves the named file of the file system
+func (frb *FileResponseBuilder) FS(fsys fs.FS, name string) *FileResponseBuilder {
+	frb.open = func() (servedFile, error) {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return servedFile{}, err
+		}
+		return statFile(file)
+	}
+	return frb
+}
+
+// Open serves an opened file, which must implement io.Seeker. The file is closed
+// once sent.
+func (frb *FileResponseBuilder) Open(file fs.File) *FileResponseBuilder {
+	frb.open = func() (servedFile, error) {
+		return statFile(file)
+	}
+	return frb
+}
+
+// Reader serves the content under the given name, used to guess the Content-Type and as
+// the download file name. A zero modTime disables Last-Modified handling. Content
+// implementing io.Closer is closed once sent.
+func (frb *FileResponseBuilder) Reader(name string, content io.ReadSeeker, modTime time.Time) *FileResponseBuilder {
+	frb.open = func() (servedFile, error) {
+		return servedFile{content: content, name: name, modTime: modTime, size: -1}, nil
+	}
+	return frb
+}
+
+// ContentType sets the Content-Type instead of detecting it
+func (frb *FileResponseBuilder) ContentType(contentType string) *FileResponseBuilder {
+	frb.Header("Content-Type", contentType)
+	return frb
+}
+
+// Inline asks browsers to display the file. An empty filename uses the file name.
+func (frb *FileResponseBuilder) Inline(filename string) *FileResponseBuilder {
+	frb.disposition = "inline"
+	frb.filename = filename
+	return frb
+}
+
+// Attachment asks browsers to download the file. An empty filename uses the file name.
+func (frb *FileResponseBuilder) Attachment(filename string) *FileResponseBuilder {
+	frb.disposition = "attachment"
+	frb.filename = filename
+	return frb
+}
+
+// Offload lets the front web server send the file: the response only carries the
+// header (typically XAccelRedirect or XSendfile) pointing at the target, an internal
+// location URI or a file path, and the server answers ranges and conditional requests.
+// The file is not opened; the Content-Type and download file name default to the ones
+// of the target.
+func (frb *FileResponseBuilder) Offload(header, target string) *FileResponseBuilder {
+	frb.offloadHeader = header
+	frb.offloadTarget = target
+	return frb
+}
+
+// Send serves the file. Errors opening the file are returned before anything is
+// written, so they can still be answered with an error response.
+func (frb *FileResponseBuilder) Send() error {
+	if frb.offloadHeader != "" {
+		return frb.sendOffloaded()
+	}
+	if frb.open == nil {
+		return fmt.Errorf("%w: no file to serve", fs.ErrInvalid)
+	}
+
+	file, err := frb.open()
+	if err != nil {
+		return err
+	}
+	if closer, ok := file.content.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	frb.setDisposition(file.name)
+	if _, ok := frb.headers["ETag"]; !ok && file.size >= 0 && !file.modTime.IsZero() {
+		frb.Header("ETag", fileETag(file.size, file.modTime))
+	}
+	ctx := frb.writeContext()
+	if err := aborted(ctx); err != nil {
+		return err
+	}
+	frb.applyHeaders()
+
+	writer := &contextWriter{ResponseWriter: frb.writer, ctx: ctx}
+	http.ServeContent(writer, frb.contentRequest(), file.name, file.modTime, file.content)
+	return writer.err
+}
+
+// sendOffloaded writes the bodiless response handing the file over to the web server
+func (frb *FileResponseBuilder) sendOffloaded() error {
+	if err := aborted(frb.writeContext()); err != nil {
+		return err
+	}
+
+	frb.setDisposition(frb.offloadTarget)
+	if _, ok := frb.headers["Content-Type"]; !ok {
+		if contentType := mime.TypeByExtension(path.Ext(frb.offloadTarget)); contentType != "" {
+			frb.Header("Content-Type", contentType)
+		}
+	}
+	frb.Header(frb.offloadHeader, frb.offloadTarget)
+	frb.writeHeaders()
+	return nil
+}
+
+// setDisposition sets the Content-Disposition header, naming the download after the
+// file when no file name was given
+func (frb *FileResponseBuilder) setDisposition(name string) {
+	if frb.disposition == "" {
+		return
+	}
+	filename := frb.filename
+	if filename == "" {
+		filename = path.Base(filepath.ToSlash(name))
+	}
+	frb.Header("Content-Disposition", ContentDisposition(frb.disposition, filename))
+}
+
+// fileETag returns a strong entity tag derived from the file size and modification time
+func fileETag(size int64, modTime time.Time) string {
+	return `"` + strconv.FormatInt(modTime.UnixNano(), 36) + "-" + strconv.FormatInt(size, 36) + `"`
+}
+
+// contentRequest returns the request given to http.ServeContent, a plain GET request
+// when the builder was not given one
+func (rb *ResponseBuilder) contentRequest() *http.Request {
+	if rb.request == nil {
+		return &http.Request{Method: http.MethodGet, Header: make(http.Header)}
+	}
+	return rb.request
+}
+
+// statFile returns the seekable content, name, modification time and size of the file,
+// closing it when it cannot be served
+func statFile(file fs.File) (servedFile, error) {
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return servedFile{}, err
+	}
+	if info.IsDir() {
+		_ = file.Close()
+		return servedFile{}, fmt.Errorf("%w: %s", ErrFileIsDirectory, info.Name())
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		_ = file.Close()
+		return servedFile{}, fmt.Errorf("%w: %s", ErrFileNotSeekable, info.Name())
+	}
+	return servedFile{content: content, name: info.Name(), modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// ContentDisposition formats a Content-Disposition header value of the given type
+// ("inline" or "attachment"). Names outside printable ASCII get an ASCII fallback
+// filename parameter followed by the RFC 5987 encoded filename* parameter.
+func ContentDisposition(dispositionType, filename string) string {
+	fallback := strings.Map(
+		func(r rune) rune {
+			if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+				return '_'
+			}
+			return r
+		}, filename,
+	)
+	value := dispositionType + `; filename="` + fallback + `"`
+	if fallback != filename {
+		value += "; filename*=UTF-8''" + encodeRFC5987(filename)
+	}
+	return value
+}
+
+// encodeRFC5987 percent-encodes every byte outside the RFC 5987 attr-char set
+func encodeRFC5987(value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+			strings.IndexByte("!#$&+-.^_`|~", c) >= 0 {
+			encoded.WriteByte(c)
+			continue
+		}
+		encoded.WriteByte('%')
+		encoded.WriteByte(hex[c>>4])
+		encoded.WriteByte(hex[c&0x0f])
+	}
+	return encoded.String()
+}
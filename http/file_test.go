@@ -0,0 +1,293 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileSuite struct {
+	suite.Suite
+}
+
+func TestFileSuite(t *testing.T) {
+	suite.Run(t, new(FileSuite))
+}
+
+func (suite *FileSuite) TestItCanServeFiles() {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"docs/report.pdf": {Data: []byte("%PDF-1.4 report"), ModTime: modTime},
+		"page":            {Data: []byte("<html><body>sniffed</body></html>"), ModTime: modTime},
+	}
+
+	testCases := map[string]struct {
+		build               func(builder *FileResponseBuilder) *FileResponseBuilder
+		rangeHeader         string
+		expectedCode        int
+		expectedType        string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		"type from extension": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.FS(fsys, "docs/report.pdf")
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "application/pdf",
+			expectedBody: "%PDF-1.4 report",
+		},
+		"sniffed type": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.FS(fsys, "page")
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "text/html; charset=utf-8",
+			expectedBody: "<html><body>sniffed</body></html>",
+		},
+		"explicit type": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.FS(fsys, "page").ContentType("application/octet-stream")
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "application/octet-stream",
+			expectedBody: "<html><body>sniffed</body></html>",
+		},
+		"attachment using file name": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.FS(fsys, "docs/report.pdf").Attachment("")
+			},
+			expectedCode:        http.StatusOK,
+			expectedType:        "application/pdf",
+			expectedDisposition: `attachment; filename="report.pdf"`,
+			expectedBody:        "%PDF-1.4 report",
+		},
+		"inline with unicode name": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.FS(fsys, "docs/report.pdf").Inline("raport größe.pdf")
+			},
+			expectedCode:        http.StatusOK,
+			expectedType:        "application/pdf",
+			expectedDisposition: `inline; filename="raport gr__e.pdf"; filename*=UTF-8''raport%20gr%C3%B6%C3%9Fe.pdf`,
+			expectedBody:        "%PDF-1.4 report",
+		},
+		"range": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.Reader("notes.txt", strings.NewReader("0123456789"), time.Time{})
+			},
+			rangeHeader:  "bytes=2-5",
+			expectedCode: http.StatusPartialContent,
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "2345",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/download", nil)
+				if testCase.rangeHeader != "" {
+					request.Header.Set("Range", testCase.rangeHeader)
+				}
+				recorder := httptest.NewRecorder()
+
+				builder := testCase.build(NewResponseBuilder(recorder).File().WithRequest(request))
+				suite.Require().NoError(builder.Send())
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(testCase.expectedType, recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedDisposition, recorder.Header().Get("Content-Disposition"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *FileSuite) TestItAnswersConditionalRequests() {
+	path := filepath.Join(suite.T().TempDir(), "style.css")
+	suite.Require().NoError(os.WriteFile(path, []byte("body {}"), 0o600))
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.Require().NoError(os.Chtimes(path, modTime, modTime))
+
+	request := httptest.NewRequest(http.MethodGet, "/style.css", nil)
+	request.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+	recorder := httptest.NewRecorder()
+
+	suite.Require().NoError(NewResponseBuilder(recorder).File().WithRequest(request).Path(path).Send())
+
+	suite.Equal(http.StatusNotModified, recorder.Code)
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *FileSuite) TestItAnswersEntityTagPreconditions() {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fsys := fstest.MapFS{"notes.txt": {Data: []byte("0123456789"), ModTime: modTime}}
+	etag := fileETag(10, modTime)
+
+	testCases := map[string]struct {
+		headers      map[string]string
+		expectedCode int
+		expectedBody string
+	}{
+		"plain": {
+			expectedCode: http.StatusOK,
+			expectedBody: "0123456789",
+		},
+		"if none match": {
+			headers:      map[string]string{"If-None-Match": etag},
+			expectedCode: http.StatusNotModified,
+		},
+		"if match failed": {
+			headers:      map[string]string{"If-Match": `"other"`},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		"if range matching": {
+			headers:      map[string]string{"Range": "bytes=0-1", "If-Range": etag},
+			expectedCode: http.StatusPartialContent,
+			expectedBody: "01",
+		},
+		"if range stale": {
+			headers:      map[string]string{"Range": "bytes=0-1", "If-Range": `"stale"`},
+			expectedCode: http.StatusOK,
+			expectedBody: "0123456789",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/notes.txt", nil)
+				for key, value := range testCase.headers {
+					request.Header.Set(key, value)
+				}
+				recorder := httptest.NewRecorder()
+
+				err := NewResponseBuilder(recorder).WithRequest(request).SendFileFS(fsys, "notes.txt")
+
+				suite.Require().NoError(err)
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(etag, recorder.Header().Get("ETag"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *FileSuite) TestItCanSendLocalFilesWithAnExplicitETag() {
+	path := filepath.Join(suite.T().TempDir(), "data.json")
+	suite.Require().NoError(os.WriteFile(path, []byte(`{}`), 0o600))
+	recorder := httptest.NewRecorder()
+
+	suite.Require().NoError(NewResponseBuilder(recorder).ETag("v1").SendFile(path))
+
+	suite.Equal(`"v1"`, recorder.Header().Get("ETag"))
+	suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+	suite.Equal(`{}`, recorder.Body.String())
+}
+
+func (suite *FileSuite) TestItCanOffloadFilesToTheWebServer() {
+	testCases := map[string]struct {
+		build               func(builder *FileResponseBuilder) *FileResponseBuilder
+		expectedHeader      string
+		expectedTarget      string
+		expectedType        string
+		expectedDisposition string
+	}{
+		"nginx": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.Offload(XAccelRedirect, "/protected/report.pdf").Attachment("")
+			},
+			expectedHeader:      XAccelRedirect,
+			expectedTarget:      "/protected/report.pdf",
+			expectedType:        "application/pdf",
+			expectedDisposition: `attachment; filename="report.pdf"`,
+		},
+		"sendfile with explicit type": {
+			build: func(builder *FileResponseBuilder) *FileResponseBuilder {
+				return builder.Path("/ignored").Offload(XSendfile, "/srv/files/data").ContentType("text/csv")
+			},
+			expectedHeader: XSendfile,
+			expectedTarget: "/srv/files/data",
+			expectedType:   "text/csv",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+
+				suite.Require().NoError(testCase.build(NewResponseBuilder(recorder).File()).Send())
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(testCase.expectedTarget, recorder.Header().Get(testCase.expectedHeader))
+				suite.Equal(testCase.expectedType, recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedDisposition, recorder.Header().Get("Content-Disposition"))
+				suite.Zero(recorder.Body.Len())
+			},
+		)
+	}
+}
+
+func (suite *FileSuite) TestItReturnsErrorsBeforeWriting() {
+	fsys := fstest.MapFS{"dir/file.txt": {Data: []byte("content")}}
+
+	testCases := map[string]struct {
+		builder     func(builder *FileResponseBuilder) *FileResponseBuilder
+		expectedErr error
+	}{
+		"missing file": {
+			builder:     func(builder *FileResponseBuilder) *FileResponseBuilder { return builder.FS(fsys, "none") },
+			expectedErr: os.ErrNotExist,
+		},
+		"directory": {
+			builder:     func(builder *FileResponseBuilder) *FileResponseBuilder { return builder.FS(fsys, "dir") },
+			expectedErr: ErrFileIsDirectory,
+		},
+		"no source": {
+			builder:     func(builder *FileResponseBuilder) *FileResponseBuilder { return builder },
+			expectedErr: os.ErrInvalid,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := testCase.builder(NewResponseBuilder(recorder).File()).Send()
+
+				suite.ErrorIs(err, testCase.expectedErr)
+				suite.False(recorder.Flushed)
+				suite.Empty(recorder.Header())
+				suite.Zero(recorder.Body.Len())
+			},
+		)
+	}
+}
+
+func (suite *FileSuite) TestItCanFormatContentDisposition() {
+	testCases := map[string]struct {
+		dispositionType string
+		filename        string
+		expected        string
+	}{
+		"ascii":   {"attachment", "report.pdf", `attachment; filename="report.pdf"`},
+		"quotes":  {"attachment", `a"b.txt`, `attachment; filename="a_b.txt"; filename*=UTF-8''a%22b.txt`},
+		"unicode": {"inline", "日本.txt", `inline; filename="__.txt"; filename*=UTF-8''%E6%97%A5%E6%9C%AC.txt`},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(testCase.expected, ContentDisposition(testCase.dispositionType, testCase.filename))
+			},
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+)
+
+// BeforeSendHook runs just before a response builder writes its headers. It may still
+// change the status code, headers and cookies of the response.
+type BeforeSendHook func(rb *ResponseBuilder)
+
+var globalBeforeSendHooks struct {
+	sync.RWMutex
+	hooks []BeforeSendHook
+}
+
+// OnBeforeSend registers a hook run by every response builder before its own hooks,
+// for cross-cutting concerns such as request ID headers or metrics. Register global
+// hooks during startup; they cannot be removed.
+func OnBeforeSend(hook BeforeSendHook) {
+	globalBeforeSendHooks.Lock()
+	defer globalBeforeSendHooks.Unlock()
+	globalBeforeSendHooks.hooks = append(globalBeforeSendHooks.hooks, hook)
+}
+
+// OnBeforeSend registers a hook run just before this builder writes its headers, after
+// the global hooks, in registration order
+func (rb *ResponseBuilder) OnBeforeSend(hook BeforeSendHook) *ResponseBuilder {
+	rb.beforeSend = append(rb.beforeSend, hook)
+	return rb
+}
+
+// Request returns the request set with WithRequest, or nil
+func (rb *ResponseBuilder) Request() *http.Request {
+	return rb.request
+}
+
+// StatusCode returns the status code the response will be sent with
+func (rb *ResponseBuilder) StatusCode() int {
+	return rb.statusCode
+}
+
+// runBeforeSendHooks runs the global hooks, then the builder hooks
+func (rb *ResponseBuilder) runBeforeSendHooks() {
+	globalBeforeSendHooks.RLock()
+	hooks := globalBeforeSendHooks.hooks
+	globalBeforeSendHooks.RUnlock()
+
+	for _, hook := range hooks {
+		hook(rb)
+	}
+	for _, hook := range rb.beforeSend {
+		hook(rb)
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HooksSuite struct {
+	suite.Suite
+	hooks []BeforeSendHook
+}
+
+func TestHooksSuite(t *testing.T) {
+	suite.Run(t, new(HooksSuite))
+}
+
+func (suite *HooksSuite) SetupTest() {
+	suite.hooks = globalBeforeSendHooks.hooks
+}
+
+func (suite *HooksSuite) TearDownTest() {
+	globalBeforeSendHooks.hooks = suite.hooks
+}
+
+func (suite *HooksSuite) TestItRunsHooksBeforeSending() {
+	testCases := map[string]struct {
+		send func(builder *ResponseBuilder) error
+	}{
+		"json": {
+			send: func(builder *ResponseBuilder) error { return builder.JSON().Data("ok").Send() },
+		},
+		"text with etag": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.AutoETag(false).Text().ContentString("ok").Send()
+			},
+		},
+		"error": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Error().WithError(errors.New("failed")).DisableLogging().Send()
+			},
+		},
+		"file": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.File().Reader("a.txt", strings.NewReader("ok"), time.Time{}).Send()
+			},
+		},
+		"binary": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Binary().Reader(strings.NewReader("ok"), 2).Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				globalBeforeSendHooks.hooks = nil
+				var calls []string
+				OnBeforeSend(
+					func(rb *ResponseBuilder) {
+						calls = append(calls, "global")
+						rb.Header("X-Request-ID", rb.Request().Header.Get("X-Request-ID"))
+					},
+				)
+
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("X-Request-ID", "req-1")
+				builder := NewResponseBuilder(recorder).
+					WithRequest(request).
+					OnBeforeSend(
+						func(rb *ResponseBuilder) {
+							calls = append(calls, "builder")
+							rb.Header("X-Status", http.StatusText(rb.StatusCode()))
+						},
+					)
+
+				suite.Require().NoError(testCase.send(builder))
+
+				suite.Equal([]string{"global", "builder"}, calls)
+				suite.Equal("req-1", recorder.Header().Get("X-Request-ID"))
+				suite.NotEmpty(recorder.Header().Get("X-Status"))
+			},
+		)
+	}
+}
+
+func (suite *HooksSuite) TestItLetsHooksChangeTheStatus() {
+	recorder := httptest.NewRecorder()
+	builder := NewResponseBuilder(recorder).
+		OnBeforeSend(func(rb *ResponseBuilder) { rb.Status(http.StatusAccepted) })
+
+	suite.Require().NoError(builder.JSON().Data("ok").Send())
+	suite.Equal(http.StatusAccepted, recorder.Code)
+}
+
+func (suite *HooksSuite) TestItClearsHooksOnReset() {
+	called := false
+	builder := NewResponseBuilder(httptest.NewRecorder()).
+		OnBeforeSend(func(*ResponseBuilder) { called = true })
+
+	builder.Reset(httptest.NewRecorder())
+	suite.Require().NoError(builder.Text().ContentString("ok").Send())
+	suite.False(called)
+}
@@ -0,0 +1,288 @@
+package httperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPError represents an error with an associated HTTP status code.
+type HTTPError interface {
+	error
+	StatusCode() int
+}
+
+// CodedError is an error carrying a machine-readable code, rendered by structured error
+// bodies so clients can branch on codes instead of messages
+type CodedError interface {
+	error
+	ErrorCode() string
+}
+
+// DetailedError is an error carrying details rendered by structured error bodies
+type DetailedError interface {
+	error
+	ErrorDetails() map[string]interface{}
+}
+
+// ErrorCategory represents a category of errors with a default status code.
+type ErrorCategory struct {
+	StatusCode int
+	name       string
+	checkFuncs []func(error) bool
+	logEnabled bool
+	logLevel   slog.Level
+	headers    map[string]string
+	onMatch    []func(ctx context.Context, err error, r *http.Request)
+
+	problemType  string
+	problemTitle string
+	code         string
+}
+
+func NewErrorCategory(statusCode int) *ErrorCategory {
+	return &ErrorCategory{
+		StatusCode: statusCode,
+		checkFuncs: make([]func(error) bool, 0),
+		logEnabled: true, // default: log errors of this category
+		logLevel:   slog.LevelError,
+		headers:    make(map[string]string),
+	}
+}
+
+func (ec *ErrorCategory) AddSentinelError(e error) {
+	ec.checkFuncs = append(
+		ec.checkFuncs, func(err error) bool {
+			return errors.Is(err, e)
+		},
+	)
+}
+
+func (ec *ErrorCategory) Matches(err error) bool {
+	for _, check := range ec.checkFuncs {
+		if check(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithName sets the name identifying this category in error logs and returns the
+// category for chaining
+func (ec *ErrorCategory) WithName(name string) *ErrorCategory {
+	ec.name = name
+	return ec
+}
+
+// Name returns the name of this category, empty when unset
+func (ec *ErrorCategory) Name() string { return ec.name }
+
+// WithLogging enables or disables logging for this category and returns the category for chaining
+func (ec *ErrorCategory) WithLogging(enabled bool) *ErrorCategory {
+	ec.logEnabled = enabled
+	return ec
+}
+
+// DisableLogging disables logging for this error category and returns the category for chaining
+func (ec *ErrorCategory) DisableLogging() *ErrorCategory { return ec.WithLogging(false) }
+
+// EnableLogging enables logging for this error category and returns the category for chaining
+func (ec *ErrorCategory) EnableLogging() *ErrorCategory { return ec.WithLogging(true) }
+
+// IsLoggingEnabled returns whether logging is enabled for this category
+func (ec *ErrorCategory) IsLoggingEnabled() bool { return ec.logEnabled }
+
+// WithLogLevel sets the level errors of this category are logged at (default: error)
+// and returns the category for chaining, e.g. slog.LevelInfo for expected business
+// errors such as not found or validation failures
+func (ec *ErrorCategory) WithLogLevel(level slog.Level) *ErrorCategory {
+	ec.logLevel = level
+	return ec
+}
+
+// LogLevel returns the level errors of this category are logged at
+func (ec *ErrorCategory) LogLevel() slog.Level { return ec.logLevel }
+
+// WithHeader adds a response header emitted when the category matches and returns the
+// category for chaining. Headers explicitly set on the response builder take precedence.
+func (ec *ErrorCategory) WithHeader(key, value string) *ErrorCategory {
+	ec.headers[http.CanonicalHeaderKey(key)] = value
+	return ec
+}
+
+// WithRetryAfter sets the Retry-After header (in seconds, rounded up) emitted when the
+// category matches, typically for 429 and 503 responses
+func (ec *ErrorCategory) WithRetryAfter(delay time.Duration) *ErrorCategory {
+	seconds := int((delay + time.Second - 1) / time.Second)
+	return ec.WithHeader("Retry-After", strconv.Itoa(seconds))
+}
+
+// WithProblemType sets the type URI and title of the RFC 7807 problem details rendered
+// for errors of this category (e.g. "https://example.com/problems/out-of-stock", "Out of
+// stock") and returns the category for chaining. An empty title keeps the status text.
+func (ec *ErrorCategory) WithProblemType(typeURI, title string) *ErrorCategory {
+	ec.problemType = typeURI
+	ec.problemTitle = title
+	return ec
+}
+
+// ProblemType returns the problem type URI and title of this category, empty when unset
+func (ec *ErrorCategory) ProblemType() (typeURI, title string) {
+	return ec.problemType, ec.problemTitle
+}
+
+// WithCode sets the machine-readable code rendered for errors of this category that do
+// not implement CodedError, and returns the category for chaining
+func (ec *ErrorCategory) WithCode(code string) *ErrorCategory {
+	ec.code = code
+	return ec
+}
+
+// Code returns the machine-readable code of this category, empty when unset
+func (ec *ErrorCategory) Code() string {
+	return ec.code
+}
+
+// ErrorCode returns the code of the first CodedError in the error chain, falling back to
+// the category code. The category may be nil.
+func ErrorCode(err error, category *ErrorCategory) string {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		if code := coded.ErrorCode(); code != "" {
+			return code
+		}
+	}
+	if category != nil {
+		return category.code
+	}
+	return ""
+}
+
+// ErrorDetails returns the details of the first DetailedError in the error chain, or nil
+func ErrorDetails(err error) map[string]interface{} {
+	var detailed DetailedError
+	if errors.As(err, &detailed) {
+		return detailed.ErrorDetails()
+	}
+	return nil
+}
+
+// OnMatch registers a hook called whenever an error is classified by this category,
+// e.g. to increment metrics, emit domain events or trigger alerts. The request is nil
+// when the response builder was not given one. Returns the category for chaining.
+func (ec *ErrorCategory) OnMatch(
+	hook func(ctx context.Context, err error, r *http.Request),
+) *ErrorCategory {
+	ec.onMatch = append(ec.onMatch, hook)
+	return ec
+}
+
+// NotifyMatch runs the registered OnMatch hooks, called by the error renderers once
+// an error was classified by this category
+func (ec *ErrorCategory) NotifyMatch(ctx context.Context, err error, r *http.Request) {
+	for _, hook := range ec.onMatch {
+		hook(ctx, err, r)
+	}
+}
+
+// Headers returns a copy of the response headers attached to this category
+func (ec *ErrorCategory) Headers() map[string]string {
+	headers := make(map[string]string, len(ec.headers))
+	for key, value := range ec.headers {
+		headers[key] = value
+	}
+	return headers
+}
+
+func AddErrorType[T error](ec *ErrorCategory) {
+	ec.checkFuncs = append(
+		ec.checkFuncs, func(err error) bool {
+			var target T
+			return errors.As(err, &target)
+		},
+	)
+}
+
+// Classify returns the status code for an error implementing HTTPError, or the
+// status code and first matching category, or 422 for unmatched FieldErrors.
+// ok is false when nothing matched.
+func Classify(err error, categories []*ErrorCategory) (statusCode int, category *ErrorCategory, ok bool) {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode(), nil, true
+	}
+
+	for _, category := range categories {
+		if category.Matches(err) {
+			return category.StatusCode, category, true
+		}
+	}
+
+	var fieldErrs FieldErrors
+	if errors.As(err, &fieldErrs) {
+		return http.StatusUnprocessableEntity, nil, true
+	}
+
+	return 0, nil, false
+}
+
+// PanicError wraps a value recovered from a panic together with the stack captured
+// at recovery time, so panics can flow through the regular error pipeline
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error returns the panic description
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap exposes panics raised with an error value to errors.Is and errors.As,
+// which lets error categories classify them
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// FieldErrors reports input validation failures as a map of field name to messages.
+// Unless an error category maps it otherwise, it is classified as 422 Unprocessable
+// Entity and structured error bodies render the per-field details.
+type FieldErrors map[string][]string
+
+// Add appends a message for the field and returns the errors for chaining
+func (fe FieldErrors) Add(field, message string) FieldErrors {
+	fe[field] = append(fe[field], message)
+	return fe
+}
+
+// Err returns the field errors as an error, or nil when no field failed validation
+func (fe FieldErrors) Err() error {
+	if len(fe) == 0 {
+		return nil
+	}
+	return fe
+}
+
+// Error lists the failed fields in a stable order
+func (fe FieldErrors) Error() string {
+	fields := make([]string, 0, len(fe))
+	for field := range fe {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, field+": "+strings.Join(fe[field], ", "))
+	}
+	return "validation failed: " + strings.Join(details, "; ")
+}
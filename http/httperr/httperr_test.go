@@ -0,0 +1,139 @@
+package httperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPErrSuite struct {
+	suite.Suite
+}
+
+func TestHTTPErrSuite(t *testing.T) {
+	suite.Run(t, new(HTTPErrSuite))
+}
+
+type conflictError struct{}
+
+func (conflictError) Error() string   { return "conflict" }
+func (conflictError) StatusCode() int { return http.StatusConflict }
+
+type validationError struct{}
+
+func (validationError) Error() string { return "invalid" }
+
+type outOfStockError struct{ sku string }
+
+func (e outOfStockError) Error() string     { return "out of stock" }
+func (e outOfStockError) ErrorCode() string { return "OUT_OF_STOCK" }
+func (e outOfStockError) ErrorDetails() map[string]interface{} {
+	return map[string]interface{}{"sku": e.sku}
+}
+
+var errMissing = errors.New("missing")
+
+func (suite *HTTPErrSuite) TestItCanClassifyErrors() {
+	notFound := NewErrorCategory(http.StatusNotFound)
+	notFound.AddSentinelError(errMissing)
+	badRequest := NewErrorCategory(http.StatusBadRequest)
+	AddErrorType[validationError](badRequest)
+	categories := []*ErrorCategory{notFound, badRequest}
+
+	testCases := map[string]struct {
+		err              error
+		expectedStatus   int
+		expectedCategory *ErrorCategory
+		expectedOk       bool
+	}{
+		"http error":          {conflictError{}, http.StatusConflict, nil, true},
+		"wrapped sentinel":    {fmt.Errorf("load: %w", errMissing), http.StatusNotFound, notFound, true},
+		"wrapped type":        {fmt.Errorf("save: %w", validationError{}), http.StatusBadRequest, badRequest, true},
+		"panic with sentinel": {&PanicError{Value: errMissing}, http.StatusNotFound, notFound, true},
+		"unknown":             {errors.New("other"), 0, nil, false},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				status, category, ok := Classify(testCase.err, categories)
+				suite.Equal(testCase.expectedStatus, status)
+				suite.Equal(testCase.expectedCategory, category)
+				suite.Equal(testCase.expectedOk, ok)
+			},
+		)
+	}
+}
+
+func (suite *HTTPErrSuite) TestItCanNotifyMatchHooks() {
+	var notified []error
+	category := NewErrorCategory(http.StatusNotFound).
+		OnMatch(func(ctx context.Context, err error, r *http.Request) { notified = append(notified, err) })
+
+	category.NotifyMatch(context.Background(), errMissing, nil)
+
+	suite.Equal([]error{errMissing}, notified)
+}
+
+func (suite *HTTPErrSuite) TestItCanDescribeProblemTypes() {
+	category := NewErrorCategory(http.StatusConflict)
+	typeURI, title := category.ProblemType()
+	suite.Empty(typeURI)
+	suite.Empty(title)
+
+	typeURI, title = category.WithProblemType("https://example.com/problems/conflict", "Conflict").ProblemType()
+	suite.Equal("https://example.com/problems/conflict", typeURI)
+	suite.Equal("Conflict", title)
+}
+
+func (suite *HTTPErrSuite) TestItCanSetTheLogLevel() {
+	category := NewErrorCategory(http.StatusNotFound)
+	suite.Equal(slog.LevelError, category.LogLevel())
+	suite.Equal(slog.LevelInfo, category.WithLogLevel(slog.LevelInfo).LogLevel())
+}
+
+func (suite *HTTPErrSuite) TestItCanResolveErrorCodesAndDetails() {
+	category := NewErrorCategory(http.StatusNotFound).WithCode("NOT_FOUND")
+	coded := fmt.Errorf("ordering: %w", outOfStockError{sku: "A-1"})
+
+	suite.Equal("NOT_FOUND", category.Code())
+	suite.Equal("OUT_OF_STOCK", ErrorCode(coded, category))
+	suite.Equal("NOT_FOUND", ErrorCode(errMissing, category))
+	suite.Empty(ErrorCode(errMissing, nil))
+	suite.Equal(map[string]interface{}{"sku": "A-1"}, ErrorDetails(coded))
+	suite.Nil(ErrorDetails(errMissing))
+}
+
+func (suite *HTTPErrSuite) TestPanicErrorDescribesRecoveredValue() {
+	suite.Equal("panic: boom", (&PanicError{Value: "boom"}).Error())
+	suite.Nil((&PanicError{Value: "boom"}).Unwrap())
+	suite.ErrorIs(&PanicError{Value: errMissing}, errMissing)
+}
+
+func (suite *HTTPErrSuite) TestFieldErrorsCollectMessagesPerField() {
+	fieldErrs := FieldErrors{}
+	suite.Nil(fieldErrs.Err())
+
+	fieldErrs.Add("name", "is required").Add("email", "is invalid").Add("email", "is taken")
+
+	suite.Equal(
+		"validation failed: email: is invalid, is taken; name: is required",
+		fieldErrs.Err().Error(),
+	)
+
+	status, category, ok := Classify(fmt.Errorf("signup: %w", fieldErrs), nil)
+	suite.Equal(http.StatusUnprocessableEntity, status)
+	suite.Nil(category)
+	suite.True(ok)
+
+	badRequest := NewErrorCategory(http.StatusBadRequest)
+	AddErrorType[FieldErrors](badRequest)
+	status, category, _ = Classify(fieldErrs, []*ErrorCategory{badRequest})
+	suite.Equal(http.StatusBadRequest, status)
+	suite.Equal(badRequest, category)
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonAPIError is a JSON:API error object
+type jsonAPIError struct {
+	ID     string                 `json:"id,omitempty"`
+	Status string                 `json:"status"`
+	Code   string                 `json:"code,omitempty"`
+	Title  string                 `json:"title"`
+	Detail string                 `json:"detail,omitempty"`
+	Source *jsonAPIErrorSource    `json:"source,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// jsonAPIErrorSource references the request document member causing the error
+type jsonAPIErrorSource struct {
+	Pointer string `json:"pointer"`
+}
+
+// AsJSONAPI configures the error response to be a JSON:API errors document
+// (application/vnd.api+json). The id member is the request ID, field errors become one
+// error object per message pointing at the field ("/data/attributes/<field>", or the
+// field itself when it is already a JSON pointer). Error details go in the meta member.
+func (erb *ErrorResponseBuilder) AsJSONAPI() *ErrorResponseBuilder {
+	erb.Header("Content-Type", "application/vnd.api+json")
+	erb.format = ErrorBodyJSONAPI
+	return erb
+}
+
+// jsonAPIErrors builds the JSON:API errors document
+func (erb *ErrorResponseBuilder) jsonAPIErrors(
+	message string,
+	statusCode int,
+	category *ErrorCategory,
+	code string,
+	details map[string]interface{},
+	fieldErrs FieldErrors,
+	debug *errorDebugInfo,
+) map[string]interface{} {
+	_, title := erb.problemTypeAndTitle(category, statusCode)
+	base := jsonAPIError{
+		ID:     erb.requestID,
+		Status: strconv.Itoa(statusCode),
+		Code:   code,
+		Title:  title,
+		Detail: message,
+	}
+	if details != nil || debug != nil {
+		base.Meta = make(map[string]interface{}, 2)
+	}
+	if details != nil {
+		base.Meta["details"] = details
+	}
+	if debug != nil {
+		base.Meta["debug"] = debug
+	}
+
+	if len(fieldErrs) == 0 {
+		return map[string]interface{}{"errors": []jsonAPIError{base}}
+	}
+
+	fields := make([]string, 0, len(fieldErrs))
+	for field := range fieldErrs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var errs []jsonAPIError
+	for _, field := range fields {
+		pointer := field
+		if !strings.HasPrefix(pointer, "/") {
+			pointer = "/data/attributes/" + field
+		}
+		for _, fieldMessage := range fieldErrs[field] {
+			fieldErr := base
+			fieldErr.Detail = fieldMessage
+			fieldErr.Source = &jsonAPIErrorSource{Pointer: pointer}
+			errs = append(errs, fieldErr)
+		}
+	}
+	return map[string]interface{}{"errors": errs}
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// PrettyJSONOptions configures the pretty printing of JSON responses not given an
+// explicit Indent
+//
+// Indent: indentation of pretty printed responses ("" = pretty printing disabled)
+// Always: pretty print every response, typically enabled in development
+// QueryParam: query parameter asking for a pretty printed response when present without
+// a value or with a true value such as "1" ("" = disabled); it needs the request, see
+// ResponseBuilder.WithRequest
+type PrettyJSONOptions struct {
+	Indent     string
+	Always     bool
+	QueryParam string
+}
+
+// DefaultPrettyJSON is the pretty printing configuration of JSON responses. It is meant
+// to be set once at startup, e.g. with Always enabled in development.
+var DefaultPrettyJSON = PrettyJSONOptions{Indent: "  ", QueryParam: "pretty"}
+
+// Indent pretty prints the response with the given indentation, regardless of
+// DefaultPrettyJSON. An empty indentation forces a compact response.
+func (jrb *JSONResponseBuilder) Indent(indent string) *JSONResponseBuilder {
+	jrb.indent = &indent
+	return jrb
+}
+
+// indentation returns the indentation of the response, "" for a compact one
+func (jrb *JSONResponseBuilder) indentation() string {
+	if jrb.indent != nil {
+		return *jrb.indent
+	}
+
+	options := DefaultPrettyJSON
+	if options.Always {
+		return options.Indent
+	}
+	if options.QueryParam == "" || jrb.request == nil {
+		return ""
+	}
+
+	query := jrb.request.URL.Query()
+	if !query.Has(options.QueryParam) {
+		return ""
+	}
+	value := query.Get(options.QueryParam)
+	if enabled, err := strconv.ParseBool(value); value != "" && (err != nil || !enabled) {
+		return ""
+	}
+	return options.Indent
+}
+
+// newEncoder returns a JSON encoder honoring the response indentation
+func (jrb *JSONResponseBuilder) newEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	if indent := jrb.indentation(); indent != "" {
+		encoder.SetIndent("", indent)
+	}
+	return encoder
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONIndentSuite struct {
+	suite.Suite
+	defaults PrettyJSONOptions
+}
+
+func TestJSONIndentSuite(t *testing.T) {
+	suite.Run(t, new(JSONIndentSuite))
+}
+
+func (suite *JSONIndentSuite) SetupTest() {
+	suite.defaults = DefaultPrettyJSON
+}
+
+func (suite *JSONIndentSuite) TearDownTest() {
+	DefaultPrettyJSON = suite.defaults
+}
+
+func (suite *JSONIndentSuite) TestItCanPrettyPrintResponses() {
+	const compact = "{\"a\":1}\n"
+	const pretty = "{\n  \"a\": 1\n}\n"
+	twoSpaces, none := "  ", ""
+
+	testCases := map[string]struct {
+		target   string
+		defaults PrettyJSONOptions
+		indent   *string
+		expected string
+	}{
+		"compact by default":          {target: "/", defaults: DefaultPrettyJSON, expected: compact},
+		"query parameter":             {target: "/?pretty=1", defaults: DefaultPrettyJSON, expected: pretty},
+		"query parameter no value":    {target: "/?pretty", defaults: DefaultPrettyJSON, expected: pretty},
+		"query parameter false":       {target: "/?pretty=false", defaults: DefaultPrettyJSON, expected: compact},
+		"query parameter disabled":    {target: "/?pretty=1", defaults: PrettyJSONOptions{Indent: "  "}, expected: compact},
+		"always":                      {target: "/", defaults: PrettyJSONOptions{Indent: "  ", Always: true}, expected: pretty},
+		"explicit indent":             {target: "/", defaults: PrettyJSONOptions{}, indent: &twoSpaces, expected: pretty},
+		"explicit compact":            {target: "/?pretty=1", defaults: DefaultPrettyJSON, indent: &none, expected: compact},
+		"explicit indent with always": {target: "/", defaults: PrettyJSONOptions{Indent: "\t", Always: true}, indent: &twoSpaces, expected: pretty},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				DefaultPrettyJSON = testCase.defaults
+				recorder := httptest.NewRecorder()
+
+				builder := NewResponseBuilder(recorder).
+					WithRequest(httptest.NewRequest(http.MethodGet, testCase.target, nil)).
+					JSON().
+					Data(map[string]int{"a": 1})
+				if testCase.indent != nil {
+					builder.Indent(*testCase.indent)
+				}
+
+				suite.Require().NoError(builder.Send())
+				suite.Equal(testCase.expected, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *JSONIndentSuite) TestItCanPrettyPrintStreams() {
+	testCases := map[string]struct {
+		values   []int
+		expected string
+	}{
+		"empty":  {values: []int{}, expected: "[]\n"},
+		"values": {values: []int{1, 2}, expected: "[\n  1,\n  2\n]\n"},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := NewResponseBuilder(recorder).
+					JSON().
+					Indent("  ").
+					Stream(SeqValues(slices.Values(testCase.values))).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Equal(testCase.expected, recorder.Body.String())
+			},
+		)
+	}
+
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).
+		JSON().
+		Indent("  ").
+		Stream(SeqValues(slices.Values([]map[string]int{{"a": 1}}))).
+		Send()
+
+	suite.Require().NoError(err)
+	suite.Equal("[\n  {\n    \"a\": 1\n  }\n]\n", recorder.Body.String())
+}
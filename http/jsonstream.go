@@ -0,0 +1,85 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"iter"
+	"time"
+)
+
+// Stream sends the values as a JSON array encoded element by element, instead of the
+// data, so large exports never hold the whole payload in memory. The response is
+// committed once streaming starts: an encoding error or a done context (reported as a
+// WriteAbortedError) truncates the array, leaving the client with invalid JSON, and is
+// returned by Send.
+func (jrb *JSONResponseBuilder) Stream(values iter.Seq[any]) *JSONResponseBuilder {
+	jrb.stream = values
+	return jrb
+}
+
+// WithContext ties a streamed response to the context, so streaming stops once the
+// client disconnected. It defaults to the request context when a request was set.
+func (jrb *JSONResponseBuilder) WithContext(ctx context.Context) *JSONResponseBuilder {
+	jrb.ctx = ctx
+	return jrb
+}
+
+// FlushInterval batches the streamed elements, flushing them at most once per interval
+// instead of after every element
+func (jrb *JSONResponseBuilder) FlushInterval(interval time.Duration) *JSONResponseBuilder {
+	jrb.flushInterval = interval
+	return jrb
+}
+
+// sendStream writes the streamed values as a JSON array
+func (jrb *JSONResponseBuilder) sendStream() error {
+	ctx := jrb.ctx
+	if ctx == nil {
+		ctx = jrb.writeContext()
+	}
+	if err := aborted(ctx); err != nil {
+		return err
+	}
+
+	// Pretty printed elements go on their own line, indented one level
+	indent := jrb.indentation()
+	var element bytes.Buffer
+	encoder := json.NewEncoder(&element)
+	encoder.SetIndent(indent, indent)
+	count := 0
+
+	return newValueStream(ctx, jrb.writer, jrb.flushInterval).run(
+		jrb.stream,
+		func() error {
+			jrb.writeHeaders()
+			_, err := jrb.writer.Write([]byte("["))
+			return err
+		},
+		func(index int, value any) error {
+			element.Reset()
+			if index > 0 {
+				element.WriteByte(',')
+			}
+			if indent != "" {
+				element.WriteString("\n" + indent)
+			}
+			count++
+			if err := encoder.Encode(value); err != nil {
+				return err
+			}
+			// Encode terminates every value with a newline
+			element.Truncate(element.Len() - 1)
+			_, err := element.WriteTo(jrb.writer)
+			return err
+		},
+		func() error {
+			closing := "]\n"
+			if indent != "" && count > 0 {
+				closing = "\n" + closing
+			}
+			_, err := jrb.writer.Write([]byte(closing))
+			return err
+		},
+	)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONStreamSuite struct {
+	suite.Suite
+}
+
+func TestJSONStreamSuite(t *testing.T) {
+	suite.Run(t, new(JSONStreamSuite))
+}
+
+func (suite *JSONStreamSuite) TestItCanStreamArrays() {
+	testCases := map[string]struct {
+		values        iter.Seq[any]
+		flushInterval time.Duration
+		expectedBody  string
+	}{
+		"empty": {
+			values:       SeqValues(slices.Values([]int{})),
+			expectedBody: "[]\n",
+		},
+		"values": {
+			values:       SeqValues(slices.Values([]map[string]int{{"id": 1}, {"id": 2}})),
+			expectedBody: "[{\"id\":1},{\"id\":2}]\n",
+		},
+		"batched flushes": {
+			values:        SeqValues(slices.Values([]string{"a", "<b>"})),
+			flushInterval: time.Hour,
+			expectedBody:  "[\"a\",\"\\u003cb\\u003e\"]\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := NewResponseBuilder(recorder).
+					JSON().
+					Stream(testCase.values).
+					FlushInterval(testCase.flushInterval).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+				suite.True(recorder.Flushed)
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+				suite.True(json.Valid(recorder.Body.Bytes()))
+			},
+		)
+	}
+}
+
+func (suite *JSONStreamSuite) TestItStopsStreamingOnErrors() {
+	ctx, cancel := context.WithCancel(context.Background())
+	values := func(yield func(any) bool) {
+		if yield(1) {
+			cancel()
+			yield(2)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).JSON().WithContext(ctx).Stream(values).Send()
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal("[1", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	err = NewResponseBuilder(recorder).JSON().Stream(SeqValues(slices.Values([]any{1, func() {}}))).Send()
+
+	suite.Error(err)
+	suite.Equal("[1", recorder.Body.String())
+}
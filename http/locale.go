@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type localeContextKey struct{}
+
+// WithLocale returns a context carrying the locale (a BCP 47 language tag such as
+// "en" or "pt-BR") selected for the request
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored in the context or an empty string
+func LocaleFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+	return locale
+}
+
+// NegotiateLanguage returns the supported language tag best matching the request
+// Accept-Language header, honoring quality values. A range matches the tags it prefixes
+// ("en" matches "en-US") and falls back to the base language of the tag ("en-US"
+// matches "en"); "*" matches any tag. The most specific matching range determines the
+// quality of a tag (exact, then prefix, then base language fallback, then "*"), so
+// "en;q=0, *" excludes "en". Ties are resolved in favor of the earliest supported tag.
+// The boolean is false when the header is missing or nothing matched.
+func NegotiateLanguage(r *http.Request, supported ...string) (string, bool) {
+	ranges := parseQualityValues(r.Header.Values("Accept-Language"))
+
+	best, bestQuality := "", 0.0
+	for _, tag := range supported {
+		// The most specific matching range determines the tag quality
+		quality, specificity := 0.0, -1
+		for _, languageRange := range ranges {
+			if s := languageSpecificity(languageRange.value, tag); s > specificity {
+				quality, specificity = languageRange.quality, s
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = tag, quality
+		}
+	}
+
+	return best, bestQuality > 0
+}
+
+// languageSpecificity ranks how precisely the language range matches the tag, -1 when
+// it does not
+func languageSpecificity(languageRange, tag string) int {
+	switch {
+	case strings.EqualFold(languageRange, tag):
+		return 3
+	case hasPrefixFold(tag, languageRange+"-"):
+		return 2
+	case hasPrefixFold(languageRange, tag+"-"):
+		return 1
+	case languageRange == "*":
+		return 0
+	default:
+		return -1
+	}
+}
+
+// hasPrefixFold is strings.HasPrefix ignoring case
+func hasPrefixFold(value, prefix string) bool {
+	return len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix)
+}
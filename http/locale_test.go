@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocaleSuite struct {
+	suite.Suite
+}
+
+func TestLocaleSuite(t *testing.T) {
+	suite.Run(t, new(LocaleSuite))
+}
+
+func (suite *LocaleSuite) TestItCanStoreTheLocaleInTheContext() {
+	suite.Empty(LocaleFromContext(context.Background()))
+	suite.Equal("pt-BR", LocaleFromContext(WithLocale(context.Background(), "pt-BR")))
+}
+
+func (suite *LocaleSuite) TestItCanNegotiateLanguages() {
+	supported := []string{"en", "fr", "pt-BR"}
+
+	testCases := map[string]struct {
+		acceptLanguage string
+		expected       string
+		expectedOk     bool
+	}{
+		"missing header":         {"", "", false},
+		"exact match":            {"fr", "fr", true},
+		"case insensitive":       {"PT-br", "pt-BR", true},
+		"regional range":         {"en-GB", "en", true},
+		"base range":             {"pt", "pt-BR", true},
+		"quality preference":     {"en;q=0.4, fr;q=0.8", "fr", true},
+		"wildcard":               {"de, *;q=0.1", "en", true},
+		"refused":                {"fr;q=0, de", "", false},
+		"tie keeps offer order":  {"fr, en", "en", true},
+		"excluded over wildcard": {"en;q=0, *", "fr", true},
+		"excluded over base":     {"pt-BR;q=0, pt, en;q=0.5", "en", true},
+		"regional over excluded": {"pt;q=0, pt-BR", "pt-BR", true},
+		"exact over fallback":    {"en-GB;q=0, en;q=0.8", "en", true},
+		"wildcard excluded":      {"*;q=0, fr", "fr", true},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("Accept-Language", testCase.acceptLanguage)
+
+				negotiated, ok := NegotiateLanguage(request, supported...)
+
+				suite.Equal(testCase.expected, negotiated)
+				suite.Equal(testCase.expectedOk, ok)
+			},
+		)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Memory is a Recorder keeping the measurements in memory, e.g. to assert the recorded
+// metrics in tests
+type Memory struct {
+	mu           sync.Mutex
+	values       map[string]float64
+	observations map[string][]float64
+}
+
+// NewMemory creates a new in-memory recorder
+func NewMemory() *Memory {
+	return &Memory{
+		values:       make(map[string]float64),
+		observations: make(map[string][]float64),
+	}
+}
+
+// AddCounter implements Recorder
+func (m *Memory) AddCounter(name string, value float64, labels Labels) {
+	m.add(name, value, labels)
+}
+
+// AddGauge implements Recorder
+func (m *Memory) AddGauge(name string, delta float64, labels Labels) {
+	m.add(name, delta, labels)
+}
+
+// ObserveHistogram implements Recorder
+func (m *Memory) ObserveHistogram(name string, value float64, labels Labels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := seriesKey(name, labels)
+	m.observations[key] = append(m.observations[key], value)
+}
+
+// Value returns the current value of a counter or gauge series
+func (m *Memory) Value(name string, labels Labels) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.values[seriesKey(name, labels)]
+}
+
+// Observations returns the values observed by a histogram series
+func (m *Memory) Observations(name string, labels Labels) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.observations[seriesKey(name, labels)])
+}
+
+func (m *Memory) add(name string, value float64, labels Labels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[seriesKey(name, labels)] += value
+}
+
+// seriesKey identifies a series by metric name and sorted labels
+func seriesKey(name string, labels Labels) string {
+	var key strings.Builder
+	key.WriteString(name)
+	for _, labelName := range slices.Sorted(maps.Keys(labels)) {
+		key.WriteString("|" + labelName + "=" + labels[labelName])
+	}
+	return key.String()
+}
@@ -0,0 +1,73 @@
+// Package metrics defines the Recorder the router, middlewares and session manager
+// report their metrics through, keeping the instrumentation independent of the metrics
+// backend. The prometheus and otel subpackages provide the Recorder implementations.
+package metrics
+
+// Names of the metrics recorded by this module
+const (
+	// RequestsTotal counts the served requests (labels: method, route, status)
+	RequestsTotal = "http_requests_total"
+	// RequestDuration observes the request durations in seconds (labels: method, route)
+	RequestDuration = "http_request_duration_seconds"
+	// ResponseSize observes the response body sizes in bytes (labels: method, route)
+	ResponseSize = "http_response_size_bytes"
+	// RequestsInFlight gauges the requests being served
+	RequestsInFlight = "http_requests_in_flight"
+	// RouteRequestsInFlight gauges the requests being served per route (labels: route)
+	RouteRequestsInFlight = "http_route_requests_in_flight"
+	// TimeoutsTotal counts the requests answered by the timeout middleware (labels: method)
+	TimeoutsTotal = "http_request_timeouts_total"
+	// RateLimitedTotal counts the requests rejected by the rate limiter (labels: method)
+	RateLimitedTotal = "http_rate_limited_requests_total"
+	// SessionGCDuration observes the session garbage collection runs in seconds
+	// (labels: result, "success" or "error")
+	SessionGCDuration = "session_gc_duration_seconds"
+)
+
+// descriptions documents the metrics recorded by this module
+var descriptions = map[string]string{
+	RequestsTotal:         "Number of served HTTP requests.",
+	RequestDuration:       "Duration of the served HTTP requests in seconds.",
+	ResponseSize:          "Size of the HTTP response bodies in bytes.",
+	RequestsInFlight:      "Number of HTTP requests being served.",
+	RouteRequestsInFlight: "Number of HTTP requests being served per route.",
+	TimeoutsTotal:         "Number of HTTP requests answered with a timeout response.",
+	RateLimitedTotal:      "Number of HTTP requests rejected by the rate limiter.",
+	SessionGCDuration:     "Duration of the session garbage collection runs in seconds.",
+}
+
+// Description returns the help text of a metric recorded by this module, or the name
+// itself for other metrics
+func Description(name string) string {
+	if description, ok := descriptions[name]; ok {
+		return description
+	}
+	return name
+}
+
+// Labels are the dimensions of a measurement. Keep their values bounded (e.g. route
+// patterns rather than raw paths), as each combination is a distinct series.
+type Labels map[string]string
+
+// Recorder records measurements by metric name. A metric must always be recorded with
+// the same kind and label names. Implementations must be fast and safe for concurrent
+// use, as they are called on the request path.
+type Recorder interface {
+	// AddCounter increases a monotonic counter by a non-negative value
+	AddCounter(name string, value float64, labels Labels)
+	// AddGauge moves a gauge up or down by delta, e.g. +1 and -1 around in-flight work
+	AddGauge(name string, delta float64, labels Labels)
+	// ObserveHistogram records a value into a distribution, e.g. a duration
+	ObserveHistogram(name string, value float64, labels Labels)
+}
+
+// Discard is a Recorder ignoring every measurement
+var Discard Recorder = discard{}
+
+type discard struct{}
+
+func (discard) AddCounter(string, float64, Labels) {}
+
+func (discard) AddGauge(string, float64, Labels) {}
+
+func (discard) ObserveHistogram(string, float64, Labels) {}
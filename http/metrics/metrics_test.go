@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (suite *MetricsSuite) TestItCanDescribeMetrics() {
+	testCases := map[string]struct {
+		name                string
+		expectedDescription string
+	}{
+		"module metric": {
+			name:                RequestsTotal,
+			expectedDescription: "Number of served HTTP requests.",
+		},
+		"other metric": {
+			name:                "orders_total",
+			expectedDescription: "orders_total",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(testCase.expectedDescription, Description(testCase.name))
+			},
+		)
+	}
+}
+
+func (suite *MetricsSuite) TestItCanRecordInMemory() {
+	recorder := NewMemory()
+	get := Labels{"method": "GET"}
+
+	recorder.AddCounter(RequestsTotal, 1, get)
+	recorder.AddCounter(RequestsTotal, 2, Labels{"method": "GET"})
+	recorder.AddCounter(RequestsTotal, 1, Labels{"method": "POST"})
+	recorder.AddGauge("in_flight", 1, nil)
+	recorder.AddGauge("in_flight", -1, nil)
+	recorder.ObserveHistogram(RequestDuration, 0.5, get)
+	recorder.ObserveHistogram(RequestDuration, 1.5, get)
+
+	suite.Equal(3.0, recorder.Value(RequestsTotal, get))
+	suite.Equal(1.0, recorder.Value(RequestsTotal, Labels{"method": "POST"}))
+	suite.Zero(recorder.Value("in_flight", nil))
+	suite.Equal([]float64{0.5, 1.5}, recorder.Observations(RequestDuration, get))
+	suite.Empty(recorder.Observations(RequestDuration, Labels{"method": "POST"}))
+}
+
+func (suite *MetricsSuite) TestDiscardIgnoresMeasurements() {
+	suite.NotPanics(
+		func() {
+			Discard.AddCounter(RequestsTotal, 1, nil)
+			Discard.AddGauge("in_flight", 1, nil)
+			Discard.ObserveHistogram(RequestDuration, 1, nil)
+		},
+	)
+}
@@ -0,0 +1,115 @@
+// Package otel implements metrics.Recorder with the OpenTelemetry metrics API. It is a
+// separate module, github.com/golibry/go-http/http/metrics/otel, keeping OpenTelemetry
+// out of the dependencies of the root module.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golibry/go-http/http/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// Recorder creates a float64 counter, up-down counter or histogram instrument on the
+// first measurement of each metric. Names ending in "_seconds" or "_bytes" get the "s"
+// and "By" units.
+type Recorder struct {
+	meter      otelmetric.Meter
+	mu         sync.RWMutex
+	counters   map[string]otelmetric.Float64Counter
+	gauges     map[string]otelmetric.Float64UpDownCounter
+	histograms map[string]otelmetric.Float64Histogram
+}
+
+// NewRecorder creates a new OpenTelemetry recorder creating its instruments with the
+// meter, e.g. otel.Meter("github.com/golibry/go-http")
+func NewRecorder(meter otelmetric.Meter) *Recorder {
+	return &Recorder{
+		meter:      meter,
+		counters:   make(map[string]otelmetric.Float64Counter),
+		gauges:     make(map[string]otelmetric.Float64UpDownCounter),
+		histograms: make(map[string]otelmetric.Float64Histogram),
+	}
+}
+
+// AddCounter implements metrics.Recorder
+func (r *Recorder) AddCounter(name string, value float64, labels metrics.Labels) {
+	counter := instrument(r, r.counters, name, r.meter.Float64Counter)
+	counter.Add(context.Background(), value, otelmetric.WithAttributes(attributes(labels)...))
+}
+
+// AddGauge implements metrics.Recorder
+func (r *Recorder) AddGauge(name string, delta float64, labels metrics.Labels) {
+	gauge := instrument(r, r.gauges, name, r.meter.Float64UpDownCounter)
+	gauge.Add(context.Background(), delta, otelmetric.WithAttributes(attributes(labels)...))
+}
+
+// ObserveHistogram implements metrics.Recorder
+func (r *Recorder) ObserveHistogram(name string, value float64, labels metrics.Labels) {
+	histogram := instrument(r, r.histograms, name, r.meter.Float64Histogram)
+	histogram.Record(
+		context.Background(),
+		value,
+		otelmetric.WithAttributes(attributes(labels)...),
+	)
+}
+
+// instrument returns the instrument of the metric, creating it on first use. The meter
+// returns a no-op instrument alongside a creation error, which is kept as well once the
+// error is reported to the OpenTelemetry error handler (see otel.SetErrorHandler).
+func instrument[I any, O any](
+	r *Recorder,
+	instruments map[string]I,
+	name string,
+	create func(name string, options ...O) (I, error),
+) I {
+	r.mu.RLock()
+	instrument, ok := instruments[name]
+	r.mu.RUnlock()
+	if ok {
+		return instrument
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if instrument, ok = instruments[name]; ok {
+		return instrument
+	}
+
+	options := []otelmetric.InstrumentOption{
+		otelmetric.WithDescription(metrics.Description(name)),
+	}
+	switch {
+	case strings.HasSuffix(name, "_seconds"):
+		options = append(options, otelmetric.WithUnit("s"))
+	case strings.HasSuffix(name, "_bytes"):
+		options = append(options, otelmetric.WithUnit("By"))
+	}
+	typedOptions := make([]O, 0, len(options))
+	for _, option := range options {
+		if typed, ok := any(option).(O); ok {
+			typedOptions = append(typedOptions, typed)
+		}
+	}
+
+	instrument, err := create(name, typedOptions...)
+	if err != nil {
+		otel.Handle(fmt.Errorf("metric %s: %w", name, err))
+	}
+	instruments[name] = instrument
+	return instrument
+}
+
+// attributes converts the labels to OpenTelemetry attributes
+func attributes(labels metrics.Labels) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(labels))
+	for key, value := range labels {
+		kvs = append(kvs, attribute.String(key, value))
+	}
+	return kvs
+}
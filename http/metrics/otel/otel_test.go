@@ -0,0 +1,72 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golibry/go-http/http/metrics"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type OtelSuite struct {
+	suite.Suite
+}
+
+func TestOtelSuite(t *testing.T) {
+	suite.Run(t, new(OtelSuite))
+}
+
+func (suite *OtelSuite) TestItRecordsMeasurements() {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	recorder := NewRecorder(provider.Meter("test"))
+
+	recorder.AddCounter(metrics.RequestsTotal, 1, metrics.Labels{"method": "GET"})
+	recorder.AddCounter(metrics.RequestsTotal, 2, metrics.Labels{"method": "GET"})
+	recorder.AddGauge("in_flight", 3, nil)
+	recorder.AddGauge("in_flight", -1, nil)
+	recorder.ObserveHistogram(metrics.RequestDuration, 0.2, metrics.Labels{"method": "GET"})
+
+	var collected metricdata.ResourceMetrics
+	suite.Require().NoError(reader.Collect(context.Background(), &collected))
+	suite.Require().Len(collected.ScopeMetrics, 1)
+
+	byName := make(map[string]metricdata.Metrics)
+	for _, metric := range collected.ScopeMetrics[0].Metrics {
+		byName[metric.Name] = metric
+	}
+
+	requests := byName[metrics.RequestsTotal]
+	suite.Equal(metrics.Description(metrics.RequestsTotal), requests.Description)
+	requestPoints := requests.Data.(metricdata.Sum[float64]).DataPoints
+	suite.Require().Len(requestPoints, 1)
+	suite.Equal(3.0, requestPoints[0].Value)
+	method, _ := requestPoints[0].Attributes.Value(attribute.Key("method"))
+	suite.Equal("GET", method.AsString())
+
+	inFlight := byName["in_flight"].Data.(metricdata.Sum[float64])
+	suite.False(inFlight.IsMonotonic)
+	suite.Equal(2.0, inFlight.DataPoints[0].Value)
+
+	duration := byName[metrics.RequestDuration]
+	suite.Equal("s", duration.Unit)
+	suite.Equal(uint64(1), duration.Data.(metricdata.Histogram[float64]).DataPoints[0].Count)
+}
+
+func (suite *OtelSuite) TestItReportsInstrumentCreationErrors() {
+	var reported []error
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	defer otel.SetErrorHandler(previous)
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	recorder := NewRecorder(provider.Meter("test"))
+
+	suite.NotPanics(func() { recorder.AddCounter("invalid name!", 1, nil) })
+	suite.Require().Len(reported, 1)
+	suite.ErrorContains(reported[0], "metric invalid name!")
+}
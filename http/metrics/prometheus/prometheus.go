@@ -0,0 +1,190 @@
+// Package prometheus implements metrics.Recorder with the Prometheus client library. It
+// is a separate module, github.com/golibry/go-http/http/metrics/prometheus, keeping the
+// client library out of the dependencies of the root module.
+package prometheus
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/golibry/go-http/http/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Recorder registers a counter, gauge or histogram vector on the first measurement of
+// each metric, labeled with the label names of that measurement. Measurements with
+// other label names are dropped, as Prometheus requires fixed label names per metric,
+// and reported like registration failures: to the logger, or to stderr without one.
+type Recorder struct {
+	options    Options
+	mu         sync.RWMutex
+	counters   map[string]*prom.CounterVec
+	gauges     map[string]*prom.GaugeVec
+	histograms map[string]*prom.HistogramVec
+}
+
+// Options configures the Prometheus recorder
+//
+// Registerer: registry the metrics are registered with (default: prom.DefaultRegisterer)
+// Namespace: optional prefix of the metric names, e.g. the service name
+// Buckets: histogram buckets (default: prom.DefBuckets)
+// BucketsByName: histogram buckets of specific metrics, e.g. metrics.ResponseSize
+// Logger: receives the measurements that could not be recorded (optional)
+type Options struct {
+	Registerer    prom.Registerer
+	Logger        *slog.Logger
+	Namespace     string
+	Buckets       []float64
+	BucketsByName map[string][]float64
+}
+
+// NewRecorder creates a new Prometheus recorder
+func NewRecorder(options Options) *Recorder {
+	if options.Registerer == nil {
+		options.Registerer = prom.DefaultRegisterer
+	}
+	if options.Buckets == nil {
+		options.Buckets = prom.DefBuckets
+	}
+	return &Recorder{
+		options:    options,
+		counters:   make(map[string]*prom.CounterVec),
+		gauges:     make(map[string]*prom.GaugeVec),
+		histograms: make(map[string]*prom.HistogramVec),
+	}
+}
+
+// AddCounter implements metrics.Recorder
+func (r *Recorder) AddCounter(name string, value float64, labels metrics.Labels) {
+	vec, err := vector(
+		r, r.counters, name, labels, func(opts prom.Opts, labelNames []string) *prom.CounterVec {
+			return prom.NewCounterVec(prom.CounterOpts(opts), labelNames)
+		},
+	)
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	counter, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	counter.Add(value)
+}
+
+// AddGauge implements metrics.Recorder
+func (r *Recorder) AddGauge(name string, delta float64, labels metrics.Labels) {
+	vec, err := vector(
+		r, r.gauges, name, labels, func(opts prom.Opts, labelNames []string) *prom.GaugeVec {
+			return prom.NewGaugeVec(prom.GaugeOpts(opts), labelNames)
+		},
+	)
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	gauge, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	gauge.Add(delta)
+}
+
+// ObserveHistogram implements metrics.Recorder
+func (r *Recorder) ObserveHistogram(name string, value float64, labels metrics.Labels) {
+	vec, err := vector(
+		r, r.histograms, name, labels,
+		func(opts prom.Opts, labelNames []string) *prom.HistogramVec {
+			buckets := r.options.Buckets
+			if byName, ok := r.options.BucketsByName[name]; ok {
+				buckets = byName
+			}
+			return prom.NewHistogramVec(
+				prom.HistogramOpts{
+					Namespace: opts.Namespace,
+					Name:      opts.Name,
+					Help:      opts.Help,
+					Buckets:   buckets,
+				},
+				labelNames,
+			)
+		},
+	)
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	histogram, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		r.report(name, err)
+		return
+	}
+	histogram.Observe(value)
+}
+
+// vector returns the vector of the metric, creating and registering it on first use.
+// A vector already registered by another recorder on the same registry is reused, a
+// collector of another kind registered under the name is an error.
+func vector[V prom.Collector](
+	r *Recorder,
+	vectors map[string]V,
+	name string,
+	labels metrics.Labels,
+	create func(opts prom.Opts, labelNames []string) V,
+) (V, error) {
+	r.mu.RLock()
+	vec, ok := vectors[name]
+	r.mu.RUnlock()
+	if ok {
+		return vec, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if vec, ok = vectors[name]; ok {
+		return vec, nil
+	}
+
+	labelNames := make([]string, 0, len(labels))
+	for labelName := range labels {
+		labelNames = append(labelNames, labelName)
+	}
+	slices.Sort(labelNames)
+
+	vec = create(
+		prom.Opts{Namespace: r.options.Namespace, Name: name, Help: metrics.Description(name)},
+		labelNames,
+	)
+	if err := r.options.Registerer.Register(vec); err != nil {
+		var registered prom.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			return vec, err
+		}
+		existing, ok := registered.ExistingCollector.(V)
+		if !ok {
+			return vec, fmt.Errorf(
+				"already registered as %T, not %T", registered.ExistingCollector, vec,
+			)
+		}
+		vec = existing
+	}
+	vectors[name] = vec
+	return vec, nil
+}
+
+// report logs a measurement that could not be recorded
+func (r *Recorder) report(name string, err error) {
+	if r.options.Logger != nil {
+		r.options.Logger.Error(
+			"Failed to record metric", slog.String("metric", name), slog.String("error", err.Error()),
+		)
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Failed to record metric %s: %v\n", name, err)
+}
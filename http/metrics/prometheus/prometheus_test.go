@@ -0,0 +1,141 @@
+package prometheus
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/golibry/go-http/http/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusSuite struct {
+	suite.Suite
+}
+
+func TestPrometheusSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusSuite))
+}
+
+// gather returns the gathered metric families by name
+func (suite *PrometheusSuite) gather(registry *prom.Registry) map[string]*dto.MetricFamily {
+	families, err := registry.Gather()
+	suite.Require().NoError(err)
+
+	byName := make(map[string]*dto.MetricFamily)
+	for _, family := range families {
+		byName[family.GetName()] = family
+	}
+	return byName
+}
+
+func (suite *PrometheusSuite) TestItRecordsMeasurements() {
+	registry := prom.NewRegistry()
+	recorder := NewRecorder(Options{Registerer: registry, Namespace: "shop"})
+
+	recorder.AddCounter(metrics.RequestsTotal, 1, metrics.Labels{"method": "GET", "route": "/"})
+	recorder.AddCounter(metrics.RequestsTotal, 2, metrics.Labels{"method": "GET", "route": "/"})
+	recorder.AddGauge("in_flight", 3, nil)
+	recorder.AddGauge("in_flight", -1, nil)
+	recorder.ObserveHistogram(metrics.RequestDuration, 0.2, metrics.Labels{"method": "GET"})
+
+	families := suite.gather(registry)
+
+	requests := families["shop_http_requests_total"]
+	suite.Require().NotNil(requests)
+	suite.Equal(metrics.Description(metrics.RequestsTotal), requests.GetHelp())
+	suite.Equal(3.0, requests.GetMetric()[0].GetCounter().GetValue())
+	suite.Len(requests.GetMetric()[0].GetLabel(), 2)
+
+	suite.Equal(2.0, families["shop_in_flight"].GetMetric()[0].GetGauge().GetValue())
+
+	duration := families["shop_http_request_duration_seconds"].GetMetric()[0].GetHistogram()
+	suite.Equal(uint64(1), duration.GetSampleCount())
+	suite.Len(duration.GetBucket(), len(prom.DefBuckets))
+}
+
+func (suite *PrometheusSuite) TestItCanUseBucketsPerMetric() {
+	registry := prom.NewRegistry()
+	recorder := NewRecorder(
+		Options{
+			Registerer:    registry,
+			BucketsByName: map[string][]float64{metrics.ResponseSize: {100, 1000}},
+		},
+	)
+
+	recorder.ObserveHistogram(metrics.ResponseSize, 512, nil)
+
+	histogram := suite.gather(registry)["http_response_size_bytes"].GetMetric()[0].GetHistogram()
+	suite.Len(histogram.GetBucket(), 2)
+	suite.Equal(uint64(1), histogram.GetBucket()[1].GetCumulativeCount())
+}
+
+func (suite *PrometheusSuite) TestItReportsMeasurementsWithOtherLabels() {
+	registry := prom.NewRegistry()
+	output := new(bytes.Buffer)
+	recorder := NewRecorder(
+		Options{Registerer: registry, Logger: slog.New(slog.NewJSONHandler(output, nil))},
+	)
+
+	recorder.AddCounter(metrics.TimeoutsTotal, 1, metrics.Labels{"method": "GET"})
+	recorder.AddCounter(metrics.TimeoutsTotal, 1, metrics.Labels{"path": "/orders"})
+
+	timeouts := suite.gather(registry)["http_request_timeouts_total"]
+	suite.Len(timeouts.GetMetric(), 1)
+	suite.Equal(1.0, timeouts.GetMetric()[0].GetCounter().GetValue())
+	suite.Contains(output.String(), `"msg":"Failed to record metric"`)
+	suite.Contains(output.String(), `"metric":"`+metrics.TimeoutsTotal+`"`)
+}
+
+func (suite *PrometheusSuite) TestItReportsRegistrationFailures() {
+	testCases := map[string]struct {
+		existing      prom.Collector
+		expectedError string
+	}{
+		"collector of another kind": {
+			existing: prom.NewGauge(
+				prom.GaugeOpts{
+					Name: metrics.RateLimitedTotal,
+					Help: metrics.Description(metrics.RateLimitedTotal),
+				},
+			),
+			expectedError: "already registered as",
+		},
+		"conflicting help": {
+			existing: prom.NewCounterVec(
+				prom.CounterOpts{Name: metrics.RateLimitedTotal, Help: "other"}, nil,
+			),
+			expectedError: "different help string",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				registry := prom.NewRegistry()
+				registry.MustRegister(testCase.existing)
+				output := new(bytes.Buffer)
+				recorder := NewRecorder(
+					Options{Registerer: registry, Logger: slog.New(slog.NewJSONHandler(output, nil))},
+				)
+
+				suite.NotPanics(func() { recorder.AddCounter(metrics.RateLimitedTotal, 1, nil) })
+
+				suite.Contains(output.String(), `"msg":"Failed to record metric"`)
+				suite.Contains(output.String(), testCase.expectedError)
+			},
+		)
+	}
+}
+
+func (suite *PrometheusSuite) TestItSharesMetricsAcrossRecorders() {
+	registry := prom.NewRegistry()
+
+	NewRecorder(Options{Registerer: registry}).AddCounter(metrics.RateLimitedTotal, 1, nil)
+	NewRecorder(Options{Registerer: registry}).AddCounter(metrics.RateLimitedTotal, 1, nil)
+
+	rateLimited := suite.gather(registry)["http_rate_limited_requests_total"]
+	suite.Equal(2.0, rateLimited.GetMetric()[0].GetCounter().GetValue())
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"time"
+)
+
+// NDJSONResponseBuilder streams values as newline delimited JSON (application/x-ndjson),
+// one JSON document per line, for log tailing and export endpoints. By default every
+// line is flushed to the client as soon as it is written; see FlushInterval.
+type NDJSONResponseBuilder struct {
+	*ResponseBuilder
+	ctx           context.Context
+	values        iter.Seq[any]
+	flushInterval time.Duration
+}
+
+// NDJSON creates a new NDJSON streaming response builder
+func (rb *ResponseBuilder) NDJSON() *NDJSONResponseBuilder {
+	rb.Header("Content-Type", "application/x-ndjson")
+	return &NDJSONResponseBuilder{ResponseBuilder: rb}
+}
+
+// WithContext ties the stream to the context, so streaming stops once the client
+// disconnected. It defaults to the request context when a request was set.
+func (nrb *NDJSONResponseBuilder) WithContext(ctx context.Context) *NDJSONResponseBuilder {
+	nrb.ctx = ctx
+	return nrb
+}
+
+// Values sets the streamed values; see ChannelValues and SeqValues to adapt channels
+// and typed iterators
+func (nrb *NDJSONResponseBuilder) Values(values iter.Seq[any]) *NDJSONResponseBuilder {
+	nrb.values = values
+	return nrb
+}
+
+// FlushInterval batches lines, flushing them at most once per interval instead of after
+// every line. Pending lines are flushed when the interval elapses, even while waiting
+// for the next value.
+func (nrb *NDJSONResponseBuilder) FlushInterval(interval time.Duration) *NDJSONResponseBuilder {
+	nrb.flushInterval = interval
+	return nrb
+}
+
+// Send writes the headers and streams every value until the values are exhausted or
+// the context is done, returning a WriteAbortedError in the latter case
+func (nrb *NDJSONResponseBuilder) Send() error {
+	ctx := nrb.ctx
+	if ctx == nil {
+		ctx = nrb.writeContext()
+	}
+	if err := aborted(ctx); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(nrb.writer)
+	return newValueStream(ctx, nrb.writer, nrb.flushInterval).run(
+		nrb.values,
+		func() error {
+			nrb.writeHeaders()
+			return nil
+		},
+		func(_ int, value any) error { return encoder.Encode(value) },
+		nil,
+	)
+}
+
+// ChannelValues adapts a channel to NDJSONResponseBuilder.Values and
+// JSONResponseBuilder.Stream. The sequence ends when the channel is closed or the
+// context is done.
+func ChannelValues[T any](ctx context.Context, values <-chan T) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok := <-values:
+				if !ok || !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqValues adapts a typed iterator to NDJSONResponseBuilder.Values and
+// JSONResponseBuilder.Stream
+func SeqValues[T any](values iter.Seq[T]) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NDJSONSuite struct {
+	suite.Suite
+}
+
+func TestNDJSONSuite(t *testing.T) {
+	suite.Run(t, new(NDJSONSuite))
+}
+
+type ndjsonRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (suite *NDJSONSuite) TestItCanStreamValues() {
+	records := []ndjsonRecord{{1, "first"}, {2, "second"}}
+
+	testCases := map[string]struct {
+		values        func() iter.Seq[any]
+		flushInterval time.Duration
+	}{
+		"typed iterator": {
+			values: func() iter.Seq[any] { return SeqValues(slices.Values(records)) },
+		},
+		"channel": {
+			values: func() iter.Seq[any] {
+				channel := make(chan ndjsonRecord, len(records))
+				for _, record := range records {
+					channel <- record
+				}
+				close(channel)
+				return ChannelValues(context.Background(), channel)
+			},
+		},
+		"batched flushes": {
+			values:        func() iter.Seq[any] { return SeqValues(slices.Values(records)) },
+			flushInterval: time.Hour,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := NewResponseBuilder(recorder).
+					NDJSON().
+					Values(testCase.values()).
+					FlushInterval(testCase.flushInterval).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal("application/x-ndjson", recorder.Header().Get("Content-Type"))
+				suite.True(recorder.Flushed)
+				suite.Equal(
+					"{\"id\":1,\"name\":\"first\"}\n{\"id\":2,\"name\":\"second\"}\n",
+					recorder.Body.String(),
+				)
+			},
+		)
+	}
+}
+
+func (suite *NDJSONSuite) TestItStopsOnceTheContextIsDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := make(chan int, 1)
+	channel <- 1
+
+	recorder := httptest.NewRecorder()
+	done := make(chan error)
+	go func() {
+		done <- NewResponseBuilder(recorder).NDJSON().WithContext(ctx).Values(ChannelValues(ctx, channel)).Send()
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		suite.ErrorIs(err, context.Canceled)
+		suite.Equal("1\n", recorder.Body.String())
+	case <-time.After(time.Second):
+		suite.Fail("stream did not stop")
+	}
+}
+
+func (suite *NDJSONSuite) TestItFlushesPendingLinesPeriodically() {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				values := func(yield func(any) bool) {
+					if yield(map[string]int{"n": 1}) {
+						<-r.Context().Done()
+					}
+				}
+				_ = NewResponseBuilder(w).
+					NDJSON().
+					WithContext(r.Context()).
+					Values(values).
+					FlushInterval(5 * time.Millisecond).
+					Send()
+			},
+		),
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.Require().NoError(err)
+	defer func() { _ = response.Body.Close() }()
+
+	buffer := make([]byte, len("{\"n\":1}\n"))
+	_, err = io.ReadFull(response.Body, buffer)
+	suite.Require().NoError(err)
+	suite.Equal("{\"n\":1}\n", string(buffer))
+}
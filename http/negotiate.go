@@ -0,0 +1,146 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Offer is a representation a negotiated response can be rendered as
+//
+// ContentType: media type matched against the Accept header and sent as Content-Type
+// Render: writes the data in that representation
+type Offer struct {
+	ContentType string
+	Render      func(w io.Writer, data interface{}) error
+}
+
+// OfferJSON renders the data as JSON
+func OfferJSON() Offer {
+	return Offer{
+		ContentType: "application/json",
+		Render: func(w io.Writer, data interface{}) error {
+			return json.NewEncoder(w).Encode(data)
+		},
+	}
+}
+
+// OfferXML renders the data as XML, preceded by the XML declaration
+func OfferXML() Offer {
+	return Offer{
+		ContentType: "application/xml; charset=utf-8",
+		Render: func(w io.Writer, data interface{}) error {
+			if _, err := io.WriteString(w, xml.Header); err != nil {
+				return err
+			}
+			return xml.NewEncoder(w).Encode(data)
+		},
+	}
+}
+
+// OfferText renders the data as plain text, formatted like fmt.Print except for byte
+// slices written as is
+func OfferText() Offer {
+	return Offer{
+		ContentType: "text/plain; charset=utf-8",
+		Render: func(w io.Writer, data interface{}) error {
+			if content, ok := data.([]byte); ok {
+				_, err := w.Write(content)
+				return err
+			}
+			_, err := fmt.Fprint(w, data)
+			return err
+		},
+	}
+}
+
+// OfferTemplate renders the data as HTML with the named template of the renderer
+// (DefaultTemplateRenderer when nil), wrapped by the renderer default layout
+func OfferTemplate(renderer *TemplateRenderer, name string) Offer {
+	return Offer{
+		ContentType: "text/html; charset=utf-8",
+		Render: func(w io.Writer, data interface{}) error {
+			if renderer == nil {
+				renderer = DefaultTemplateRenderer
+			}
+			return renderer.Render(w, name, "", data)
+		},
+	}
+}
+
+// NegotiatedResponseBuilder renders the data in the offered representation best matching
+// the request Accept header (see NegotiateContentType), adding Accept to the Vary header
+type NegotiatedResponseBuilder struct {
+	*ResponseBuilder
+	offers   []Offer
+	fallback *Offer
+	data     interface{}
+}
+
+// Negotiate creates a new negotiated response builder for the request
+func (rb *ResponseBuilder) Negotiate(r *http.Request) *NegotiatedResponseBuilder {
+	rb.WithRequest(r)
+	rb.Vary("Accept")
+	return &NegotiatedResponseBuilder{ResponseBuilder: rb}
+}
+
+// Offer adds representations, in order of preference
+func (nrb *NegotiatedResponseBuilder) Offer(offers ...Offer) *NegotiatedResponseBuilder {
+	nrb.offers = append(nrb.offers, offers...)
+	return nrb
+}
+
+// Fallback sets the representation used when no offer is acceptable, instead of
+// failing with NotAcceptableError
+func (nrb *NegotiatedResponseBuilder) Fallback(offer Offer) *NegotiatedResponseBuilder {
+	nrb.fallback = &offer
+	return nrb
+}
+
+// Data sets the rendered data
+func (nrb *NegotiatedResponseBuilder) Data(data interface{}) *NegotiatedResponseBuilder {
+	nrb.data = data
+	return nrb
+}
+
+// Send renders and writes the response. Without an acceptable offer nor fallback, a
+// NotAcceptableError is returned before anything is written, so it can be answered
+// with a 406 error response. Rendering errors are returned the same way.
+func (nrb *NegotiatedResponseBuilder) Send() error {
+	offer, ok := nrb.selectOffer()
+	if !ok {
+		return NotAcceptableError{Accept: strings.Join(nrb.request.Header.Values("Accept"), ", ")}
+	}
+
+	var body bytes.Buffer
+	if err := offer.Render(&body, nrb.data); err != nil {
+		return err
+	}
+
+	nrb.Header("Content-Type", offer.ContentType)
+	return nrb.writeBody(body.Bytes())
+}
+
+// selectOffer returns the offer matching the Accept header best, else the fallback
+func (nrb *NegotiatedResponseBuilder) selectOffer() (Offer, bool) {
+	contentTypes := make([]string, len(nrb.offers))
+	for i, offer := range nrb.offers {
+		contentTypes[i] = offer.ContentType
+	}
+
+	if contentType, ok := NegotiateContentType(nrb.request, contentTypes...); ok {
+		for _, offer := range nrb.offers {
+			if offer.ContentType == contentType {
+				return offer, true
+			}
+		}
+	}
+	if nrb.fallback != nil {
+		return *nrb.fallback, true
+	}
+	return Offer{}, false
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"encoding/xml"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NegotiateSuite struct {
+	suite.Suite
+}
+
+func TestNegotiateSuite(t *testing.T) {
+	suite.Run(t, new(NegotiateSuite))
+}
+
+type negotiatedUser struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func (user negotiatedUser) String() string {
+	return "user " + user.Name
+}
+
+func (suite *NegotiateSuite) TestItCanRenderTheBestRepresentation() {
+	renderer := NewTemplateRenderer(TemplateRendererOptions{})
+	suite.Require().NoError(renderer.Add("user", template.Must(template.New("user").Parse(`<h1>{{.Name}}</h1>`))))
+
+	testCases := map[string]struct {
+		accept       string
+		fallback     bool
+		expectedType string
+		expectedBody string
+	}{
+		"missing accept": {
+			expectedType: "application/json",
+			expectedBody: "{\"name\":\"ana\"}\n",
+		},
+		"xml preferred": {
+			accept:       "application/json;q=0.5, application/xml",
+			expectedType: "application/xml; charset=utf-8",
+			expectedBody: xml.Header + "<negotiatedUser><name>ana</name></negotiatedUser>",
+		},
+		"html": {
+			accept:       "text/html, */*;q=0.1",
+			expectedType: "text/html; charset=utf-8",
+			expectedBody: "<h1>ana</h1>",
+		},
+		"text": {
+			accept:       "text/*",
+			expectedType: "text/plain; charset=utf-8",
+			expectedBody: "user ana",
+		},
+		"fallback": {
+			accept:       "image/png",
+			fallback:     true,
+			expectedType: "application/json",
+			expectedBody: "{\"name\":\"ana\"}\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+				request.Header.Set("Accept", testCase.accept)
+				recorder := httptest.NewRecorder()
+
+				builder := NewResponseBuilder(recorder).
+					Negotiate(request).
+					Offer(OfferJSON(), OfferXML(), OfferText(), OfferTemplate(renderer, "user")).
+					Data(negotiatedUser{Name: "ana"})
+				if testCase.fallback {
+					builder.Fallback(OfferJSON())
+				}
+
+				suite.Require().NoError(builder.Send())
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(testCase.expectedType, recorder.Header().Get("Content-Type"))
+				suite.Equal("Accept", recorder.Header().Get("Vary"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *NegotiateSuite) TestItReturnsNotAcceptableWithoutWriting() {
+	request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	request.Header.Set("Accept", "image/png")
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).Negotiate(request).Offer(OfferJSON()).Data("x").Send()
+
+	var notAcceptable NotAcceptableError
+	suite.Require().True(errors.As(err, &notAcceptable))
+	suite.Equal("image/png", notAcceptable.Accept)
+	suite.Equal(http.StatusNotAcceptable, notAcceptable.StatusCode())
+	suite.Zero(recorder.Body.Len())
+	suite.Empty(recorder.Header())
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NotAcceptableError is returned when the Accept header cannot be satisfied
+type NotAcceptableError struct {
+	Accept string
+}
+
+func (e NotAcceptableError) Error() string {
+	return fmt.Sprintf("cannot produce a representation acceptable for %q", e.Accept)
+}
+
+// StatusCode implements the HTTPError interface
+func (e NotAcceptableError) StatusCode() int {
+	return http.StatusNotAcceptable
+}
+
+// MediaRange is a media range of an Accept header
+//
+// MediaType: lowercased type and subtype, possibly wildcards ("text/*", "*/*")
+// Params: media type parameters (e.g. "version"), the quality excluded
+// Quality: "q" parameter, 1 when missing
+type MediaRange struct {
+	MediaType string
+	Params    map[string]string
+	Quality   float64
+}
+
+// specificity ranks how precisely the range matches the media type, -1 when it does not
+func (mr MediaRange) specificity(mainType, subType string) int {
+	rangeMainType, rangeSubType, _ := strings.Cut(mr.MediaType, "/")
+	switch {
+	case rangeMainType == mainType && rangeSubType == subType:
+		return 2
+	case rangeMainType == mainType && rangeSubType == "*":
+		return 1
+	case rangeMainType == "*" && rangeSubType == "*":
+		return 0
+	default:
+		return -1
+	}
+}
+
+// qualityValue is an entry of a header weighting its values by quality, like Accept,
+// Accept-Language and Accept-Encoding
+type qualityValue struct {
+	value   string
+	params  string
+	quality float64
+}
+
+// parseQualityValues parses the comma separated entries of every header line, giving
+// entries without a "q" parameter a quality of 1 and skipping empty entries and entries
+// with a malformed quality. The parameters are kept apart from the values.
+func parseQualityValues(header []string) []qualityValue {
+	var entries []qualityValue
+	for _, line := range header {
+		for _, entry := range strings.Split(line, ",") {
+			value, params, _ := strings.Cut(entry, ";")
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			quality, ok := parseQuality(params)
+			if !ok {
+				continue
+			}
+			entries = append(
+				entries, qualityValue{value: value, params: params, quality: quality},
+			)
+		}
+	}
+	return entries
+}
+
+// parseQuality returns the "q" parameter of the semicolon separated parameters, 1 when
+// it is missing. The boolean is false when it is malformed.
+func parseQuality(params string) (float64, bool) {
+	for _, param := range strings.Split(params, ";") {
+		name, value, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return quality, err == nil
+	}
+	return 1, true
+}
+
+// ParseAccept parses the media ranges of the Accept header lines (see
+// http.Header.Values), in header order, skipping malformed entries. Ranges with a zero
+// quality are kept, as they exclude the media types they match.
+func ParseAccept(header []string) []MediaRange {
+	var ranges []MediaRange
+	for _, entry := range parseQualityValues(header) {
+		value := entry.value
+		if strings.TrimSpace(entry.params) != "" {
+			value += ";" + entry.params
+		}
+		mediaType, params, err := mime.ParseMediaType(value)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			continue
+		}
+		delete(params, "q")
+		ranges = append(
+			ranges, MediaRange{MediaType: mediaType, Params: params, Quality: entry.quality},
+		)
+	}
+	return ranges
+}
+
+// NegotiateContentType returns the offered media type best matching the request Accept
+// header, honoring quality values and range specificity. Ties are resolved in favor of
+// the earliest offer. A missing Accept header accepts the first offer. The boolean is
+// false when no offer is acceptable.
+func NegotiateContentType(r *http.Request, offers ...string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+
+	header := r.Header.Values("Accept")
+	if strings.TrimSpace(strings.Join(header, "")) == "" {
+		return offers[0], true
+	}
+	ranges := ParseAccept(header)
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		mediaType, _, err := mime.ParseMediaType(offer)
+		if err != nil {
+			continue
+		}
+		mainType, subType, _ := strings.Cut(mediaType, "/")
+
+		// The most specific matching range determines the offer quality
+		quality, specificity := 0.0, -1
+		for _, mediaRange := range ranges {
+			if s := mediaRange.specificity(mainType, subType); s > specificity {
+				quality, specificity = mediaRange.Quality, s
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best, bestQuality > 0
+}
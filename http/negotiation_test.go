@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NegotiationSuite struct {
+	suite.Suite
+}
+
+func TestNegotiationSuite(t *testing.T) {
+	suite.Run(t, new(NegotiationSuite))
+}
+
+func (suite *NegotiationSuite) TestItCanNegotiateContentType() {
+	offers := []string{"application/json", "application/xml", "text/html"}
+
+	testCases := map[string]struct {
+		accept     string
+		expected   string
+		expectedOk bool
+	}{
+		"missing header":        {"", "application/json", true},
+		"exact match":           {"text/html", "text/html", true},
+		"any":                   {"*/*", "application/json", true},
+		"quality preference":    {"application/json;q=0.5, application/xml", "application/xml", true},
+		"subtype wildcard":      {"text/*", "text/html", true},
+		"specific overrides":    {"application/*;q=0.2, application/xml;q=0, */*;q=0.1", "application/json", true},
+		"refused":               {"application/json;q=0, image/png", "", false},
+		"no acceptable offer":   {"image/png", "", false},
+		"malformed entry skip":  {"application/;;, text/html", "text/html", true},
+		"tie keeps offer order": {"application/xml, application/json", "application/json", true},
+		"quality after params":  {"application/json;charset=utf-8;q=0, text/html", "text/html", true},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("Accept", testCase.accept)
+
+				negotiated, ok := NegotiateContentType(request, offers...)
+
+				suite.Equal(testCase.expected, negotiated)
+				suite.Equal(testCase.expectedOk, ok)
+			},
+		)
+	}
+}
+
+func (suite *NegotiationSuite) TestItReadsEveryAcceptLine() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Add("Accept", "application/json;q=0.1")
+	request.Header.Add("Accept", "text/html")
+
+	negotiated, ok := NegotiateContentType(request, "application/json", "text/html")
+
+	suite.True(ok)
+	suite.Equal("text/html", negotiated)
+}
+
+func (suite *NegotiationSuite) TestItCanParseQualityValues() {
+	entries := parseQualityValues(
+		[]string{"gzip;q=0.5, , br;level=4;Q=0.8", "identity;q=bad, *;q=0"},
+	)
+
+	suite.Equal(
+		[]qualityValue{
+			{value: "gzip", params: "q=0.5", quality: 0.5},
+			{value: "br", params: "level=4;Q=0.8", quality: 0.8},
+			{value: "*", params: "q=0", quality: 0},
+		},
+		entries,
+	)
+}
+
+func (suite *NegotiationSuite) TestItCanParseAcceptHeaders() {
+	ranges := ParseAccept(
+		[]string{
+			"application/vnd.api+json; version=2; q=0.5, text/*",
+			"Application/JSON;q=0, invalid, image/png;q=bad",
+		},
+	)
+
+	suite.Equal(
+		[]MediaRange{
+			{
+				MediaType: "application/vnd.api+json",
+				Params:    map[string]string{"version": "2"},
+				Quality:   0.5,
+			},
+			{MediaType: "text/*", Params: map[string]string{}, Quality: 1},
+			{MediaType: "application/json", Params: map[string]string{}, Quality: 0},
+		},
+		ranges,
+	)
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// The helpers below let writer wrappers expose the optional http.Flusher, http.Hijacker,
+// http.Pusher and io.ReaderFrom interfaces of the writer they wrap, which streaming,
+// websocket and sendfile code paths look for. Wrappers should also implement Unwrap
+// for http.ResponseController.
+
+// Flush flushes the writer when it supports it
+func Flush(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack takes over the connection of the writer, failing with http.ErrNotSupported
+// when the writer cannot be hijacked
+func Hijack(w http.ResponseWriter) (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Push initiates an HTTP/2 server push, failing with http.ErrNotSupported when the
+// writer cannot push
+func Push(w http.ResponseWriter, target string, opts *http.PushOptions) error {
+	if pusher, ok := w.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// ReadFrom copies the reader into the writer, through its io.ReaderFrom implementation
+// when it has one (sendfile on plain connections)
+func ReadFrom(w http.ResponseWriter, r io.Reader) (int64, error) {
+	if readerFrom, ok := w.(io.ReaderFrom); ok {
+		return readerFrom.ReadFrom(r)
+	}
+	return io.Copy(WriterOnly{w}, r)
+}
+
+// WriterOnly hides the io.ReaderFrom implementation of a writer, so a wrapper copying
+// into itself from its own ReadFrom does not recurse
+type WriterOnly struct {
+	io.Writer
+}
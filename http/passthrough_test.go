@@ -0,0 +1,63 @@
+package http
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PassthroughSuite struct {
+	suite.Suite
+}
+
+func TestPassthroughSuite(t *testing.T) {
+	suite.Run(t, new(PassthroughSuite))
+}
+
+// hijackableRecorder supports connection hijacking and HTTP/2 server pushes
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	pushed   string
+}
+
+func (hr *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hr.hijacked = true
+	return nil, nil, nil
+}
+
+func (hr *hijackableRecorder) Push(target string, _ *http.PushOptions) error {
+	hr.pushed = target
+	return nil
+}
+
+func (suite *PassthroughSuite) TestItPassesOptionalInterfacesThrough() {
+	recorder := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+	Flush(recorder)
+	_, _, err := Hijack(recorder)
+	suite.NoError(err)
+	suite.NoError(Push(recorder, "/app.css", nil))
+	n, err := ReadFrom(recorder, strings.NewReader("body"))
+	suite.NoError(err)
+
+	suite.True(recorder.Flushed)
+	suite.True(recorder.hijacked)
+	suite.Equal("/app.css", recorder.pushed)
+	suite.Equal(int64(4), n)
+	suite.Equal("body", recorder.Body.String())
+}
+
+func (suite *PassthroughSuite) TestItReportsUnsupportedInterfaces() {
+	writer := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+
+	suite.NotPanics(func() { Flush(writer) })
+	_, _, err := Hijack(writer)
+	suite.ErrorIs(err, http.ErrNotSupported)
+	suite.ErrorIs(Push(writer, "/app.css", nil), http.ErrNotSupported)
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+)
+
+var responseBuilderPool = sync.Pool{
+	New: func() any {
+		return &ResponseBuilder{headers: make(map[string]string)}
+	},
+}
+
+// pooledBuilders keeps the sub-builders of a pooled ResponseBuilder, so they are reused
+// together with it instead of being allocated per response
+type pooledBuilders struct {
+	json  *JSONResponseBuilder
+	text  *TextResponseBuilder
+	html  *HTMLResponseBuilder
+	error *ErrorResponseBuilder
+}
+
+// AcquireResponseBuilder returns a response builder from a shared pool, to avoid per
+// request allocations on hot paths. The JSON, text, HTML and error builders it creates
+// are reused as well. Call Release once the response was sent; neither the builder nor
+// any builder created from it may be used afterwards.
+func AcquireResponseBuilder(w http.ResponseWriter) *ResponseBuilder {
+	rb := responseBuilderPool.Get().(*ResponseBuilder)
+	rb.Reset(w)
+	if rb.pooled == nil {
+		rb.pooled = &pooledBuilders{}
+	}
+	return rb
+}
+
+// Release returns a builder obtained from AcquireResponseBuilder to the pool. It is a
+// no-op for builders created with NewResponseBuilder.
+func (rb *ResponseBuilder) Release() {
+	if rb.pooled == nil {
+		return
+	}
+	rb.Reset(nil)
+	responseBuilderPool.Put(rb)
+}
+
+// Reset clears the builder state so it can answer another response on the writer,
+// keeping the allocated header map and cookie slice
+func (rb *ResponseBuilder) Reset(w http.ResponseWriter) {
+	if rb.headers == nil {
+		rb.headers = make(map[string]string)
+	}
+	clear(rb.headers)
+	clear(rb.cookies)
+	rb.cookies = rb.cookies[:0]
+	rb.writer = w
+	rb.request = nil
+	rb.statusCode = http.StatusOK
+	rb.etag = ""
+	rb.etagMode = etagNone
+	rb.compress = false
+	rb.compressThreshold = 0
+	rb.buffered = false
+	rb.bodySize = 0
+	rb.committed = false
+	rb.signingKey = nil
+	rb.envelope = nil
+	clear(rb.meta)
+	clear(rb.beforeSend)
+	rb.beforeSend = rb.beforeSend[:0]
+}
+
+// reuse resets the value kept in the slot, allocating it on first use
+func reuse[T any](slot **T, value T) *T {
+	if *slot == nil {
+		*slot = new(T)
+	}
+	**slot = value
+	return *slot
+}
+
+// jsonBuilder returns a JSON builder bound to rb, reused when rb is pooled
+func (rb *ResponseBuilder) jsonBuilder() *JSONResponseBuilder {
+	if rb.pooled == nil {
+		return &JSONResponseBuilder{ResponseBuilder: rb}
+	}
+	return reuse(&rb.pooled.json, JSONResponseBuilder{ResponseBuilder: rb})
+}
+
+// textBuilder returns a text builder bound to rb, reused when rb is pooled
+func (rb *ResponseBuilder) textBuilder() *TextResponseBuilder {
+	if rb.pooled == nil {
+		return &TextResponseBuilder{
+			ContentResponseBuilder: &ContentResponseBuilder{ResponseBuilder: rb},
+		}
+	}
+	builder := rb.pooled.text
+	if builder == nil {
+		builder = &TextResponseBuilder{ContentResponseBuilder: &ContentResponseBuilder{}}
+		rb.pooled.text = builder
+	}
+	*builder.ContentResponseBuilder = ContentResponseBuilder{ResponseBuilder: rb}
+	return builder
+}
+
+// htmlBuilder returns an HTML builder bound to rb, reused when rb is pooled
+func (rb *ResponseBuilder) htmlBuilder() *HTMLResponseBuilder {
+	if rb.pooled == nil {
+		return &HTMLResponseBuilder{
+			ContentResponseBuilder: &ContentResponseBuilder{ResponseBuilder: rb},
+		}
+	}
+	builder := rb.pooled.html
+	if builder == nil {
+		builder = &HTMLResponseBuilder{ContentResponseBuilder: &ContentResponseBuilder{}}
+		rb.pooled.html = builder
+	}
+	*builder.ContentResponseBuilder = ContentResponseBuilder{ResponseBuilder: rb}
+	return builder
+}
+
+// errorBuilder returns an error builder bound to rb, reused when rb is pooled
+func (rb *ResponseBuilder) errorBuilder() *ErrorResponseBuilder {
+	if rb.pooled == nil {
+		return &ErrorResponseBuilder{
+			ResponseBuilder: rb,
+			loggingEnabled:  true,
+			categories:      make([]*ErrorCategory, 0),
+		}
+	}
+	var categories []*ErrorCategory
+	var extensions map[string]interface{}
+	if rb.pooled.error != nil {
+		categories = rb.pooled.error.categories
+		clear(categories)
+		categories = categories[:0]
+		extensions = rb.pooled.error.extensions
+		clear(extensions)
+	}
+	if categories == nil {
+		categories = make([]*ErrorCategory, 0)
+	}
+	return reuse(
+		&rb.pooled.error, ErrorResponseBuilder{
+			ResponseBuilder: rb,
+			loggingEnabled:  true,
+			categories:      categories,
+			extensions:      extensions,
+		},
+	)
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PoolSuite struct {
+	suite.Suite
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(PoolSuite))
+}
+
+func (suite *PoolSuite) TestItCanResetBuilders() {
+	builder := NewResponseBuilder(httptest.NewRecorder()).
+		Status(http.StatusCreated).
+		Header("X-Custom", "value").
+		Cookie(&http.Cookie{Name: "theme", Value: "dark"}).
+		WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).
+		AutoETag(true)
+
+	recorder := httptest.NewRecorder()
+	builder.Reset(recorder)
+	suite.Require().NoError(builder.Text().ContentString("ok").Send())
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("X-Custom"))
+	suite.Empty(recorder.Header().Get("ETag"))
+	suite.Empty(recorder.Result().Cookies())
+	suite.Equal("ok", recorder.Body.String())
+}
+
+func (suite *PoolSuite) TestItReusesPooledSubBuilders() {
+	builder := AcquireResponseBuilder(httptest.NewRecorder())
+	defer builder.Release()
+
+	json := builder.JSON().Data("first").Indent("  ")
+	suite.Same(json, builder.JSON())
+	suite.Nil(builder.JSON().data)
+	suite.Nil(builder.JSON().indent)
+
+	text := builder.Text()
+	text.ContentString("first")
+	suite.Same(text, builder.Text())
+	suite.Nil(builder.Text().content)
+	suite.Same(builder.HTML(), builder.HTML())
+
+	errorBuilder := builder.Error().
+		WithError(errors.New("first")).
+		WithExtension("balance", 30).
+		AddErrorCategory(NewErrorCategory(http.StatusConflict)).
+		DisableLogging()
+	suite.Same(errorBuilder, builder.Error())
+	suite.Nil(errorBuilder.err)
+	suite.Empty(errorBuilder.extensions)
+	suite.Empty(errorBuilder.categories)
+	suite.True(errorBuilder.loggingEnabled)
+}
+
+func (suite *PoolSuite) TestItCanSendPooledResponses() {
+	for range 3 {
+		recorder := httptest.NewRecorder()
+		builder := AcquireResponseBuilder(recorder)
+
+		suite.Empty(builder.headers)
+		suite.Empty(builder.cookies)
+		suite.Require().NoError(
+			builder.Status(http.StatusAccepted).
+				Header("X-Custom", "value").
+				Cookie(&http.Cookie{Name: "theme", Value: "dark"}).
+				JSON().
+				Data(map[string]string{"status": "ok"}).
+				Send(),
+		)
+		builder.Release()
+
+		suite.Equal(http.StatusAccepted, recorder.Code)
+		suite.Equal("value", recorder.Header().Get("X-Custom"))
+		suite.Len(recorder.Result().Cookies(), 1)
+		suite.Equal("{\"status\":\"ok\"}\n", recorder.Body.String())
+	}
+}
+
+func (suite *PoolSuite) TestItIgnoresReleasingUnpooledBuilders() {
+	builder := NewResponseBuilder(httptest.NewRecorder()).Header("X-Custom", "value")
+	builder.Release()
+
+	suite.Equal("value", builder.headers["X-Custom"])
+	suite.NotSame(builder.JSON(), builder.JSON())
+}
+
+func (suite *PoolSuite) TestItCanResetResponseWriters() {
+	hookCalls := 0
+	writer := AcquireResponseWriter(httptest.NewRecorder()).
+		CaptureBody(16).
+		OnFirstWrite(func(header http.Header) { hookCalls++ })
+	writer.WriteHeader(http.StatusCreated)
+	_, _ = writer.Write([]byte("first"))
+	writer.Finish()
+	writer.Release()
+
+	recorder := httptest.NewRecorder()
+	writer = AcquireResponseWriter(recorder)
+	defer writer.Release()
+
+	suite.False(writer.WroteHeader())
+	suite.Nil(writer.WrittenHeader())
+	suite.Empty(writer.CapturedBody())
+	suite.Zero(writer.TimeToFirstByte())
+
+	_, _ = writer.Write([]byte("second"))
+
+	suite.Equal(1, hookCalls)
+	suite.Equal(http.StatusOK, writer.StatusCode())
+	suite.Empty(writer.CapturedBody())
+	suite.Equal("second", recorder.Body.String())
+}
+
+func (suite *PoolSuite) TestItIgnoresReleasingUnpooledResponseWriters() {
+	recorder := httptest.NewRecorder()
+	writer := NewResponseWriter(recorder)
+	writer.WriteHeader(http.StatusCreated)
+	writer.Release()
+
+	suite.Same(recorder, writer.Unwrap())
+	suite.Equal(http.StatusCreated, writer.StatusCode())
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// readerBufferPool recycles the buffers used to copy reader bodies
+var readerBufferPool = sync.Pool{
+	New: func() any {
+		buffer := make([]byte, binaryChunkSize)
+		return &buffer
+	},
+}
+
+// ContentReader streams the content from the reader instead of holding it in memory. A
+// non-negative size is sent as Content-Length and must match the content length. Such
+// bodies are neither signed, compressed nor given a computed entity tag, which all need
+// the whole body. Readers implementing io.Closer are closed once sent.
+func (crb *ContentResponseBuilder) ContentReader(r io.Reader, size int64) *ContentResponseBuilder {
+	crb.content = nil
+	crb.reader = r
+	crb.size = size
+	return crb
+}
+
+// sendReader streams the content through a pooled buffer, or through the writer
+// io.ReaderFrom implementation when it has one (sendfile on plain connections). The
+// first chunk is read before committing, so a failing reader can still be answered
+// with an error response.
+func (rb *ResponseBuilder) sendReader(content io.Reader, size int64) error {
+	if closer, ok := unwrapReader(content).(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	ctx := rb.writeContext()
+	if err := aborted(ctx); err != nil {
+		return err
+	}
+
+	buffer := readerBufferPool.Get().(*[]byte)
+	defer readerBufferPool.Put(buffer)
+
+	n, err := io.ReadAtLeast(content, *buffer, 1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	if size >= 0 {
+		rb.Header("Content-Length", strconv.FormatInt(size, 10))
+	}
+	rb.writeHeaders()
+	writer := &contextWriter{ResponseWriter: rb.writer, ctx: ctx}
+	if _, err := writer.Write((*buffer)[:n]); err != nil {
+		return err
+	}
+	if _, ok := rb.writer.(io.ReaderFrom); ok {
+		_, err = writer.ReadFrom(unwrapReader(content))
+		return err
+	}
+	_, err = io.CopyBuffer(WriterOnly{writer}, readerOnly{content}, *buffer)
+	return err
+}
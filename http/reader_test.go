@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReaderSuite struct {
+	suite.Suite
+}
+
+func TestReaderSuite(t *testing.T) {
+	suite.Run(t, new(ReaderSuite))
+}
+
+// closeRecorder records whether the reader was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (cr *closeRecorder) Close() error {
+	cr.closed = true
+	return nil
+}
+
+func (suite *ReaderSuite) TestItCanStreamContentFromReaders() {
+	large := strings.Repeat("x", 3*binaryChunkSize)
+
+	testCases := map[string]struct {
+		content               string
+		size                  int64
+		expectedContentLength string
+	}{
+		"known size":   {content: "hello", size: 5, expectedContentLength: "5"},
+		"unknown size": {content: "hello", size: -1},
+		"large":        {content: large, size: int64(len(large)), expectedContentLength: "98304"},
+		"empty":        {content: "", size: 0, expectedContentLength: "0"},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				reader := &closeRecorder{Reader: strings.NewReader(testCase.content)}
+
+				err := NewResponseBuilder(recorder).
+					Status(http.StatusAccepted).
+					Text().
+					ContentReader(reader, testCase.size).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Equal(http.StatusAccepted, recorder.Code)
+				suite.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedContentLength, recorder.Header().Get("Content-Length"))
+				suite.Equal(testCase.content, recorder.Body.String())
+				suite.True(reader.closed)
+			},
+		)
+	}
+}
+
+func (suite *ReaderSuite) TestItDoesNotCommitWhenTheReaderFailsImmediately() {
+	recorder := httptest.NewRecorder()
+	builder := NewResponseBuilder(recorder)
+
+	err := builder.HTML().ContentReader(iotest.ErrReader(errors.New("disk failure")), -1).Send()
+
+	suite.EqualError(err, "disk failure")
+	suite.False(builder.Committed())
+	suite.Empty(recorder.Header())
+}
+
+func (suite *ReaderSuite) TestItCanSwitchBackToInMemoryContent() {
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		Text().
+		ContentReader(strings.NewReader("streamed"), -1).
+		ContentString("buffered").
+		Send()
+
+	suite.Require().NoError(err)
+	suite.Equal("buffered", recorder.Body.String())
+}
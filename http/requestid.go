@@ -0,0 +1,34 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader is the header used to propagate request IDs between services
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a context carrying the given request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in the context or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// RequestIDFromRequest returns the request ID from the request context, falling back
+// to the RequestIDHeader sent by the client
+func RequestIDFromRequest(r *http.Request) string {
+	if requestID := RequestIDFromContext(r.Context()); requestID != "" {
+		return requestID
+	}
+	return r.Header.Get(RequestIDHeader)
+}
@@ -1,100 +1,73 @@
 package http
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
+
+	"github.com/golibry/go-http/http/httperr"
 )
 
 // HTTPError represents an error with an associated HTTP status code.
-type HTTPError interface {
-	error
-	StatusCode() int
-}
+// It is an alias of httperr.HTTPError, shared with the middleware package.
+type HTTPError = httperr.HTTPError
 
 // ErrorCategory represents a category of errors with a default status code.
-type ErrorCategory struct {
-	StatusCode int
-	checkFuncs []func(error) bool
-	logEnabled bool
-}
-
-func NewErrorCategory(statusCode int) *ErrorCategory {
-	return &ErrorCategory{
-		StatusCode: statusCode,
-		checkFuncs: make([]func(error) bool, 0),
-		logEnabled: true, // default: log errors of this category
-	}
-}
-
-func (ec *ErrorCategory) AddSentinelError(e error) {
-	ec.checkFuncs = append(
-		ec.checkFuncs, func(err error) bool {
-			return errors.Is(err, e)
-		},
-	)
-}
-
-func (ec *ErrorCategory) Matches(err error) bool {
-	for _, check := range ec.checkFuncs {
-		if check(err) {
-			return true
-		}
-	}
-	return false
-}
-
-// WithLogging enables or disables logging for this category and returns the category for chaining
-func (ec *ErrorCategory) WithLogging(enabled bool) *ErrorCategory {
-	ec.logEnabled = enabled
-	return ec
-}
-
-// DisableLogging disables logging for this error category and returns the category for chaining
-func (ec *ErrorCategory) DisableLogging() *ErrorCategory { return ec.WithLogging(false) }
-
-// EnableLogging enables logging for this error category and returns the category for chaining
-func (ec *ErrorCategory) EnableLogging() *ErrorCategory { return ec.WithLogging(true) }
+// It is an alias of httperr.ErrorCategory, shared with the middleware package.
+type ErrorCategory = httperr.ErrorCategory
 
-// IsLoggingEnabled returns whether logging is enabled for this category
-func (ec *ErrorCategory) IsLoggingEnabled() bool { return ec.logEnabled }
+// PanicError wraps a value recovered from a panic together with the stack captured
+// at recovery time. It is an alias of httperr.PanicError.
+type PanicError = httperr.PanicError
 
-func AddErrorType[T error](ec *ErrorCategory) {
-	ec.checkFuncs = append(
-		ec.checkFuncs, func(err error) bool {
-			var target T
-			return errors.As(err, &target)
-		},
-	)
-}
+// FieldErrors reports input validation failures per field.
+// It is an alias of httperr.FieldErrors.
+type FieldErrors = httperr.FieldErrors
 
-type ResponseWriter struct {
-	http.ResponseWriter
-	statusCode int
-}
+// CodedError is an error carrying a machine-readable code.
+// It is an alias of httperr.CodedError.
+type CodedError = httperr.CodedError
 
-func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
-	return &ResponseWriter{w, http.StatusOK}
-}
+// DetailedError is an error carrying details for structured error bodies.
+// It is an alias of httperr.DetailedError.
+type DetailedError = httperr.DetailedError
 
-func (rw *ResponseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
+// NewErrorCategory creates an error category, see httperr.NewErrorCategory
+func NewErrorCategory(statusCode int) *ErrorCategory {
+	return httperr.NewErrorCategory(statusCode)
 }
 
-func (rw *ResponseWriter) StatusCode() int {
-	return rw.statusCode
+// AddErrorType adds an error type to the category, see httperr.AddErrorType
+func AddErrorType[T error](ec *ErrorCategory) {
+	httperr.AddErrorType[T](ec)
 }
 
 // ResponseBuilder provides a base structure for building HTTP responses
 type ResponseBuilder struct {
-	writer     http.ResponseWriter
-	statusCode int
-	headers    map[string]string
+	writer            http.ResponseWriter
+	request           *http.Request
+	statusCode        int
+	headers           map[string]string
+	cookies           []*http.Cookie
+	etag              string
+	etagMode          etagMode
+	compress          bool
+	compressThreshold int
+	buffered          bool
+	bodySize          int
+	committed         bool
+	signingKey        *SigningKey
+	envelope          *bool
+	meta              map[string]interface{}
+	beforeSend        []BeforeSendHook
+	pooled            *pooledBuilders
 }
 
 // NewResponseBuilder creates a new response builder
@@ -118,24 +91,53 @@ func (rb *ResponseBuilder) Header(key, value string) *ResponseBuilder {
 	return rb
 }
 
+// Committed tells whether Send started writing the response. Builders only commit the
+// status line once the body is ready (streams once streaming starts), so a failed Send
+// that did not commit can still be answered with an error response, e.g. a clean 500
+// instead of a half-written 200.
+func (rb *ResponseBuilder) Committed() bool {
+	return rb.committed
+}
+
+// WithRequest sets the request being answered, needed by conditional responses
+func (rb *ResponseBuilder) WithRequest(r *http.Request) *ResponseBuilder {
+	rb.request = r
+	return rb
+}
+
 // writeHeaders writes all headers to the response writer
 func (rb *ResponseBuilder) writeHeaders() {
+	rb.applyHeaders()
+	rb.writer.WriteHeader(rb.statusCode)
+}
+
+// applyHeaders runs the before send hooks, then sets the headers and cookies on the
+// response writer, without writing them. The response counts as committed from then on.
+func (rb *ResponseBuilder) applyHeaders() {
+	rb.committed = true
+	rb.runBeforeSendHooks()
 	for key, value := range rb.headers {
 		rb.writer.Header().Set(key, value)
 	}
-	rb.writer.WriteHeader(rb.statusCode)
+	for _, cookie := range rb.cookies {
+		http.SetCookie(rb.writer, cookie)
+	}
 }
 
 // JSONResponseBuilder builds JSON responses
 type JSONResponseBuilder struct {
 	*ResponseBuilder
-	data interface{}
+	data          interface{}
+	indent        *string
+	stream        iter.Seq[any]
+	ctx           context.Context
+	flushInterval time.Duration
 }
 
 // JSON creates a new JSON response builder
 func (rb *ResponseBuilder) JSON() *JSONResponseBuilder {
 	rb.Header("Content-Type", "application/json")
-	return &JSONResponseBuilder{ResponseBuilder: rb}
+	return rb.jsonBuilder()
 }
 
 // Data sets the JSON data to be written
@@ -146,33 +148,51 @@ func (jrb *JSONResponseBuilder) Data(data interface{}) *JSONResponseBuilder {
 
 // Send writes the JSON response
 func (jrb *JSONResponseBuilder) Send() error {
-	jrb.writeHeaders()
-	return json.NewEncoder(jrb.writer).Encode(jrb.data)
+	if jrb.stream != nil {
+		return jrb.sendStream()
+	}
+
+	data := jrb.data
+	if jrb.envelopes() {
+		data = Envelope{Data: data, Meta: jrb.envelopeMeta()}
+	}
+
+	// Encoding first keeps the response uncommitted when the data cannot be encoded
+	var body bytes.Buffer
+	if err := jrb.newEncoder(&body).Encode(data); err != nil {
+		return err
+	}
+	return jrb.writeBody(body.Bytes())
 }
 
 // ContentResponseBuilder provides common functionality for content-based responses
 type ContentResponseBuilder struct {
 	*ResponseBuilder
 	content []byte
+	reader  io.Reader
+	size    int64
 }
 
 // Content sets the content to be written
 func (crb *ContentResponseBuilder) Content(content []byte) *ContentResponseBuilder {
 	crb.content = content
+	crb.reader = nil
 	return crb
 }
 
 // ContentString sets the content from a string
 func (crb *ContentResponseBuilder) ContentString(content string) *ContentResponseBuilder {
 	crb.content = []byte(content)
+	crb.reader = nil
 	return crb
 }
 
 // Send writes the content response
 func (crb *ContentResponseBuilder) Send() error {
-	crb.writeHeaders()
-	_, err := crb.writer.Write(crb.content)
-	return err
+	if crb.reader != nil {
+		return crb.sendReader(crb.reader, crb.size)
+	}
+	return crb.writeBody(crb.content)
 }
 
 // TextResponseBuilder builds plain text responses
@@ -183,9 +203,7 @@ type TextResponseBuilder struct {
 // Text creates a new text response builder
 func (rb *ResponseBuilder) Text() *TextResponseBuilder {
 	rb.Header("Content-Type", "text/plain; charset=utf-8")
-	return &TextResponseBuilder{
-		ContentResponseBuilder: &ContentResponseBuilder{ResponseBuilder: rb},
-	}
+	return rb.textBuilder()
 }
 
 // HTMLResponseBuilder builds HTML responses
@@ -196,29 +214,52 @@ type HTMLResponseBuilder struct {
 // HTML creates a new HTML response builder
 func (rb *ResponseBuilder) HTML() *HTMLResponseBuilder {
 	rb.Header("Content-Type", "text/html; charset=utf-8")
-	return &HTMLResponseBuilder{
-		ContentResponseBuilder: &ContentResponseBuilder{ResponseBuilder: rb},
-	}
+	return rb.htmlBuilder()
 }
 
+// ErrorBodyFormat identifies the representation of an error response body
+type ErrorBodyFormat int
+
+const (
+	// ErrorBodyText renders errors as plain text (default)
+	ErrorBodyText ErrorBodyFormat = iota
+	// ErrorBodyJSON renders errors as JSON, see AsJSON
+	ErrorBodyJSON
+	// ErrorBodyProblem renders errors as RFC 7807 problem details, see AsProblem
+	ErrorBodyProblem
+	// ErrorBodyJSONAPI renders errors as a JSON:API errors document, see AsJSONAPI
+	ErrorBodyJSONAPI
+)
+
 // ErrorResponseBuilder builds error responses with advanced error handling capabilities
 type ErrorResponseBuilder struct {
 	*ResponseBuilder
-	err        error
-	message    string
-	isJSON     bool
-	ctx        context.Context
-	logger     *slog.Logger
-	categories []*ErrorCategory
-}
-
-// Error creates a new error response builder
+	err              error
+	message          string
+	requestID        string
+	instance         string
+	problemType      string
+	title            string
+	code             string
+	details          map[string]interface{}
+	extensions       map[string]interface{}
+	translator       Translator
+	locale           string
+	messageID        string
+	format           ErrorBodyFormat
+	loggingEnabled   bool
+	devMode          bool
+	maskServerErrors *bool
+	ctx              context.Context
+	logger           *slog.Logger
+	formatter        ErrorFormatter
+	categories       []*ErrorCategory
+	logAttrs         []slog.Attr
+}
+
+// Error creates a new error response builder, in the DefaultErrorResponse format
 func (rb *ResponseBuilder) Error() *ErrorResponseBuilder {
-	rb.Header("Content-Type", "text/plain; charset=utf-8")
-	return &ErrorResponseBuilder{
-		ResponseBuilder: rb,
-		categories:      make([]*ErrorCategory, 0),
-	}
+	return rb.errorBuilder().WithFormat(DefaultErrorResponse.Format)
 }
 
 // WithError sets the error to be written
@@ -233,10 +274,106 @@ func (erb *ErrorResponseBuilder) WithMessage(message string) *ErrorResponseBuild
 	return erb
 }
 
+// WithRequestID sets the request ID included in structured error bodies, the log entry
+// and the RequestIDHeader response header. It defaults to the ID stored in the request
+// context, then in the builder context (see WithRequestID at package level).
+func (erb *ErrorResponseBuilder) WithRequestID(requestID string) *ErrorResponseBuilder {
+	erb.requestID = requestID
+	return erb
+}
+
+// DisableLogging turns off error logging, e.g. when the caller already logged the error
+func (erb *ErrorResponseBuilder) DisableLogging() *ErrorResponseBuilder {
+	erb.loggingEnabled = false
+	return erb
+}
+
+// WithDevMode includes the full error chain (%+v), the wrapped causes and the stack trace
+// of recovered panics in the response body. Only enable it in development, production
+// responses must stay terse to avoid disclosing internals.
+func (erb *ErrorResponseBuilder) WithDevMode(enabled bool) *ErrorResponseBuilder {
+	erb.devMode = enabled
+	return erb
+}
+
+// DefaultMaskServerErrors tells whether error responses with a 5xx status hide the error,
+// see MaskServerErrors. It is meant to be set once at startup, e.g. in production.
+var DefaultMaskServerErrors = false
+
+// MaskServerErrors overrides DefaultMaskServerErrors for this response. Masked 5xx
+// responses replace the error message with the status text and leave out error details
+// and dev mode information, taking precedence over WithDevMode; the error is still
+// logged. Messages, codes and details set on the builder are sent as is.
+func (erb *ErrorResponseBuilder) MaskServerErrors(mask bool) *ErrorResponseBuilder {
+	erb.maskServerErrors = &mask
+	return erb
+}
+
+// masksServerErrors resolves the masking mode of the response
+func (erb *ErrorResponseBuilder) masksServerErrors() bool {
+	if erb.maskServerErrors != nil {
+		return *erb.maskServerErrors
+	}
+	return DefaultMaskServerErrors
+}
+
 // AsJSON configures the error response to be in JSON format
 func (erb *ErrorResponseBuilder) AsJSON() *ErrorResponseBuilder {
 	erb.Header("Content-Type", "application/json")
-	erb.isJSON = true
+	erb.format = ErrorBodyJSON
+	return erb
+}
+
+// AsProblem configures the error response to be an RFC 7807 problem details document
+// (application/problem+json) with type, title, status, detail and instance members.
+// The type and title come from the builder, else from the matched error category (see
+// ErrorCategory.WithProblemType), else default to "about:blank" and the status text.
+func (erb *ErrorResponseBuilder) AsProblem() *ErrorResponseBuilder {
+	erb.Header("Content-Type", "application/problem+json")
+	erb.format = ErrorBodyProblem
+	return erb
+}
+
+// WithProblemType sets the problem type URI of problem details responses
+func (erb *ErrorResponseBuilder) WithProblemType(typeURI string) *ErrorResponseBuilder {
+	erb.problemType = typeURI
+	return erb
+}
+
+// WithTitle sets the title of problem details responses
+func (erb *ErrorResponseBuilder) WithTitle(title string) *ErrorResponseBuilder {
+	erb.title = title
+	return erb
+}
+
+// WithCode sets the machine-readable error code of structured error bodies, taking
+// precedence over CodedError implementations and the matched category code
+func (erb *ErrorResponseBuilder) WithCode(code string) *ErrorResponseBuilder {
+	erb.code = code
+	return erb
+}
+
+// WithDetails sets the details object of structured error bodies, taking precedence over
+// DetailedError implementations
+func (erb *ErrorResponseBuilder) WithDetails(details map[string]interface{}) *ErrorResponseBuilder {
+	erb.details = details
+	return erb
+}
+
+// WithExtension adds an extension member to problem details responses. Extensions never
+// replace the standard members.
+func (erb *ErrorResponseBuilder) WithExtension(key string, value interface{}) *ErrorResponseBuilder {
+	if erb.extensions == nil {
+		erb.extensions = make(map[string]interface{})
+	}
+	erb.extensions[key] = value
+	return erb
+}
+
+// WithInstance sets the URI reference identifying the problem occurrence,
+// used by problem details responses (typically the request path)
+func (erb *ErrorResponseBuilder) WithInstance(instance string) *ErrorResponseBuilder {
+	erb.instance = instance
 	return erb
 }
 
@@ -246,12 +383,32 @@ func (erb *ErrorResponseBuilder) WithLogger(logger *slog.Logger) *ErrorResponseB
 	return erb
 }
 
+// WithLogAttrs adds attributes to the error log entry, next to the error, status code,
+// request ID, request method and path, and matched category name logged by default
+func (erb *ErrorResponseBuilder) WithLogAttrs(attrs ...slog.Attr) *ErrorResponseBuilder {
+	erb.logAttrs = append(erb.logAttrs, attrs...)
+	return erb
+}
+
 // WithContext sets the context for structured logging
 func (erb *ErrorResponseBuilder) WithContext(ctx context.Context) *ErrorResponseBuilder {
 	erb.ctx = ctx
 	return erb
 }
 
+// WithRequest sets the request being answered, passed to error category hooks
+func (erb *ErrorResponseBuilder) WithRequest(r *http.Request) *ErrorResponseBuilder {
+	erb.request = r
+	return erb
+}
+
+// WithFormatter sets a custom formatter rendering the response body, taking precedence
+// over the built-in text, JSON and problem formats
+func (erb *ErrorResponseBuilder) WithFormatter(formatter ErrorFormatter) *ErrorResponseBuilder {
+	erb.formatter = formatter
+	return erb
+}
+
 // WithErrorCategories sets the error categories for flexible error classification
 func (erb *ErrorResponseBuilder) WithErrorCategories(categories ...*ErrorCategory) *ErrorResponseBuilder {
 	erb.categories = append(erb.categories, categories...)
@@ -264,19 +421,30 @@ func (erb *ErrorResponseBuilder) AddErrorCategory(category *ErrorCategory) *Erro
 	return erb
 }
 
-// classifyError determines the HTTP status code and matched category for an error
-func (erb *ErrorResponseBuilder) classifyError(err error) (int, *ErrorCategory) {
-	// Check if the error implements HTTPError interface
-	var httpErr HTTPError
-	if errors.As(err, &httpErr) {
-		return httpErr.StatusCode(), nil
+// requestIDFromContext returns the request ID stored in the request context, then in the
+// builder context
+func (erb *ErrorResponseBuilder) requestIDFromContext() string {
+	if erb.request != nil {
+		if requestID := RequestIDFromContext(erb.request.Context()); requestID != "" {
+			return requestID
+		}
 	}
+	return RequestIDFromContext(erb.ctx)
+}
 
-	// Check error categories
-	for _, category := range erb.categories {
-		if category.Matches(err) {
-			return category.StatusCode, category
-		}
+// context returns the context used for logging and hooks
+func (erb *ErrorResponseBuilder) context() context.Context {
+	if erb.ctx != nil {
+		return erb.ctx
+	}
+	return context.Background()
+}
+
+// classifyError determines the HTTP status code and matched category for an error
+func (erb *ErrorResponseBuilder) classifyError(err error) (int, *ErrorCategory) {
+	// Check HTTPError interface and error categories
+	if statusCode, category, ok := httperr.Classify(err, erb.errorCategories()); ok {
+		return statusCode, category
 	}
 
 	// If a status code was explicitly set (not the default 200), use it
@@ -291,6 +459,8 @@ func (erb *ErrorResponseBuilder) classifyError(err error) (int, *ErrorCategory)
 
 // Send writes the error response with enhanced error handling
 func (erb *ErrorResponseBuilder) Send() error {
+	erb.applyDefaults()
+
 	// Determine the appropriate status code and matched category
 	var statusCode int
 	var matchedCategory *ErrorCategory
@@ -307,50 +477,276 @@ func (erb *ErrorResponseBuilder) Send() error {
 	// Update the response builder's status code
 	erb.Status(statusCode)
 
+	// Echo the request ID so clients can quote it, unless a header carries it already
+	if erb.requestID == "" {
+		erb.requestID = erb.requestIDFromContext()
+	}
+	if erb.requestID != "" && erb.headers[RequestIDHeader] == "" && erb.writer.Header().Get(RequestIDHeader) == "" {
+		erb.Header(RequestIDHeader, erb.requestID)
+	}
+
+	// Emit the matched category headers unless explicitly set on the builder
+	if matchedCategory != nil {
+		matchedCategory.NotifyMatch(erb.context(), erb.err, erb.request)
+		for key, value := range matchedCategory.Headers() {
+			if _, exists := erb.headers[key]; !exists {
+				erb.Header(key, value)
+			}
+		}
+	}
+
 	// Log the error based on category logging policy
-	if erb.err != nil {
-		shouldLog := true
+	if erb.err != nil && erb.loggingEnabled {
+		shouldLog, level := true, slog.LevelError
 		if matchedCategory != nil {
-			shouldLog = matchedCategory.IsLoggingEnabled()
+			shouldLog, level = matchedCategory.IsLoggingEnabled(), matchedCategory.LogLevel()
 		}
 		if shouldLog {
 			if erb.logger != nil {
-				logCtx := erb.ctx
-				if logCtx == nil {
-					logCtx = context.Background()
-				}
-				erb.logger.ErrorContext(
-					logCtx,
-					"HTTP Request Error",
-					slog.String("Error", erb.err.Error()),
-					slog.Int("StatusCode", statusCode),
+				erb.logger.LogAttrs(
+					erb.context(), level, "HTTP Request Error",
+					erb.logAttributes(statusCode, matchedCategory)...,
 				)
-			} else {
+			} else if erb.requestID != "" {
 				// Fallback to stderr if no logger available
+				_, _ = fmt.Fprintf(os.Stderr, "Error: %+v (requestId=%s)\n", erb.err, erb.requestID)
+			} else {
 				_, _ = fmt.Fprintf(os.Stderr, "Error: %+v\n", erb.err)
 			}
 		}
 	}
 
+	// Masked server errors only expose what was explicitly set on the builder
+	masked := statusCode >= http.StatusInternalServerError && erb.masksServerErrors()
+
+	code, details := erb.code, erb.details
+	if code == "" {
+		code = httperr.ErrorCode(erb.err, matchedCategory)
+	}
+	if details == nil && !masked {
+		details = httperr.ErrorDetails(erb.err)
+	}
+
 	// Determine the message to send
-	message := erb.message
-	if message == "" && erb.err != nil {
+	message, _ := erb.translate(code)
+	if message == "" {
+		message = erb.message
+	}
+	var panicErr *PanicError
+	if message == "" && erb.err != nil && !masked && !errors.As(erb.err, &panicErr) {
+		// Panic values are never meant for clients, they fall back to the status text
 		message = erb.err.Error()
 	}
 	if message == "" {
 		message = http.StatusText(statusCode)
 	}
 
-	if erb.isJSON {
-		erb.writeHeaders()
+	if erb.formatter != nil {
+		return erb.sendFormatted(message, statusCode, masked)
+	}
+
+	var debug *errorDebugInfo
+	if erb.devMode && erb.err != nil && !masked {
+		debug = newErrorDebugInfo(erb.err)
+	}
+
+	var fieldErrs FieldErrors
+	hasFieldErrs := erb.err != nil && errors.As(erb.err, &fieldErrs)
+
+	// Enveloped responses are uniform JSON documents
+	enveloped := erb.envelopes()
+	if enveloped && erb.format == ErrorBodyText {
+		erb.AsJSON()
+	}
+
+	switch erb.format {
+	case ErrorBodyJSON:
 		errorResponse := map[string]interface{}{
 			"error":  message,
 			"status": statusCode,
 		}
-		return json.NewEncoder(erb.writer).Encode(errorResponse)
+		if code != "" {
+			errorResponse["code"] = code
+		}
+		if details != nil {
+			errorResponse["details"] = details
+		}
+		if erb.requestID != "" {
+			errorResponse["requestId"] = erb.requestID
+		}
+		if hasFieldErrs {
+			errorResponse["fields"] = fieldErrs
+		}
+		if debug != nil {
+			errorResponse["debug"] = debug
+		}
+		if enveloped {
+			return erb.sendJSON(erb.envelopeError(errorResponse))
+		}
+		return erb.sendJSON(errorResponse)
+
+	case ErrorBodyJSONAPI:
+		return erb.sendJSON(erb.jsonAPIErrors(message, statusCode, matchedCategory, code, details, fieldErrs, debug))
+
+	case ErrorBodyProblem:
+		problem := make(map[string]interface{}, len(erb.extensions)+4)
+		for key, value := range erb.extensions {
+			problem[key] = value
+		}
+		problemType, title := erb.problemTypeAndTitle(matchedCategory, statusCode)
+		problem["type"] = problemType
+		problem["title"] = title
+		problem["status"] = statusCode
+		problem["detail"] = message
+		if erb.instance != "" {
+			problem["instance"] = erb.instance
+		}
+		if code != "" {
+			problem["code"] = code
+		}
+		if details != nil {
+			problem["details"] = details
+		}
+		if erb.requestID != "" {
+			problem["requestId"] = erb.requestID
+		}
+		if hasFieldErrs {
+			problem["fields"] = fieldErrs
+		}
+		if debug != nil {
+			problem["debug"] = debug
+		}
+		return erb.sendJSON(problem)
+	}
+
+	if debug != nil {
+		message += "\n\n" + debug.String()
+	}
+
+	return erb.writeBody([]byte(message))
+}
+
+// logAttributes returns the attributes of the error log entry
+func (erb *ErrorResponseBuilder) logAttributes(statusCode int, category *ErrorCategory) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 6+len(erb.logAttrs))
+	attrs = append(
+		attrs,
+		slog.String("Error", erb.err.Error()),
+		slog.Int("StatusCode", statusCode),
+	)
+	if erb.requestID != "" {
+		attrs = append(attrs, slog.String("RequestID", erb.requestID))
+	}
+	if erb.request != nil {
+		attrs = append(
+			attrs,
+			slog.String("Method", erb.request.Method),
+			slog.String("Path", erb.request.URL.Path),
+		)
+	}
+	if category != nil && category.Name() != "" {
+		attrs = append(attrs, slog.String("Category", category.Name()))
+	}
+	return append(attrs, erb.logAttrs...)
+}
+
+// problemTypeAndTitle resolves the problem type and title from the builder, then the
+// matched category, then the defaults
+func (erb *ErrorResponseBuilder) problemTypeAndTitle(
+	category *ErrorCategory,
+	statusCode int,
+) (string, string) {
+	problemType, title := erb.problemType, erb.title
+	if category != nil {
+		categoryType, categoryTitle := category.ProblemType()
+		if problemType == "" {
+			problemType = categoryType
+		}
+		if title == "" {
+			title = categoryTitle
+		}
+	}
+
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+	if title == "" {
+		title = http.StatusText(statusCode)
+	}
+	return problemType, title
+}
+
+// sendFormatted writes the body produced by the custom error formatter, which receives
+// an error built from the message when the error is masked
+func (erb *ErrorResponseBuilder) sendFormatted(message string, statusCode int, masked bool) error {
+	err := erb.err
+	if err == nil || masked {
+		err = errors.New(message)
+	}
+
+	ctx := erb.context()
+	if erb.request != nil {
+		ctx = erb.request.Context()
+	}
+
+	body, contentType, formatErr := erb.formatter.FormatError(ctx, err, statusCode)
+	if formatErr != nil {
+		return fmt.Errorf("failed to format error response: %w", formatErr)
+	}
+
+	erb.Header("Content-Type", contentType)
+	return erb.writeBody(body)
+}
+
+// errorDebugInfo holds the verbose error details exposed in development mode
+type errorDebugInfo struct {
+	Error  string   `json:"error"`
+	Causes []string `json:"causes,omitempty"`
+	Stack  string   `json:"stack,omitempty"`
+}
+
+func newErrorDebugInfo(err error) *errorDebugInfo {
+	debug := &errorDebugInfo{Error: fmt.Sprintf("%+v", err)}
+
+	causes := unwrapErrors(err)
+	for len(causes) > 0 {
+		cause := causes[0]
+		causes = append(causes[1:], unwrapErrors(cause)...)
+		debug.Causes = append(debug.Causes, cause.Error())
 	}
 
-	erb.writeHeaders()
-	_, err := erb.writer.Write([]byte(message))
-	return err
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		debug.Stack = string(panicErr.Stack)
+	}
+
+	return debug
+}
+
+// unwrapErrors returns the errors directly wrapped by err
+func unwrapErrors(err error) []error {
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapped.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		return append([]error(nil), wrapped.Unwrap()...)
+	}
+	return nil
+}
+
+// String renders the debug details as plain text
+func (debug *errorDebugInfo) String() string {
+	text := "Error: " + debug.Error
+	if len(debug.Causes) > 0 {
+		text += "\nCauses:"
+		for _, cause := range debug.Causes {
+			text += "\n  - " + cause
+		}
+	}
+	if debug.Stack != "" {
+		text += "\n\nStack:\n" + debug.Stack
+	}
+	return text
 }
@@ -5,10 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -35,6 +39,22 @@ func (e ValidationError) Error() string {
 	return "validation failed for field: " + e.field
 }
 
+type InsufficientFundsError struct {
+	balance int
+}
+
+func (e InsufficientFundsError) Error() string {
+	return "insufficient funds"
+}
+
+func (e InsufficientFundsError) ErrorCode() string {
+	return "INSUFFICIENT_FUNDS"
+}
+
+func (e InsufficientFundsError) ErrorDetails() map[string]interface{} {
+	return map[string]interface{}{"balance": e.balance}
+}
+
 type ResponseSuite struct {
 	suite.Suite
 }
@@ -74,6 +94,46 @@ func (suite *ResponseSuite) TestItCanBuildJSONResponse() {
 	suite.Assert().Equal("hello world", result["message"])
 }
 
+func (suite *ResponseSuite) TestItDefersTheStatusUntilTheBodyIsReady() {
+	testCases := map[string]struct {
+		send func(builder *ResponseBuilder) error
+	}{
+		"json encoding failure": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.JSON().Data(map[string]interface{}{"callback": func() {}}).Send()
+			},
+		},
+		"binary read failure": {
+			send: func(builder *ResponseBuilder) error {
+				return builder.Binary().Reader(iotest.ErrReader(errors.New("disk failure")), 10).Send()
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).Status(http.StatusCreated)
+
+				err := testCase.send(builder)
+
+				suite.Require().Error(err)
+				suite.False(builder.Committed())
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Empty(recorder.Header())
+				suite.Zero(recorder.Body.Len())
+
+				errorBuilder := NewResponseBuilder(recorder).Error().WithError(err).DisableLogging()
+				suite.Require().NoError(errorBuilder.Send())
+				suite.True(errorBuilder.Committed())
+				suite.Equal(http.StatusInternalServerError, recorder.Code)
+				suite.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+			},
+		)
+	}
+}
+
 func (suite *ResponseSuite) TestItCanBuildTextResponse() {
 	recorder := httptest.NewRecorder()
 	content := "Hello, World!"
@@ -443,3 +503,697 @@ func (suite *ResponseSuite) TestItCanEnableLoggingPerCategory() {
 	suite.Assert().Contains(logOutput, "validation failed for field: age")
 	suite.Assert().Contains(logOutput, "StatusCode=400")
 }
+
+func (suite *ResponseSuite) TestItCanLogErrorsAtTheCategoryLevel() {
+	testCases := map[string]struct {
+		category      *ErrorCategory
+		err           error
+		expectedLevel string
+	}{
+		"default level": {
+			category:      NewErrorCategory(http.StatusBadRequest),
+			err:           ValidationError{field: "name"},
+			expectedLevel: "level=ERROR",
+		},
+		"info level": {
+			category:      NewErrorCategory(http.StatusBadRequest).WithLogLevel(slog.LevelInfo),
+			err:           ValidationError{field: "name"},
+			expectedLevel: "level=INFO",
+		},
+		"unmatched error": {
+			category:      NewErrorCategory(http.StatusBadRequest).WithLogLevel(slog.LevelInfo),
+			err:           errors.New("unexpected"),
+			expectedLevel: "level=ERROR",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				var logBuffer bytes.Buffer
+				AddErrorType[ValidationError](testCase.category)
+
+				err := NewResponseBuilder(httptest.NewRecorder()).
+					Error().
+					WithError(testCase.err).
+					AddErrorCategory(testCase.category).
+					WithLogger(slog.New(slog.NewTextHandler(&logBuffer, nil))).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Contains(logBuffer.String(), testCase.expectedLevel)
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItCanAddLogAttributes() {
+	var logBuffer bytes.Buffer
+	category := NewErrorCategory(http.StatusBadRequest).WithName("validation")
+	AddErrorType[ValidationError](category)
+	request := httptest.NewRequest(http.MethodPost, "/users?debug=1", nil)
+
+	err := NewResponseBuilder(httptest.NewRecorder()).
+		Error().
+		WithError(ValidationError{field: "name"}).
+		AddErrorCategory(category).
+		WithRequest(request).
+		WithRequestID("req-1").
+		WithLogger(slog.New(slog.NewTextHandler(&logBuffer, nil))).
+		WithLogAttrs(slog.String("TenantID", "acme")).
+		Send()
+
+	suite.Require().NoError(err)
+	logOutput := logBuffer.String()
+	suite.Contains(logOutput, "StatusCode=400")
+	suite.Contains(logOutput, "RequestID=req-1")
+	suite.Contains(logOutput, "Method=POST")
+	suite.Contains(logOutput, "Path=/users ")
+	suite.Contains(logOutput, "Category=validation")
+	suite.Contains(logOutput, "TenantID=acme")
+}
+
+func (suite *ResponseSuite) TestItCanIncludeRequestIDAndDisableLogging() {
+	recorder := httptest.NewRecorder()
+	var logBuffer bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logBuffer, nil))
+
+	err := NewResponseBuilder(recorder).
+		Error().
+		WithError(errors.New("already logged")).
+		WithLogger(logger).
+		WithRequestID("req-1").
+		DisableLogging().
+		AsJSON().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().JSONEq(
+		`{"error":"already logged","status":500,"requestId":"req-1"}`,
+		recorder.Body.String(),
+	)
+	suite.Assert().Empty(logBuffer.String())
+}
+
+func (suite *ResponseSuite) TestItIncludesTheRequestIDFromContext() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request = request.WithContext(WithRequestID(request.Context(), "req-ctx"))
+
+	testCases := map[string]struct {
+		configure      func(builder *ErrorResponseBuilder)
+		headerWritten  string
+		expectedID     string
+		expectedHeader string
+	}{
+		"request context": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(request)
+			},
+			expectedID:     "req-ctx",
+			expectedHeader: "req-ctx",
+		},
+		"builder context": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithContext(WithRequestID(context.Background(), "req-builder"))
+			},
+			expectedID:     "req-builder",
+			expectedHeader: "req-builder",
+		},
+		"explicit id": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(request).WithRequestID("req-explicit")
+			},
+			expectedID:     "req-explicit",
+			expectedHeader: "req-explicit",
+		},
+		"header already written": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(request)
+			},
+			headerWritten:  "req-middleware",
+			expectedID:     "req-ctx",
+			expectedHeader: "req-middleware",
+		},
+		"no id": {
+			configure: func(builder *ErrorResponseBuilder) {},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				if testCase.headerWritten != "" {
+					recorder.Header().Set(RequestIDHeader, testCase.headerWritten)
+				}
+				var logBuffer bytes.Buffer
+				builder := NewResponseBuilder(recorder).
+					Error().
+					WithError(errors.New("failed")).
+					WithLogger(slog.New(slog.NewTextHandler(&logBuffer, nil))).
+					AsJSON()
+				testCase.configure(builder)
+
+				suite.Require().NoError(builder.Send())
+
+				suite.Equal(testCase.expectedHeader, recorder.Header().Get(RequestIDHeader))
+				var body map[string]interface{}
+				suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &body))
+				if testCase.expectedID != "" {
+					suite.Equal(testCase.expectedID, body["requestId"])
+				} else {
+					suite.NotContains(body, "requestId")
+				}
+				if testCase.expectedID != "" {
+					suite.Contains(logBuffer.String(), "RequestID="+testCase.expectedID)
+				} else {
+					suite.NotContains(logBuffer.String(), "RequestID")
+				}
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItCanMaskServerErrors() {
+	defaultMask := DefaultMaskServerErrors
+	defer func() { DefaultMaskServerErrors = defaultMask }()
+
+	dbErr := fmt.Errorf("query users: %w", errors.New("connection refused on 10.0.0.5"))
+
+	testCases := map[string]struct {
+		globalMask bool
+		err        error
+		configure  func(builder *ErrorResponseBuilder)
+		expected   string
+	}{
+		"unmasked by default": {
+			err:      dbErr,
+			expected: `{"error":"query users: connection refused on 10.0.0.5","status":500}`,
+		},
+		"masked globally": {
+			globalMask: true,
+			err:        dbErr,
+			expected:   `{"error":"Internal Server Error","status":500}`,
+		},
+		"masked per builder": {
+			err: dbErr,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.MaskServerErrors(true).WithDevMode(true)
+			},
+			expected: `{"error":"Internal Server Error","status":500}`,
+		},
+		"unmasked per builder": {
+			globalMask: true,
+			err:        dbErr,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.MaskServerErrors(false)
+			},
+			expected: `{"error":"query users: connection refused on 10.0.0.5","status":500}`,
+		},
+		"client errors kept": {
+			globalMask: true,
+			err:        CustomHTTPError{message: "name is required", statusCode: http.StatusBadRequest},
+			expected:   `{"error":"name is required","status":400}`,
+		},
+		"details dropped": {
+			globalMask: true,
+			err: fmt.Errorf(
+				"%w: %w",
+				CustomHTTPError{message: "upstream down", statusCode: http.StatusBadGateway},
+				InsufficientFundsError{balance: 30},
+			),
+			expected: `{"error":"Bad Gateway","status":502,"code":"INSUFFICIENT_FUNDS"}`,
+		},
+		"explicit message kept": {
+			globalMask: true,
+			err:        dbErr,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithMessage("Please retry later")
+			},
+			expected: `{"error":"Please retry later","status":500}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				DefaultMaskServerErrors = testCase.globalMask
+				recorder := httptest.NewRecorder()
+				var logBuffer bytes.Buffer
+				builder := NewResponseBuilder(recorder).
+					Error().
+					WithError(testCase.err).
+					WithLogger(slog.New(slog.NewTextHandler(&logBuffer, nil))).
+					AsJSON()
+				if testCase.configure != nil {
+					testCase.configure(builder)
+				}
+
+				suite.Require().NoError(builder.Send())
+				suite.JSONEq(testCase.expected, recorder.Body.String())
+				suite.Contains(logBuffer.String(), builder.err.Error())
+			},
+		)
+	}
+
+	var formatted error
+	err := NewResponseBuilder(httptest.NewRecorder()).
+		Error().
+		WithError(dbErr).
+		MaskServerErrors(true).
+		DisableLogging().
+		WithFormatter(
+			ErrorFormatterFunc(
+				func(ctx context.Context, err error, statusCode int) ([]byte, string, error) {
+					formatted = err
+					return nil, "text/plain", nil
+				},
+			),
+		).
+		Send()
+
+	suite.Require().NoError(err)
+	suite.EqualError(formatted, "Internal Server Error")
+}
+
+func (suite *ResponseSuite) TestItHidesPanicValuesFromClients() {
+	sentinelError := errors.New("dependency down")
+	category := NewErrorCategory(http.StatusServiceUnavailable)
+	category.AddSentinelError(sentinelError)
+
+	testCases := map[string]struct {
+		panicError   *PanicError
+		expectedCode int
+		expectedBody string
+	}{
+		"plain panic value": {
+			panicError:   &PanicError{Value: "secret internals"},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "Internal Server Error",
+		},
+		"panic with categorized error": {
+			panicError:   &PanicError{Value: sentinelError},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "Service Unavailable",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+
+				err := NewResponseBuilder(recorder).
+					Error().
+					WithError(testCase.panicError).
+					AddErrorCategory(category).
+					DisableLogging().
+					Send()
+
+				suite.Assert().NoError(err)
+				suite.Assert().Equal(testCase.expectedCode, recorder.Code)
+				suite.Assert().Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItCanBuildProblemDetailsErrorResponse() {
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		Error().
+		WithError(CustomHTTPError{message: "order is locked", statusCode: http.StatusConflict}).
+		WithInstance("/orders/1").
+		WithRequestID("req-9").
+		AsProblem().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusConflict, recorder.Code)
+	suite.Assert().Equal("application/problem+json", recorder.Header().Get("Content-Type"))
+	suite.Assert().JSONEq(
+		`{"type":"about:blank","title":"Conflict","status":409,"detail":"order is locked",`+
+			`"instance":"/orders/1","requestId":"req-9"}`,
+		recorder.Body.String(),
+	)
+}
+
+func (suite *ResponseSuite) TestItCanDeriveProblemTypesAndExtensions() {
+	outOfStock := errors.New("out of stock")
+	category := NewErrorCategory(http.StatusConflict).
+		WithProblemType("https://example.com/problems/out-of-stock", "Out of stock").
+		DisableLogging()
+	category.AddSentinelError(outOfStock)
+
+	testCases := map[string]struct {
+		configure    func(builder *ErrorResponseBuilder) *ErrorResponseBuilder
+		expectedBody string
+	}{
+		"category type": {
+			configure: func(builder *ErrorResponseBuilder) *ErrorResponseBuilder {
+				return builder.WithExtension("sku", "A-1").WithExtension("status", 200)
+			},
+			expectedBody: `{"type":"https://example.com/problems/out-of-stock","title":"Out of stock",` +
+				`"status":409,"detail":"reserving: out of stock","sku":"A-1"}`,
+		},
+		"builder type": {
+			configure: func(builder *ErrorResponseBuilder) *ErrorResponseBuilder {
+				return builder.WithProblemType("https://example.com/problems/reservation").WithTitle("Reservation failed")
+			},
+			expectedBody: `{"type":"https://example.com/problems/reservation","title":"Reservation failed",` +
+				`"status":409,"detail":"reserving: out of stock"}`,
+		},
+		"builder title with category type": {
+			configure: func(builder *ErrorResponseBuilder) *ErrorResponseBuilder {
+				return builder.WithTitle("Unavailable")
+			},
+			expectedBody: `{"type":"https://example.com/problems/out-of-stock","title":"Unavailable",` +
+				`"status":409,"detail":"reserving: out of stock"}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).
+					Error().
+					WithError(fmt.Errorf("reserving: %w", outOfStock)).
+					AddErrorCategory(category).
+					AsProblem()
+
+				suite.Assert().NoError(testCase.configure(builder).Send())
+				suite.Assert().Equal(http.StatusConflict, recorder.Code)
+				suite.Assert().JSONEq(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItCanBuildJSONAPIErrorResponse() {
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).
+		Error().
+		WithError(CustomHTTPError{message: "order is locked", statusCode: http.StatusConflict}).
+		WithRequestID("req-9").
+		WithCode("ORDER_LOCKED").
+		AsJSONAPI().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusConflict, recorder.Code)
+	suite.Assert().Equal("application/vnd.api+json", recorder.Header().Get("Content-Type"))
+	suite.Assert().JSONEq(
+		`{"errors":[{"id":"req-9","status":"409","code":"ORDER_LOCKED","title":"Conflict",`+
+			`"detail":"order is locked"}]}`,
+		recorder.Body.String(),
+	)
+
+	fieldsRecorder := httptest.NewRecorder()
+	err = NewResponseBuilder(fieldsRecorder).
+		Error().
+		WithError(FieldErrors{}.Add("email", "is required").Add("email", "is invalid").Add("/data/id", "is unknown")).
+		DisableLogging().
+		AsJSONAPI().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusUnprocessableEntity, fieldsRecorder.Code)
+	suite.Assert().JSONEq(
+		`{"errors":[`+
+			`{"status":"422","title":"Unprocessable Entity","detail":"is unknown","source":{"pointer":"/data/id"}},`+
+			`{"status":"422","title":"Unprocessable Entity","detail":"is required",`+
+			`"source":{"pointer":"/data/attributes/email"}},`+
+			`{"status":"422","title":"Unprocessable Entity","detail":"is invalid",`+
+			`"source":{"pointer":"/data/attributes/email"}}]}`,
+		fieldsRecorder.Body.String(),
+	)
+}
+
+func (suite *ResponseSuite) TestItCanRenderErrorCodesAndDetails() {
+	notFound := errors.New("not found")
+	category := NewErrorCategory(http.StatusNotFound).WithCode("NOT_FOUND").DisableLogging()
+	category.AddSentinelError(notFound)
+	AddErrorType[InsufficientFundsError](category)
+
+	testCases := map[string]struct {
+		err       error
+		configure func(builder *ErrorResponseBuilder)
+		expected  string
+	}{
+		"coded error": {
+			err: fmt.Errorf("payment: %w", InsufficientFundsError{balance: 30}),
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsJSON()
+			},
+			expected: `{"error":"payment: insufficient funds","status":404,"code":"INSUFFICIENT_FUNDS",` +
+				`"details":{"balance":30}}`,
+		},
+		"category code": {
+			err: notFound,
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsJSON()
+			},
+			expected: `{"error":"not found","status":404,"code":"NOT_FOUND"}`,
+		},
+		"builder code and details": {
+			err: InsufficientFundsError{balance: 30},
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsJSON().WithCode("PAYMENT_FAILED").WithDetails(map[string]interface{}{"retry": true})
+			},
+			expected: `{"error":"insufficient funds","status":404,"code":"PAYMENT_FAILED",` +
+				`"details":{"retry":true}}`,
+		},
+		"problem details": {
+			err: InsufficientFundsError{balance: 30},
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsProblem()
+			},
+			expected: `{"type":"about:blank","title":"Not Found","status":404,"detail":"insufficient funds",` +
+				`"code":"INSUFFICIENT_FUNDS","details":{"balance":30}}`,
+		},
+		"json api": {
+			err: InsufficientFundsError{balance: 30},
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.AsJSONAPI()
+			},
+			expected: `{"errors":[{"status":"404","code":"INSUFFICIENT_FUNDS","title":"Not Found",` +
+				`"detail":"insufficient funds","meta":{"details":{"balance":30}}}]}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).Error().WithError(testCase.err).AddErrorCategory(category)
+				testCase.configure(builder)
+
+				suite.Require().NoError(builder.Send())
+				suite.JSONEq(testCase.expected, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItEmitsErrorCategoryHeaders() {
+	rateLimited := errors.New("rate limited")
+	category := NewErrorCategory(http.StatusTooManyRequests).
+		WithRetryAfter(1500*time.Millisecond).
+		WithHeader("x-ratelimit-remaining", "0").
+		DisableLogging()
+	category.AddSentinelError(rateLimited)
+
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).
+		Header("X-Ratelimit-Remaining", "explicit").
+		Error().
+		WithError(fmt.Errorf("quota: %w", rateLimited)).
+		AddErrorCategory(category).
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Assert().Equal("2", recorder.Header().Get("Retry-After"))
+	suite.Assert().Equal("explicit", recorder.Header().Get("X-Ratelimit-Remaining"))
+	suite.Assert().Equal(
+		map[string]string{"Retry-After": "2", "X-Ratelimit-Remaining": "0"},
+		category.Headers(),
+	)
+
+	unmatched := httptest.NewRecorder()
+	err = NewResponseBuilder(unmatched).
+		Error().
+		WithError(errors.New("other")).
+		AddErrorCategory(category).
+		DisableLogging().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Empty(unmatched.Header().Get("Retry-After"))
+}
+
+func (suite *ResponseSuite) TestItCanExposeErrorDetailsInDevMode() {
+	rootCause := errors.New("connection refused")
+	wrappedError := fmt.Errorf("loading user: %w", fmt.Errorf("querying db: %w", rootCause))
+
+	textRecorder := httptest.NewRecorder()
+	err := NewResponseBuilder(textRecorder).
+		Error().
+		WithError(wrappedError).
+		WithMessage("Could not load user").
+		WithDevMode(true).
+		DisableLogging().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(
+		"Could not load user\n\n"+
+			"Error: loading user: querying db: connection refused\n"+
+			"Causes:\n"+
+			"  - querying db: connection refused\n"+
+			"  - connection refused",
+		textRecorder.Body.String(),
+	)
+
+	jsonRecorder := httptest.NewRecorder()
+	err = NewResponseBuilder(jsonRecorder).
+		Error().
+		WithError(&PanicError{Value: wrappedError, Stack: []byte("main.go:1")}).
+		WithDevMode(true).
+		DisableLogging().
+		AsJSON().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().JSONEq(
+		`{"error":"Internal Server Error","status":500,"debug":{`+
+			`"error":"panic: loading user: querying db: connection refused",`+
+			`"causes":["loading user: querying db: connection refused",`+
+			`"querying db: connection refused","connection refused"],`+
+			`"stack":"main.go:1"}}`,
+		jsonRecorder.Body.String(),
+	)
+
+	productionRecorder := httptest.NewRecorder()
+	err = NewResponseBuilder(productionRecorder).
+		Error().
+		WithError(wrappedError).
+		WithMessage("Could not load user").
+		DisableLogging().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal("Could not load user", productionRecorder.Body.String())
+}
+
+func (suite *ResponseSuite) TestItCanRenderErrorsWithCustomFormatter() {
+	type contextKey string
+	formatter := ErrorFormatterFunc(
+		func(ctx context.Context, err error, statusCode int) ([]byte, string, error) {
+			body, encodeErr := json.Marshal(
+				map[string]interface{}{
+					"envelope": map[string]interface{}{
+						"code":    statusCode,
+						"reason":  err.Error(),
+						"service": ctx.Value(contextKey("service")),
+					},
+				},
+			)
+			return body, "application/vnd.org.error+json", encodeErr
+		},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request = request.WithContext(context.WithValue(request.Context(), contextKey("service"), "billing"))
+
+	testCases := map[string]struct {
+		builder      func(*ErrorResponseBuilder) *ErrorResponseBuilder
+		expectedBody string
+	}{
+		"error": {
+			builder: func(erb *ErrorResponseBuilder) *ErrorResponseBuilder {
+				return erb.WithError(CustomHTTPError{"payment declined", http.StatusPaymentRequired}).AsJSON()
+			},
+			expectedBody: `{"envelope":{"code":402,"reason":"payment declined","service":"billing"}}`,
+		},
+		"message only": {
+			builder: func(erb *ErrorResponseBuilder) *ErrorResponseBuilder {
+				erb.Status(http.StatusPaymentRequired)
+				return erb.WithMessage("pay first")
+			},
+			expectedBody: `{"envelope":{"code":402,"reason":"pay first","service":"billing"}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				erb := NewResponseBuilder(recorder).
+					Sign(testSigningKey).
+					Buffered().
+					Error().
+					WithRequest(request).
+					WithFormatter(formatter).
+					DisableLogging()
+
+				err := testCase.builder(erb).Send()
+
+				suite.Assert().NoError(err)
+				suite.Assert().Equal(http.StatusPaymentRequired, recorder.Code)
+				suite.Assert().Equal(
+					"application/vnd.org.error+json",
+					recorder.Header().Get("Content-Type"),
+				)
+				suite.Assert().JSONEq(testCase.expectedBody, recorder.Body.String())
+				suite.Assert().Equal(
+					strconv.Itoa(recorder.Body.Len()), recorder.Header().Get("Content-Length"),
+				)
+				suite.Assert().True(
+					VerifySignature(
+						testSigningKey, recorder.Body.Bytes(), recorder.Header().Get(SignatureHeader),
+					),
+				)
+			},
+		)
+	}
+}
+
+func (suite *ResponseSuite) TestItCanRenderFieldErrors() {
+	fieldErrs := FieldErrors{}.Add("email", "is required")
+
+	jsonRecorder := httptest.NewRecorder()
+	err := NewResponseBuilder(jsonRecorder).
+		Error().
+		WithError(fieldErrs).
+		DisableLogging().
+		AsJSON().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusUnprocessableEntity, jsonRecorder.Code)
+	suite.Assert().JSONEq(
+		`{"error":"validation failed: email: is required","status":422,`+
+			`"fields":{"email":["is required"]}}`,
+		jsonRecorder.Body.String(),
+	)
+
+	problemRecorder := httptest.NewRecorder()
+	err = NewResponseBuilder(problemRecorder).
+		Error().
+		WithError(fmt.Errorf("signup: %w", fieldErrs)).
+		WithMessage("Invalid input").
+		DisableLogging().
+		AsProblem().
+		Send()
+
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(http.StatusUnprocessableEntity, problemRecorder.Code)
+	suite.Assert().JSONEq(
+		`{"type":"about:blank","title":"Unprocessable Entity","status":422,`+
+			`"detail":"Invalid input","fields":{"email":["is required"]}}`,
+		problemRecorder.Body.String(),
+	)
+}
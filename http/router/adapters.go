@@ -0,0 +1,54 @@
+package router
+
+import "net/http"
+
+// Middlewares returns the chain middlewares outermost first, the order expected by the
+// Use methods of third-party routers, e.g. chi or gorilla/mux:
+//
+//	chiRouter.Use(chain.Middlewares()...)
+//	for _, mw := range chain.Middlewares() {
+//		gorillaRouter.Use(mw)
+//	}
+//
+// Disabled (nil) middlewares are left out.
+func (chain Chain) Middlewares() []func(http.Handler) http.Handler {
+	middlewares := make([]func(http.Handler) http.Handler, 0, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Middleware != nil {
+			middlewares = append(middlewares, chain[i].Middleware)
+		}
+	}
+	return middlewares
+}
+
+// Middleware returns the whole chain as a single middleware, e.g. for
+// chiRouter.With(chain.Middleware()) or route scoped gorilla/mux middlewares
+func (chain Chain) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return chain.Then(next)
+	}
+}
+
+// PathValues exposes the path parameters extracted by a third-party router through
+// http.Request.PathValue, so the typed parameter helpers (Param, ParamInt...) and
+// handlers written for ServerMuxWrapper work unchanged. For gorilla/mux:
+//
+//	gorillaRouter.Use(router.PathValues(mux.Vars))
+//
+// Existing path values are kept when the router does not report the parameter.
+func PathValues(vars func(r *http.Request) map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				values := vars(r)
+				if len(values) > 0 {
+					r = r.Clone(r.Context())
+					for name, value := range values {
+						r.SetPathValue(name, value)
+					}
+				}
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
@@ -0,0 +1,93 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AdaptersTestSuite struct {
+	suite.Suite
+}
+
+func TestAdaptersSuite(t *testing.T) {
+	suite.Run(t, new(AdaptersTestSuite))
+}
+
+// thirdPartyRouter mimics the Use/vars API of routers like chi and gorilla/mux
+type thirdPartyRouter struct {
+	middlewares []func(http.Handler) http.Handler
+	handler     http.Handler
+}
+
+func (router *thirdPartyRouter) Use(middlewares ...func(http.Handler) http.Handler) {
+	router.middlewares = append(router.middlewares, middlewares...)
+}
+
+func (router *thirdPartyRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler := router.handler
+	for i := len(router.middlewares) - 1; i >= 0; i-- {
+		handler = router.middlewares[i](handler)
+	}
+	handler.ServeHTTP(w, r)
+}
+
+func thirdPartyVars(r *http.Request) map[string]string {
+	return map[string]string{"id": strings.TrimPrefix(r.URL.Path, "/users/")}
+}
+
+func (suite *AdaptersTestSuite) TestItCanUseChainsWithThirdPartyRouters() {
+	chain := NewChain(testNamedMiddleware("inner"), Disable("removed"), testNamedMiddleware("outer"))
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			id, err := ParamInt(r, "id")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Add("X-Middleware", "handler")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte{byte('0' + id)})
+		},
+	)
+
+	testCases := map[string]func(router *thirdPartyRouter){
+		"use middlewares": func(router *thirdPartyRouter) {
+			router.Use(PathValues(thirdPartyVars))
+			router.Use(chain.Middlewares()...)
+		},
+		"single middleware": func(router *thirdPartyRouter) {
+			router.Use(PathValues(thirdPartyVars), chain.Middleware())
+		},
+	}
+
+	for name, setup := range testCases {
+		suite.Run(
+			name, func() {
+				router := &thirdPartyRouter{handler: handler}
+				setup(router)
+
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal("7", recorder.Body.String())
+				suite.Equal([]string{"outer", "inner", "handler"}, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+}
+
+func (suite *AdaptersTestSuite) TestItCanMountAThirdPartyRouter() {
+	mux := NewServerMuxWrapper(NewChain(testNamedMiddleware("default")))
+	mux.Mount("/legacy", &thirdPartyRouter{handler: testHandler()})
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/legacy/users", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("default", recorder.Header().Get("X-Middleware"))
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrNotCatchAll is wrapped by ParamError when the parameter is not the trailing
+	// {name...} wildcard of the matched route pattern
+	ErrNotCatchAll = errors.New("not a catch-all parameter")
+
+	// ErrUnsafePath is wrapped by ParamError when a catch-all parameter does not
+	// designate a path inside the root, e.g. because of ".." segments
+	ErrUnsafePath = errors.New("unsafe path")
+)
+
+// ParamRest returns the remainder matched by the trailing {name...} wildcard of the route
+// pattern as sent by the client: still percent-encoded, slashes included, possibly empty.
+// Unlike Param, encoded slashes ("%2F") stay distinguishable from separators, which
+// proxies forwarding the remainder need.
+func ParamRest(r *http.Request, name string) (string, error) {
+	_, _, patternPath := splitPattern(r.Pattern)
+	patternSegments := strings.Split(patternPath, "/")
+
+	last := len(patternSegments) - 1
+	if r.Pattern == "" || patternSegments[last] != "{"+name+"...}" {
+		return "", &ParamError{Name: name, Err: ErrNotCatchAll}
+	}
+
+	segments := strings.SplitN(r.URL.EscapedPath(), "/", last+1)
+	if len(segments) <= last {
+		return "", nil
+	}
+	return segments[last], nil
+}
+
+// ParamFilePath returns the remainder matched by a {name...} wildcard as a slash separated
+// path relative to a root, suitable for io/fs ("." for an empty remainder). It fails with
+// a ParamError wrapping ErrUnsafePath when the decoded remainder contains "." or ".."
+// segments, backslashes or NUL bytes, so it cannot escape the root.
+func ParamFilePath(r *http.Request, name string) (string, error) {
+	value := r.PathValue(name)
+	if value == "" {
+		return ".", nil
+	}
+
+	if strings.ContainsAny(value, "\\\x00") {
+		return "", &ParamError{Name: name, Value: value, Err: ErrUnsafePath}
+	}
+
+	cleaned := strings.Trim(path.Clean("/"+value), "/")
+	if cleaned == "" {
+		cleaned = "."
+	}
+	if !fs.ValidPath(cleaned) || cleaned != strings.Trim(value, "/") {
+		return "", &ParamError{Name: name, Value: value, Err: ErrUnsafePath}
+	}
+	return cleaned, nil
+}
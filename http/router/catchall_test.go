@@ -0,0 +1,119 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CatchAllTestSuite struct {
+	suite.Suite
+}
+
+func TestCatchAllSuite(t *testing.T) {
+	suite.Run(t, new(CatchAllTestSuite))
+}
+
+// serveParam registers the pattern on a fresh mux and returns the value and error of
+// the parameter helper for the target
+func (suite *CatchAllTestSuite) serveParam(
+	pattern string,
+	target string,
+	helper func(r *http.Request, name string) (string, error),
+) (string, error) {
+	var value string
+	var err error
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		pattern, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				value, err = helper(r, "path")
+			},
+		),
+	)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+	return value, err
+}
+
+func (suite *CatchAllTestSuite) TestItCanCaptureRawRemainders() {
+	testCases := map[string]struct {
+		pattern       string
+		target        string
+		expectedValue string
+		expectedError error
+	}{
+		"nested segments": {
+			pattern:       "/proxy/{path...}",
+			target:        "/proxy/api/v1/users",
+			expectedValue: "api/v1/users",
+		},
+		"encoded characters": {
+			pattern:       "GET example.com/proxy/{service}/{path...}",
+			target:        "http://example.com/proxy/users/a%2Fb/c%20d",
+			expectedValue: "a%2Fb/c%20d",
+		},
+		"empty remainder": {
+			pattern:       "/proxy/{path...}",
+			target:        "/proxy/",
+			expectedValue: "",
+		},
+		"not a catch-all": {
+			pattern:       "/proxy/{path}",
+			target:        "/proxy/users",
+			expectedError: ErrNotCatchAll,
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				value, err := suite.serveParam(tc.pattern, tc.target, ParamRest)
+				if tc.expectedError != nil {
+					suite.True(errors.Is(err, tc.expectedError))
+					return
+				}
+				suite.NoError(err)
+				suite.Equal(tc.expectedValue, value)
+			},
+		)
+	}
+}
+
+func (suite *CatchAllTestSuite) TestItCanCaptureFilePaths() {
+	testCases := map[string]struct {
+		target        string
+		expectedValue string
+		expectUnsafe  bool
+	}{
+		"file":                {target: "/files/docs/readme.md", expectedValue: "docs/readme.md"},
+		"directory":           {target: "/files/docs/", expectedValue: "docs"},
+		"root":                {target: "/files/", expectedValue: "."},
+		"encoded slash":       {target: "/files/docs%2Freadme.md", expectedValue: "docs/readme.md"},
+		"encoded traversal":   {target: "/files/docs%2F..%2F..%2Fetc%2Fpasswd", expectUnsafe: true},
+		"encoded backslash":   {target: "/files/..%5Csecret", expectUnsafe: true},
+		"encoded dot segment": {target: "/files/docs%2F.%2Freadme.md", expectUnsafe: true},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				value, err := suite.serveParam("/files/{path...}", tc.target, ParamFilePath)
+				if tc.expectUnsafe {
+					suite.True(errors.Is(err, ErrUnsafePath))
+					var paramErr *ParamError
+					suite.Require().True(errors.As(err, &paramErr))
+					suite.Equal(http.StatusBadRequest, paramErr.StatusCode())
+					return
+				}
+				suite.NoError(err)
+				suite.Equal(tc.expectedValue, value)
+			},
+		)
+	}
+}
@@ -0,0 +1,82 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Chain is an ordered list of named middlewares, the first entry being the innermost.
+// Its methods return a new chain and leave the receiver untouched, so a shared base
+// chain can be derived safely. It can be used wherever a []NamedMiddleware is expected.
+type Chain []NamedMiddleware
+
+// NewChain creates a chain of the given middlewares
+func NewChain(middlewares ...NamedMiddleware) Chain {
+	return append(Chain(nil), middlewares...)
+}
+
+// Append adds the middlewares at the end of the chain (outermost)
+func (chain Chain) Append(middlewares ...NamedMiddleware) Chain {
+	return append(chain.clone(len(middlewares)), middlewares...)
+}
+
+// Insert adds the middlewares right after the named one, so they wrap it.
+// It panics when no middleware has that name.
+func (chain Chain) Insert(after string, middlewares ...NamedMiddleware) Chain {
+	index := chain.mustIndex(after)
+	inserted := make(Chain, 0, len(chain)+len(middlewares))
+	inserted = append(inserted, chain[:index+1]...)
+	inserted = append(inserted, middlewares...)
+	return append(inserted, chain[index+1:]...)
+}
+
+// Replace swaps the middleware having the same name, keeping its position.
+// It panics when no middleware has that name.
+func (chain Chain) Replace(middleware NamedMiddleware) Chain {
+	index := chain.mustIndex(middleware.Name)
+	replaced := chain.clone(0)
+	replaced[index] = middleware
+	return replaced
+}
+
+// Remove drops the named middlewares; unknown names are ignored
+func (chain Chain) Remove(names ...string) Chain {
+	removed := make(Chain, 0, len(chain))
+	for _, namedMw := range chain {
+		if !containsString(names, namedMw.Name) {
+			removed = append(removed, namedMw)
+		}
+	}
+	return removed
+}
+
+// Names returns the middleware names in chain order
+func (chain Chain) Names() []string {
+	return middlewareNames(chain, nil)
+}
+
+// Then wraps the handler with the chain
+func (chain Chain) Then(handler http.Handler) http.Handler {
+	return WithNamedMiddlewares(handler, chain, nil)
+}
+
+func (chain Chain) clone(extra int) Chain {
+	return append(make(Chain, 0, len(chain)+extra), chain...)
+}
+
+func (chain Chain) index(name string) int {
+	for i, namedMw := range chain {
+		if namedMw.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (chain Chain) mustIndex(name string) int {
+	index := chain.index(name)
+	if index < 0 {
+		panic(fmt.Sprintf("router: no middleware named %q in chain", name))
+	}
+	return index
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ChainTestSuite struct {
+	suite.Suite
+}
+
+func TestChainSuite(t *testing.T) {
+	suite.Run(t, new(ChainTestSuite))
+}
+
+func testNamedMiddleware(name string) NamedMiddleware {
+	return NamedMiddleware{Name: name, Middleware: createTestMiddleware(name)}
+}
+
+func (suite *ChainTestSuite) TestItCanManipulateChains() {
+	base := NewChain(testNamedMiddleware("recoverer"), testNamedMiddleware("logging"))
+
+	testCases := map[string]struct {
+		chain         Chain
+		expectedNames []string
+	}{
+		"append": {
+			chain:         base.Append(testNamedMiddleware("session")),
+			expectedNames: []string{"recoverer", "logging", "session"},
+		},
+		"insert": {
+			chain:         base.Insert("recoverer", testNamedMiddleware("timeout"), testNamedMiddleware("csrf")),
+			expectedNames: []string{"recoverer", "timeout", "csrf", "logging"},
+		},
+		"replace": {
+			chain:         base.Replace(NamedMiddleware{Name: "logging", Middleware: createTestMiddleware("quiet")}),
+			expectedNames: []string{"recoverer", "logging"},
+		},
+		"remove": {
+			chain:         base.Remove("recoverer", "unknown"),
+			expectedNames: []string{"logging"},
+		},
+		"base untouched": {
+			chain:         base,
+			expectedNames: []string{"recoverer", "logging"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(testCase.expectedNames, testCase.chain.Names())
+			},
+		)
+	}
+}
+
+func (suite *ChainTestSuite) TestItCanWrapHandlers() {
+	chain := NewChain(testNamedMiddleware("recoverer"), testNamedMiddleware("logging")).
+		Replace(NamedMiddleware{Name: "logging", Middleware: createTestMiddleware("quiet")})
+
+	recorder := httptest.NewRecorder()
+	chain.Then(testHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal([]string{"quiet", "recoverer"}, recorder.Header().Values("X-Middleware"))
+
+	mux := NewServerMuxWrapper(chain)
+	mux.Handle("/", testHandler())
+	suite.Equal([]string{"recoverer", "logging"}, mux.Routes()[0].Middlewares)
+}
+
+func (suite *ChainTestSuite) TestItPanicsOnUnknownNames() {
+	chain := NewChain(testNamedMiddleware("logging"))
+
+	suite.Panics(func() { chain.Insert("unknown", testNamedMiddleware("csrf")) })
+	suite.Panics(func() { chain.Replace(testNamedMiddleware("unknown")) })
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// paramConstraints maps path parameter names to the expression their value must match
+type paramConstraints map[string]*regexp.Regexp
+
+// parseConstraints strips regex constraints from a pattern ({id:[0-9]+} becomes {id})
+// and returns the ServeMux pattern with the compiled constraints. Catch-all segments
+// ({path...}) are kept as is and may be constrained too ({path...:.+\.pdf}).
+// Like ServeMux with invalid patterns, it panics on invalid expressions.
+// A request whose parameters do not match falls through to the routes registered with
+// the same pattern apart from wildcard names and constraints ("/users/{slug}" after
+// "/users/{id:[0-9]+}"), constrained ones first, and is answered with the not found
+// handler when none matches.
+func parseConstraints(pattern string) (string, paramConstraints) {
+	var builder strings.Builder
+	var constraints paramConstraints
+
+	for {
+		start := strings.Index(pattern, "{")
+		if start < 0 {
+			builder.WriteString(pattern)
+			break
+		}
+
+		end := matchingBrace(pattern, start)
+		if end < 0 {
+			builder.WriteString(pattern)
+			break
+		}
+
+		builder.WriteString(pattern[:start])
+		wildcard := pattern[start+1 : end]
+		pattern = pattern[end+1:]
+
+		name, expression, constrained := strings.Cut(wildcard, ":")
+		builder.WriteString("{" + name + "}")
+		if !constrained {
+			continue
+		}
+
+		compiled, err := regexp.Compile("^(?:" + expression + ")$")
+		if err != nil {
+			panic(fmt.Sprintf("router: invalid constraint for parameter %q: %v", name, err))
+		}
+		if constraints == nil {
+			constraints = make(paramConstraints)
+		}
+		constraints[strings.TrimSuffix(name, "...")] = compiled
+	}
+
+	return builder.String(), constraints
+}
+
+// matchingBrace returns the index of the brace closing the one at start, accounting
+// for braces nested in expressions such as {code:[A-Z]{3}}
+func matchingBrace(pattern string, start int) int {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// matches reports whether every constrained parameter of the request matches
+func (constraints paramConstraints) matches(r *http.Request) bool {
+	for name, expression := range constraints {
+		if !expression.MatchString(r.PathValue(name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// constrainedRoute is one of the routes sharing a ServeMux pattern
+type constrainedRoute struct {
+	pattern     string
+	names       []string
+	constraints paramConstraints
+	handler     http.Handler
+}
+
+// constrainedRoutes serves the routes whose ServeMux patterns only differ by their
+// wildcard names and constraints ("/users/{id:[0-9]+}" and "/users/{slug}"), which
+// ServeMux cannot register together. Requests fall through the constrained routes in
+// registration order, then to the unconstrained one, and are answered like an unknown
+// path when none matches, before any route middleware runs.
+type constrainedRoutes struct {
+	mu     sync.RWMutex
+	names  []string
+	routes []constrainedRoute
+	reject http.Handler
+}
+
+// add registers a route, keeping the unconstrained one last. It panics when both routes
+// are unconstrained, as ServeMux does for conflicting patterns.
+func (cr *constrainedRoutes) add(route constrainedRoute) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	position := len(cr.routes)
+	if last := position - 1; last >= 0 && len(cr.routes[last].constraints) == 0 {
+		if len(route.constraints) == 0 {
+			panic(
+				fmt.Sprintf(
+					"router: pattern %q conflicts with pattern %q", route.pattern,
+					cr.routes[last].pattern,
+				),
+			)
+		}
+		position = last
+	}
+	cr.routes = slices.Insert(cr.routes, position, route)
+}
+
+func (cr *constrainedRoutes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cr.mu.RLock()
+	routes := cr.routes
+	cr.mu.RUnlock()
+
+	values := make([]string, len(cr.names))
+	for i, name := range cr.names {
+		values[i] = r.PathValue(name)
+	}
+
+	for _, route := range routes {
+		// Path values are set under the names of the route being tried
+		for i, name := range route.names {
+			r.SetPathValue(name, values[i])
+		}
+		if route.constraints.matches(r) {
+			route.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+	cr.reject.ServeHTTP(w, r)
+}
+
+// patternShape returns the ServeMux pattern with its wildcard names removed, shared by
+// the patterns matching the same requests, and the removed names
+func patternShape(muxPattern string) (string, []string) {
+	var shape strings.Builder
+	var names []string
+	for {
+		start := strings.Index(muxPattern, "{")
+		end := strings.Index(muxPattern, "}")
+		if start < 0 || end < start {
+			shape.WriteString(muxPattern)
+			return shape.String(), names
+		}
+
+		shape.WriteString(muxPattern[:start])
+		name := muxPattern[start+1 : end]
+		switch {
+		case name == "$":
+			shape.WriteString("{$}")
+		case strings.HasSuffix(name, "..."):
+			shape.WriteString("{...}")
+			names = append(names, strings.TrimSuffix(name, "..."))
+		default:
+			shape.WriteString("{}")
+			names = append(names, name)
+		}
+		muxPattern = muxPattern[end+1:]
+	}
+}
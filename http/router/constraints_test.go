@@ -0,0 +1,227 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConstraintsTestSuite struct {
+	suite.Suite
+}
+
+func TestConstraintsSuite(t *testing.T) {
+	suite.Run(t, new(ConstraintsTestSuite))
+}
+
+func (suite *ConstraintsTestSuite) TestItCanConstrainPathParams() {
+	paramHandler := func(names ...string) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				for _, name := range names {
+					_, _ = w.Write([]byte(r.PathValue(name) + ";"))
+				}
+			},
+		)
+	}
+
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{{Name: "logging", Middleware: createTestMiddleware("logging")}},
+	)
+	mux.Handle("GET /users/{id:[0-9]+}", paramHandler("id"), WithName("user.show"))
+	mux.Handle("GET /countries/{code:[A-Z]{2}}/cities/{city}", paramHandler("code", "city"))
+	mux.Handle("GET /files/{path...}", paramHandler("path"))
+	mux.Handle("GET /docs/{path...:.+\\.pdf}", paramHandler("path"))
+
+	testCases := map[string]struct {
+		target              string
+		expectedStatus      int
+		expectedBody        string
+		expectedMiddlewares []string
+	}{
+		"numeric id": {
+			target:              "/users/42",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "42;",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"non numeric id": {
+			target:         "/users/abc",
+			expectedStatus: http.StatusNotFound,
+		},
+		"nested braces": {
+			target:              "/countries/RO/cities/cluj",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "RO;cluj;",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"nested braces mismatch": {
+			target:         "/countries/ROU/cities/cluj",
+			expectedStatus: http.StatusNotFound,
+		},
+		"catch all": {
+			target:              "/files/a/b/c.txt",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "a/b/c.txt;",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"constrained catch all": {
+			target:              "/docs/guides/intro.pdf",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "guides/intro.pdf;",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"constrained catch all mismatch": {
+			target:         "/docs/guides/intro.html",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				}
+				suite.Equal(testCase.expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+
+	url, err := mux.URL("user.show", "id", 7)
+	suite.Require().NoError(err)
+	suite.Equal("/users/7", url)
+
+	route := mux.Routes()[0]
+	suite.Equal("GET /users/{id:[0-9]+}", route.Pattern)
+	suite.Equal("/users/{id}", route.Path)
+}
+
+func (suite *ConstraintsTestSuite) TestItUsesNotFoundHandlerOnMismatch() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("GET /users/{id:[0-9]+}", testHandler())
+	mux.NotFound(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("custom"))
+			},
+		),
+	)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/abc", nil))
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal("custom", recorder.Body.String())
+}
+
+func (suite *ConstraintsTestSuite) TestItFallsThroughOnConstraintMismatches() {
+	paramHandler := func(label string, name string) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(label + ":" + r.PathValue(name)))
+			},
+		)
+	}
+	patterns := map[string]string{
+		"id":       "GET /users/{id:[0-9]+}",
+		"code":     "GET /users/{code:[A-Z]+}",
+		"slug":     "GET /users/{slug}",
+		"exact id": "GET /users/{id:[0-9]+}/{$}",
+	}
+	handlers := map[string]http.Handler{
+		"id":       paramHandler("id", "id"),
+		"code":     paramHandler("code", "code"),
+		"slug":     paramHandler("slug", "slug"),
+		"exact id": paramHandler("exact", "id"),
+	}
+
+	testCases := map[string]struct {
+		registrationOrder []string
+		expectedBodies    map[string]string
+	}{
+		"unconstrained route last": {
+			registrationOrder: []string{"id", "code", "slug"},
+			expectedBodies: map[string]string{
+				"/users/42": "id:42", "/users/RO": "code:RO", "/users/jane": "slug:jane",
+			},
+		},
+		"unconstrained route first": {
+			registrationOrder: []string{"slug", "code", "id"},
+			expectedBodies: map[string]string{
+				"/users/42": "id:42", "/users/RO": "code:RO", "/users/jane": "slug:jane",
+			},
+		},
+		"constrained routes only": {
+			registrationOrder: []string{"code", "id"},
+			expectedBodies:    map[string]string{"/users/42": "id:42", "/users/RO": "code:RO"},
+		},
+		"other shapes": {
+			registrationOrder: []string{"exact id", "slug"},
+			expectedBodies:    map[string]string{"/users/42/": "exact:42", "/users/42": "slug:42"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				mux := NewServerMuxWrapper(nil)
+				mux.SetStrictRouting(true)
+				for _, route := range testCase.registrationOrder {
+					mux.Handle(patterns[route], handlers[route])
+				}
+
+				for target, expectedBody := range testCase.expectedBodies {
+					recorder := httptest.NewRecorder()
+					mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+					suite.Equal(http.StatusOK, recorder.Code, target)
+					suite.Equal(expectedBody, recorder.Body.String(), target)
+				}
+
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/a-b/c", nil))
+				suite.Equal(http.StatusNotFound, recorder.Code)
+			},
+		)
+	}
+}
+
+func (suite *ConstraintsTestSuite) TestItAnswersNotFoundWhenNoConstraintMatches() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("GET /users/{id:[0-9]+}", testHandler())
+	mux.Handle("GET /users/{code:[A-Z]+}", testHandler())
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/jane", nil))
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *ConstraintsTestSuite) TestItPanicsOnConflictingUnconstrainedRoutes() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("GET /users/{id}", testHandler())
+	mux.Handle("GET /users/{code:[A-Z]+}", testHandler())
+
+	suite.PanicsWithValue(
+		`router: pattern "GET /users/{slug}" conflicts with pattern "GET /users/{id}"`,
+		func() {
+			mux.Handle("GET /users/{slug}", testHandler())
+		},
+	)
+}
+
+func (suite *ConstraintsTestSuite) TestItPanicsOnInvalidConstraints() {
+	suite.Panics(
+		func() {
+			NewServerMuxWrapper(nil).Handle("GET /users/{id:[0-9}", testHandler())
+		},
+	)
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// registerPreflight records the handler answering preflight requests for a route
+// restricted to a method, which OPTIONS requests do not match. It runs the route's
+// middleware chain around a middleware.CORS applying the route policy.
+func (mux *ServerMuxWrapper) registerPreflight(
+	muxPattern string,
+	config *routeConfig,
+	overrides []NamedMiddleware,
+) {
+	method, _, _ := splitPattern(muxPattern)
+	if method == "" || method == http.MethodOptions {
+		return
+	}
+
+	preflight := middleware.NewCORS(http.HandlerFunc(mux.serveNotFound), *config.cors)
+	handler := config.wrap(WithNamedMiddlewares(preflight, mux.defaultNamedMiddlewares, overrides))
+
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	if mux.preflightHandlers == nil {
+		mux.preflightHandlers = make(map[string]http.Handler)
+	}
+	mux.preflightHandlers[muxPattern] = handler
+}
+
+// preflightHandler returns the handler of the CORS preflight request targeting a route
+// declared with WithCORS, if any
+func (mux *ServerMuxWrapper) preflightHandler(r *http.Request) http.Handler {
+	requestedMethod := r.Header.Get("Access-Control-Request-Method")
+	if r.Method != http.MethodOptions || requestedMethod == "" {
+		return nil
+	}
+
+	probe := new(http.Request)
+	*probe = *r
+	probe.Method = requestedMethod
+	_, pattern := mux.ServeMux.Handler(probe)
+
+	mux.routesMu.RLock()
+	defer mux.routesMu.RUnlock()
+
+	return mux.preflightHandlers[pattern]
+}
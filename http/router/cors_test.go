@@ -0,0 +1,151 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type CORSTestSuite struct {
+	suite.Suite
+}
+
+func TestCORSSuite(t *testing.T) {
+	suite.Run(t, new(CORSTestSuite))
+}
+
+func (suite *CORSTestSuite) TestItCanApplyRoutePolicies() {
+	mux := NewServerMuxWrapper(
+		NewChain(
+			testNamedMiddleware("default"),
+			NamedMiddleware{
+				Name: MiddlewareCORS,
+				Middleware: func(next http.Handler) http.Handler {
+					return middleware.NewCORS(
+						next, middleware.CORSOptions{AllowedOrigins: []string{"https://app.example.com"}},
+					)
+				},
+			},
+		),
+	)
+	mux.Handle("GET /internal", testHandler())
+	mux.Handle("GET /public", testHandler(), WithCORS(middleware.CORSOptions{AllowedOrigins: []string{"*"}}))
+	partners := mux.Group("/partner").WithOptions(
+		WithCORS(
+			middleware.CORSOptions{
+				AllowedOrigins: []string{"https://*.partners.example.com"},
+				AllowedMethods: []string{http.MethodPost},
+			},
+		),
+	)
+	partners.Handle("POST /orders", testHandler())
+
+	testCases := map[string]struct {
+		method          string
+		target          string
+		origin          string
+		expectedCode    int
+		expectedOrigin  string
+		expectedMethods string
+	}{
+		"default policy": {
+			method:         http.MethodGet,
+			target:         "/internal",
+			origin:         "https://app.example.com",
+			expectedCode:   http.StatusOK,
+			expectedOrigin: "https://app.example.com",
+		},
+		"default policy rejecting": {
+			method:       http.MethodGet,
+			target:       "/internal",
+			origin:       "https://acme.partners.example.com",
+			expectedCode: http.StatusOK,
+		},
+		"route policy": {
+			method:         http.MethodGet,
+			target:         "/public",
+			origin:         "https://other.org",
+			expectedCode:   http.StatusOK,
+			expectedOrigin: "*",
+		},
+		"group policy": {
+			method:         http.MethodPost,
+			target:         "/partner/orders",
+			origin:         "https://acme.partners.example.com",
+			expectedCode:   http.StatusOK,
+			expectedOrigin: "https://acme.partners.example.com",
+		},
+		"group policy rejecting": {
+			method:       http.MethodPost,
+			target:       "/partner/orders",
+			origin:       "https://app.example.com",
+			expectedCode: http.StatusOK,
+		},
+		"group policy preflight": {
+			method:          http.MethodOptions,
+			target:          "/partner/orders",
+			origin:          "https://acme.partners.example.com",
+			expectedCode:    http.StatusNoContent,
+			expectedOrigin:  "https://acme.partners.example.com",
+			expectedMethods: http.MethodPost,
+		},
+		"route policy preflight": {
+			method:          http.MethodOptions,
+			target:          "/public",
+			origin:          "https://other.org",
+			expectedCode:    http.StatusNoContent,
+			expectedOrigin:  "*",
+			expectedMethods: "GET, HEAD, POST, PUT, PATCH, DELETE",
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(tc.method, tc.target, nil)
+				request.Header.Set("Origin", tc.origin)
+				if tc.method == http.MethodOptions {
+					request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+					if tc.target == "/public" {
+						request.Header.Set("Access-Control-Request-Method", http.MethodGet)
+					}
+				}
+
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, request)
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				suite.Equal(tc.expectedOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+				suite.Equal(tc.expectedMethods, recorder.Header().Get("Access-Control-Allow-Methods"))
+			},
+		)
+	}
+}
+
+func (suite *CORSTestSuite) TestItCanAnswerPreflightsWithoutCORSMiddleware() {
+	mux := NewServerMuxWrapper(NewChain(testNamedMiddleware("default")))
+	mux.Handle(
+		"DELETE /items/{id}",
+		testHandler(),
+		WithCORS(middleware.CORSOptions{AllowedOrigins: []string{"https://app.example.com"}}),
+	)
+
+	request := httptest.NewRequest(http.MethodOptions, "/items/1", nil)
+	request.Header.Set("Origin", "https://app.example.com")
+	request.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusNoContent, recorder.Code)
+	suite.Equal("https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	suite.Equal("default", recorder.Header().Get("X-Middleware"))
+
+	request = httptest.NewRequest(http.MethodOptions, "/items/1", nil)
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusMethodNotAllowed, recorder.Code)
+}
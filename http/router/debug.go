@@ -0,0 +1,91 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// RoutesPath is the path the route table is served at by ServeRoutes
+const RoutesPath = "/_routes"
+
+// RoutesHandler returns a handler dumping the route table, with the middleware chain and
+// metadata of every route. It renders JSON when requested with ?format=json or an
+// Accept header listing application/json, an aligned text table otherwise.
+// The table exposes the application internals, do not serve it in production.
+func (mux *ServerMuxWrapper) RoutesHandler() http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			routes := mux.Routes()
+			if r.URL.Query().Get("format") == "json" ||
+				strings.Contains(r.Header.Get("Accept"), "application/json") {
+				_ = httpInternal.NewResponseBuilder(w).JSON().Data(routes).Send()
+				return
+			}
+
+			var table strings.Builder
+			_ = WriteRoutes(&table, routes)
+			_ = httpInternal.NewResponseBuilder(w).Text().ContentString(table.String()).Send()
+		},
+	)
+}
+
+// ServeRoutes registers the route table at GET /_routes
+func (mux *ServerMuxWrapper) ServeRoutes(options ...RouteOption) {
+	mux.Handle(http.MethodGet+" "+RoutesPath, mux.RoutesHandler(), options...)
+}
+
+// WriteRoutes renders the routes as an aligned text table. Middlewares are listed
+// innermost first; methods and hosts are "*" when not restricted.
+func WriteRoutes(w io.Writer, routes []Route) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "METHOD\tHOST\tPATH\tNAME\tHANDLER\tMIDDLEWARES\tMETADATA")
+
+	for _, route := range routes {
+		_, _ = fmt.Fprintf(
+			table,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			orDefault(route.Method, "*"),
+			orDefault(route.Host, "*"),
+			route.Path,
+			orDefault(route.Name, "-"),
+			route.Handler,
+			orDefault(strings.Join(route.Middlewares, ", "), "-"),
+			orDefault(describeMetadata(route.Metadata), "-"),
+		)
+	}
+
+	return table.Flush()
+}
+
+// describeMetadata summarizes the metadata fields relevant in a route listing
+func describeMetadata(metadata RouteMetadata) string {
+	var parts []string
+	if metadata.Summary != "" {
+		parts = append(parts, fmt.Sprintf("%q", metadata.Summary))
+	}
+	if len(metadata.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(metadata.Tags, ","))
+	}
+	if len(metadata.Scopes) > 0 {
+		parts = append(parts, "scopes="+strings.Join(metadata.Scopes, ","))
+	}
+	if metadata.Public {
+		parts = append(parts, "public")
+	}
+	if metadata.Deprecated {
+		parts = append(parts, "deprecated")
+	}
+	return strings.Join(parts, " ")
+}
+
+func orDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
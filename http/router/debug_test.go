@@ -0,0 +1,94 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugTestSuite struct {
+	suite.Suite
+}
+
+func TestDebugSuite(t *testing.T) {
+	suite.Run(t, new(DebugTestSuite))
+}
+
+func debugUsersHandler(w http.ResponseWriter, r *http.Request) {}
+
+func (suite *DebugTestSuite) newMux() *ServerMuxWrapper {
+	mux := NewServerMuxWrapper(NewChain(testNamedMiddleware("recoverer")))
+	mux.Handle(
+		"GET /users/{id}",
+		http.HandlerFunc(debugUsersHandler),
+		WithName("user"),
+		WithMetadata(RouteMetadata{Summary: "Get user", Tags: []string{"users"}, Deprecated: true}),
+	)
+	mux.ServeRoutes()
+	return mux
+}
+
+func (suite *DebugTestSuite) TestItCanRenderTheRouteTableAsText() {
+	recorder := httptest.NewRecorder()
+	suite.newMux().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, RoutesPath, nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	suite.Require().Len(lines, 3)
+	suite.Equal(
+		[]string{"METHOD", "HOST", "PATH", "NAME", "HANDLER", "MIDDLEWARES", "METADATA"},
+		strings.Fields(lines[0]),
+	)
+	suite.Equal(
+		[]string{
+			"GET", "*", "/users/{id}", "user",
+			"github.com/golibry/go-http/http/router.debugUsersHandler",
+			"recoverer", `"Get`, `user"`, "tags=users", "deprecated",
+		},
+		strings.Fields(lines[1]),
+	)
+	suite.Equal(
+		[]string{"GET", "*", "/_routes", "-"},
+		strings.Fields(lines[2])[:4],
+	)
+}
+
+func (suite *DebugTestSuite) TestItCanRenderTheRouteTableAsJSON() {
+	testCases := map[string]*http.Request{
+		"query":  httptest.NewRequest(http.MethodGet, RoutesPath+"?format=json", nil),
+		"accept": httptest.NewRequest(http.MethodGet, RoutesPath, nil),
+	}
+	testCases["accept"].Header.Set("Accept", "application/json")
+
+	for name, request := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				suite.newMux().ServeHTTP(recorder, request)
+
+				suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+
+				var routes []map[string]interface{}
+				suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &routes))
+				suite.Require().Len(routes, 2)
+				suite.Equal("GET /users/{id}", routes[0]["pattern"])
+				suite.Equal("user", routes[0]["name"])
+				suite.Equal([]interface{}{"recoverer"}, routes[0]["middlewares"])
+				suite.Equal(
+					map[string]interface{}{
+						"summary":    "Get user",
+						"tags":       []interface{}{"users"},
+						"deprecated": true,
+					},
+					routes[0]["metadata"],
+				)
+			},
+		)
+	}
+}
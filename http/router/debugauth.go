@@ -0,0 +1,94 @@
+package router
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// MiddlewareDebugAuth is the name of the middlewares returned by Authorize and AllowNetworks
+const MiddlewareDebugAuth = "debugauth"
+
+// Authorize returns a middleware serving the requests passing the gate, e.g.
+// debug.BasicAuth, and answering 401 Unauthorized otherwise. Together with
+// AllowNetworks, it protects debug endpoints such as the pprof package handlers.
+func Authorize(gate func(r *http.Request) bool) NamedMiddleware {
+	return NamedMiddleware{
+		Name: MiddlewareDebugAuth,
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if !gate(r) {
+						w.Header().Set("WWW-Authenticate", `Basic realm="debug"`)
+						_ = httpInternal.NewResponseBuilder(w).
+							Status(http.StatusUnauthorized).
+							Error().
+							WithMessage(http.StatusText(http.StatusUnauthorized)).
+							DisableLogging().
+							Send()
+						return
+					}
+					next.ServeHTTP(w, r)
+				},
+			)
+		},
+	}
+}
+
+// AllowNetworks returns a middleware serving the requests of clients within the
+// networks, given in CIDR notation (e.g. "10.0.0.0/8", "127.0.0.1/32"), and answering
+// 403 Forbidden otherwise. The client is the connection peer (RemoteAddr), put the
+// middleware behind a trusted proxy resolver when the service runs behind proxies.
+// It panics on invalid networks, as they are configuration errors.
+func AllowNetworks(networks ...string) NamedMiddleware {
+	prefixes := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			panic(fmt.Sprintf("router: invalid network %q: %v", network, err))
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return NamedMiddleware{
+		Name: MiddlewareDebugAuth,
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if !allowed(prefixes, r.RemoteAddr) {
+						_ = httpInternal.NewResponseBuilder(w).
+							Status(http.StatusForbidden).
+							Error().
+							WithMessage(http.StatusText(http.StatusForbidden)).
+							DisableLogging().
+							Send()
+						return
+					}
+					next.ServeHTTP(w, r)
+				},
+			)
+		},
+	}
+}
+
+// allowed tells whether the remote address is within one of the networks
+func allowed(prefixes []netip.Prefix, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugAuthSuite struct {
+	suite.Suite
+}
+
+func TestDebugAuthSuite(t *testing.T) {
+	suite.Run(t, new(DebugAuthSuite))
+}
+
+func (suite *DebugAuthSuite) TestItCanAllowNetworks() {
+	middleware := AllowNetworks("10.0.0.0/8", "::1/128").Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	)
+
+	testCases := map[string]struct {
+		remoteAddr   string
+		expectedCode int
+	}{
+		"allowed IPv4":    {remoteAddr: "10.1.2.3:5000", expectedCode: http.StatusOK},
+		"allowed IPv6":    {remoteAddr: "[::1]:5000", expectedCode: http.StatusOK},
+		"mapped IPv4":     {remoteAddr: "[::ffff:10.1.2.3]:5000", expectedCode: http.StatusOK},
+		"outside network": {remoteAddr: "192.168.1.1:5000", expectedCode: http.StatusForbidden},
+		"malformed":       {remoteAddr: "unknown", expectedCode: http.StatusForbidden},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+				request.RemoteAddr = testCase.remoteAddr
+				recorder := httptest.NewRecorder()
+
+				middleware.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+			},
+		)
+	}
+}
+
+func (suite *DebugAuthSuite) TestItRejectsInvalidNetworks() {
+	suite.Panics(func() { AllowNetworks("10.0.0.0") })
+}
+
+func (suite *DebugAuthSuite) TestItLeavesTheDefaultServeMuxAlone() {
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/vars"} {
+		_, pattern := http.DefaultServeMux.Handler(httptest.NewRequest(http.MethodGet, path, nil))
+
+		suite.Empty(pattern, path)
+	}
+}
@@ -0,0 +1,93 @@
+package router
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// errorhandlerConfig holds the middleware.Errorhandler settings of HandleE routes
+type errorhandlerConfig struct {
+	ctx     context.Context
+	logger  *slog.Logger
+	options middleware.ErrorhandlerOptions
+}
+
+// SetErrorhandler configures the middleware.Errorhandler rendering the errors of the
+// handlers registered with HandleE. It applies to all of them, including the ones
+// registered before the call. Route error categories (WithErrorCategories) are checked
+// before options.ErrorCategories.
+func (mux *ServerMuxWrapper) SetErrorhandler(
+	ctx context.Context,
+	logger *slog.Logger,
+	options middleware.ErrorhandlerOptions,
+) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.errorhandler = &errorhandlerConfig{ctx: ctx, logger: logger, options: options}
+}
+
+// HandleE registers an error-returning handler, wrapped in a middleware.Errorhandler
+// configured with SetErrorhandler, and the default middlewares
+func (mux *ServerMuxWrapper) HandleE(
+	pattern string,
+	handler func(w http.ResponseWriter, r *http.Request) error,
+	options ...RouteOption,
+) {
+	mux.handle(pattern, mux.errorHandling(handler), nil, withIdentity(handler, options))
+}
+
+// HandleEWithCustomMiddlewares registers an error-returning handler like HandleE, with
+// selective override of the default middlewares
+func (mux *ServerMuxWrapper) HandleEWithCustomMiddlewares(
+	pattern string,
+	handler func(w http.ResponseWriter, r *http.Request) error,
+	overrides []NamedMiddleware,
+	options ...RouteOption,
+) {
+	mux.handle(pattern, mux.errorHandling(handler), overrides, withIdentity(handler, options))
+}
+
+// HandleE registers an error-returning handler under the group prefix, see
+// ServerMuxWrapper.HandleE
+func (group *RouteGroup) HandleE(
+	pattern string,
+	handler func(w http.ResponseWriter, r *http.Request) error,
+	options ...RouteOption,
+) {
+	group.HandleWithCustomMiddlewares(
+		pattern, group.mux.errorHandling(handler), nil, withIdentity(handler, options)...,
+	)
+}
+
+// errorHandling adapts the handler, resolving the errorhandler settings per request
+func (mux *ServerMuxWrapper) errorHandling(
+	handler func(w http.ResponseWriter, r *http.Request) error,
+) http.Handler {
+	customHandler := middleware.CustomHandlerFunc(handler)
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mux.routesMu.RLock()
+			config := mux.errorhandler
+			mux.routesMu.RUnlock()
+
+			if config == nil {
+				config = &errorhandlerConfig{ctx: context.Background()}
+			}
+			middleware.NewErrorhandler(customHandler, config.ctx, config.logger, config.options).
+				ServeHTTP(w, r)
+		},
+	)
+}
+
+// withIdentity appends an option reporting the given handler in Routes, rather than the
+// adapter actually registered
+func withIdentity(handler interface{}, options []RouteOption) []RouteOption {
+	return append(
+		append([]RouteOption(nil), options...),
+		func(config *routeConfig) { config.identity = handler },
+	)
+}
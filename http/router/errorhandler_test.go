@@ -0,0 +1,90 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorhandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorhandlerSuite(t *testing.T) {
+	suite.Run(t, new(ErrorhandlerTestSuite))
+}
+
+var errTestNotFound = errors.New("item not found")
+
+func findItem(w http.ResponseWriter, r *http.Request) error {
+	if r.PathValue("id") != "1" {
+		return errTestNotFound
+	}
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (suite *ErrorhandlerTestSuite) TestItCanRegisterErrorReturningHandlers() {
+	notFoundCategory := httperr.NewErrorCategory(http.StatusNotFound)
+	notFoundCategory.AddSentinelError(errTestNotFound)
+	goneCategory := httperr.NewErrorCategory(http.StatusGone)
+	goneCategory.AddSentinelError(errTestNotFound)
+
+	mux := NewServerMuxWrapper(NewChain(testNamedMiddleware("default")))
+	mux.HandleE("GET /items/{id}", findItem)
+	mux.HandleE("GET /archive/{id}", findItem, WithErrorCategories(goneCategory))
+	mux.HandleEWithCustomMiddlewares("GET /raw/{id}", findItem, []NamedMiddleware{Disable("default")})
+	mux.Group("/v2").HandleE("GET /items/{id}", findItem)
+	mux.SetErrorhandler(
+		context.Background(),
+		nil,
+		middleware.ErrorhandlerOptions{
+			Format:          middleware.ErrorFormatJSON,
+			ErrorCategories: []*httperr.ErrorCategory{notFoundCategory},
+		},
+	)
+
+	testCases := map[string]struct {
+		expectedCode       int
+		expectedMiddleware string
+	}{
+		"/items/1":    {expectedCode: http.StatusOK, expectedMiddleware: "default"},
+		"/items/2":    {expectedCode: http.StatusNotFound, expectedMiddleware: "default"},
+		"/archive/2":  {expectedCode: http.StatusGone, expectedMiddleware: "default"},
+		"/raw/2":      {expectedCode: http.StatusNotFound},
+		"/v2/items/2": {expectedCode: http.StatusNotFound, expectedMiddleware: "default"},
+	}
+
+	for target, tc := range testCases {
+		suite.Run(
+			target, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				suite.Equal(tc.expectedMiddleware, recorder.Header().Get("X-Middleware"))
+				if tc.expectedCode != http.StatusOK {
+					suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+				}
+			},
+		)
+	}
+
+	suite.Equal("github.com/golibry/go-http/http/router.findItem", mux.Routes()[0].Handler)
+}
+
+func (suite *ErrorhandlerTestSuite) TestItCanRenderErrorsWithoutConfiguration() {
+	mux := NewServerMuxWrapper(nil)
+	mux.HandleE("/", func(w http.ResponseWriter, r *http.Request) error { return errTestNotFound })
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+}
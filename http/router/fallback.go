@@ -0,0 +1,157 @@
+package router
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// probedMethods are checked against the registered patterns, together with any custom
+// method used in a pattern, to tell an unknown path from an unsupported method
+var probedMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// NotFound sets the handler for requests matching no route. It is wrapped by the
+// default middlewares, unlike the bare ServeMux plain-text 404.
+func (mux *ServerMuxWrapper) NotFound(handler http.Handler) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.notFoundHandler = WithNamedMiddlewares(handler, mux.defaultNamedMiddlewares, nil)
+}
+
+// MethodNotAllowed sets the handler for requests whose path has routes, but none for
+// the request method. The Allow header listing the supported methods is set before the
+// handler, wrapped by the default middlewares, runs.
+func (mux *ServerMuxWrapper) MethodNotAllowed(handler http.Handler) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.methodNotAllowedHandler = WithNamedMiddlewares(handler, mux.defaultNamedMiddlewares, nil)
+}
+
+// ServeHTTP dispatches the request to the matching route or to the configured fallback
+func (mux *ServerMuxWrapper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mux.routesMu.RLock()
+	notFoundHandler := mux.notFoundHandler
+	methodNotAllowedHandler := mux.methodNotAllowedHandler
+	metricsSink := mux.metricsSink
+	mux.routesMu.RUnlock()
+
+	_, pattern := mux.ServeMux.Handler(r)
+
+	if metricsSink != nil {
+		metricsWriter := newMetricsResponseWriter(w)
+		start := time.Now()
+		defer func() { metricsWriter.observe(metricsSink, r, pattern, time.Since(start)) }()
+		w = metricsWriter
+	}
+
+	if pattern != "" {
+		if method, _, _ := splitPattern(pattern); r.Method == http.MethodHead && method != http.MethodHead {
+			headWriter := newHeadResponseWriter(w)
+			defer headWriter.finish()
+			w = headWriter
+		}
+	}
+
+	if pattern == "" {
+		if preflightHandler := mux.preflightHandler(r); preflightHandler != nil {
+			preflightHandler.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	if pattern != "" || (notFoundHandler == nil && methodNotAllowedHandler == nil) {
+		mux.ServeMux.ServeHTTP(w, r)
+		return
+	}
+
+	allowed := mux.allowedMethods(r)
+	switch {
+	case len(allowed) > 0 && methodNotAllowedHandler != nil:
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		methodNotAllowedHandler.ServeHTTP(w, r)
+	case len(allowed) == 0 && notFoundHandler != nil:
+		notFoundHandler.ServeHTTP(w, r)
+	default:
+		mux.ServeMux.ServeHTTP(w, r)
+	}
+}
+
+// serveNotFound answers like an unknown path, with the NotFound handler when set
+func (mux *ServerMuxWrapper) serveNotFound(w http.ResponseWriter, r *http.Request) {
+	mux.routesMu.RLock()
+	notFoundHandler := mux.notFoundHandler
+	mux.routesMu.RUnlock()
+
+	if notFoundHandler != nil {
+		notFoundHandler.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// registerMethod records the method of a pattern so custom methods are probed too
+func (mux *ServerMuxWrapper) registerMethod(pattern string) {
+	method, _, _ := splitPattern(pattern)
+	if method == "" {
+		return
+	}
+
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	if mux.methods == nil {
+		mux.methods = make(map[string]bool)
+	}
+	mux.methods[method] = true
+}
+
+// allowedMethods returns the sorted methods having a route for the request path
+func (mux *ServerMuxWrapper) allowedMethods(r *http.Request) []string {
+	methods := append([]string(nil), probedMethods...)
+
+	mux.routesMu.RLock()
+	for method := range mux.methods {
+		if !containsString(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	mux.routesMu.RUnlock()
+
+	var allowed []string
+	probe := new(http.Request)
+	for _, method := range methods {
+		if method == r.Method {
+			continue
+		}
+		*probe = *r
+		probe.Method = method
+		if _, pattern := mux.ServeMux.Handler(probe); pattern != "" {
+			allowed = append(allowed, method)
+		}
+	}
+
+	sort.Strings(allowed)
+	return allowed
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
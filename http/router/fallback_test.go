@@ -0,0 +1,162 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FallbackTestSuite struct {
+	suite.Suite
+}
+
+func TestFallbackSuite(t *testing.T) {
+	suite.Run(t, new(FallbackTestSuite))
+}
+
+func (suite *FallbackTestSuite) TestItCanUseCustomNotFoundHandler() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{{Name: "logging", Middleware: createTestMiddleware("logging")}},
+	)
+	mux.Handle("GET /users/{id}", testHandler())
+	mux.Handle("/docs/", testHandler())
+	mux.NotFound(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"not found"}`))
+			},
+		),
+	)
+
+	testCases := map[string]struct {
+		method              string
+		target              string
+		expectedStatus      int
+		expectedBody        string
+		expectedLocation    string
+		expectedMiddlewares []string
+	}{
+		"unknown path": {
+			method:              http.MethodGet,
+			target:              "/unknown",
+			expectedStatus:      http.StatusNotFound,
+			expectedBody:        `{"error":"not found"}`,
+			expectedMiddlewares: []string{"logging"},
+		},
+		"matched route": {
+			method:              http.MethodGet,
+			target:              "/users/42",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "OK",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"unsupported method keeps 405": {
+			method:         http.MethodDelete,
+			target:         "/users/42",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		"trailing slash redirect": {
+			method:           http.MethodGet,
+			target:           "/docs",
+			expectedLocation: "/docs/",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(testCase.method, testCase.target, nil))
+
+				if testCase.expectedStatus != 0 {
+					suite.Equal(testCase.expectedStatus, recorder.Code)
+				}
+				suite.Equal(testCase.expectedLocation, recorder.Header().Get("Location"))
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				}
+				suite.Equal(testCase.expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+}
+
+func (suite *FallbackTestSuite) TestItKeepsDefaultNotFoundWithoutHandler() {
+	mux := NewServerMuxWrapper(nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal("404 page not found\n", recorder.Body.String())
+}
+
+func (suite *FallbackTestSuite) TestItCanUseCustomMethodNotAllowedHandler() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{{Name: "logging", Middleware: createTestMiddleware("logging")}},
+	)
+	mux.Handle("GET /users/{id}", testHandler())
+	mux.Handle("DELETE /users/{id}", testHandler())
+	mux.Handle("PURGE /cache", testHandler())
+	mux.MethodNotAllowed(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				_, _ = w.Write([]byte("allowed: " + w.Header().Get("Allow")))
+			},
+		),
+	)
+
+	testCases := map[string]struct {
+		method              string
+		target              string
+		expectedStatus      int
+		expectedAllow       string
+		expectedMiddlewares []string
+	}{
+		"unsupported method": {
+			method:              http.MethodPost,
+			target:              "/users/42",
+			expectedStatus:      http.StatusMethodNotAllowed,
+			expectedAllow:       "DELETE, GET, HEAD",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"custom method route": {
+			method:              http.MethodGet,
+			target:              "/cache",
+			expectedStatus:      http.StatusMethodNotAllowed,
+			expectedAllow:       "PURGE",
+			expectedMiddlewares: []string{"logging"},
+		},
+		"supported method": {
+			method:              http.MethodDelete,
+			target:              "/users/42",
+			expectedStatus:      http.StatusOK,
+			expectedMiddlewares: []string{"logging"},
+		},
+		"unknown path keeps 404": {
+			method:         http.MethodPost,
+			target:         "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(testCase.method, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				suite.Equal(testCase.expectedAllow, recorder.Header().Get("Allow"))
+				if testCase.expectedAllow != "" {
+					suite.Equal("allowed: "+testCase.expectedAllow, recorder.Body.String())
+				}
+				suite.Equal(testCase.expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+}
@@ -0,0 +1,154 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RouteGroup registers routes under a common path prefix with a shared middleware list
+// and route options. Group middlewares behave like per-route overrides: an entry named
+// like a default middleware replaces it in place, other entries wrap the default chain.
+type RouteGroup struct {
+	mux         *ServerMuxWrapper
+	prefix      string
+	middlewares []NamedMiddleware
+	options     []RouteOption
+}
+
+// Group creates a route group whose routes inherit the prefix and the group middlewares
+// in addition to the mux defaults
+func (mux *ServerMuxWrapper) Group(prefix string, middlewares ...NamedMiddleware) *RouteGroup {
+	return &RouteGroup{
+		mux:         mux,
+		prefix:      cleanPrefix(prefix),
+		middlewares: middlewares,
+	}
+}
+
+// Group creates a nested group. Its prefix is appended to the parent prefix and its
+// middlewares are merged with the parent ones, same-named entries taking precedence.
+func (group *RouteGroup) Group(prefix string, middlewares ...NamedMiddleware) *RouteGroup {
+	return &RouteGroup{
+		mux:         group.mux,
+		prefix:      group.prefix + cleanPrefix(prefix),
+		middlewares: mergeNamedMiddlewares(group.middlewares, middlewares),
+		options:     group.options,
+	}
+}
+
+// WithOptions returns a copy of the group applying the route options to all its routes,
+// including the ones of nested groups, before the options given at registration, e.g.
+// error categories for a whole area of the application:
+//
+//	payments := mux.Group("/payments").WithOptions(router.WithErrorCategories(paymentErrors...))
+//
+// Options naming routes (WithName) must not be used here since names are unique.
+func (group *RouteGroup) WithOptions(options ...RouteOption) *RouteGroup {
+	return &RouteGroup{
+		mux:         group.mux,
+		prefix:      group.prefix,
+		middlewares: group.middlewares,
+		options:     group.routeOptions(options),
+	}
+}
+
+// Handle registers the handler under the group prefix, wrapped by the defaults and the
+// group middlewares
+func (group *RouteGroup) Handle(pattern string, handler http.Handler, options ...RouteOption) {
+	group.HandleWithCustomMiddlewares(pattern, handler, nil, options...)
+}
+
+// HandleWithCustomMiddlewares registers the handler under the group prefix with
+// additional per-route overrides, merged with the group middlewares
+func (group *RouteGroup) HandleWithCustomMiddlewares(
+	pattern string,
+	handler http.Handler,
+	overrides []NamedMiddleware,
+	options ...RouteOption,
+) {
+	group.mux.handle(
+		prefixPattern(group.prefix, pattern),
+		handler,
+		mergeNamedMiddlewares(group.middlewares, overrides),
+		group.routeOptions(options),
+	)
+}
+
+// routeOptions returns the group options followed by the given ones
+func (group *RouteGroup) routeOptions(options []RouteOption) []RouteOption {
+	merged := make([]RouteOption, 0, len(group.options)+len(options))
+	merged = append(merged, group.options...)
+	return append(merged, options...)
+}
+
+// mergeNamedMiddlewares replaces base entries by same-named overrides and places the
+// remaining overrides first, so the more specific middlewares run closer to the handler
+func mergeNamedMiddlewares(base []NamedMiddleware, overrides []NamedMiddleware) []NamedMiddleware {
+	if len(overrides) == 0 {
+		return base
+	}
+
+	overrideMap := make(map[string]NamedMiddleware, len(overrides))
+	for _, override := range overrides {
+		overrideMap[override.Name] = override
+	}
+
+	baseNames := make(map[string]bool, len(base))
+	for _, namedMw := range base {
+		baseNames[namedMw.Name] = true
+	}
+
+	merged := make([]NamedMiddleware, 0, len(base)+len(overrides))
+	for _, override := range overrides {
+		if !baseNames[override.Name] {
+			merged = append(merged, override)
+		}
+	}
+	for _, namedMw := range base {
+		if override, exists := overrideMap[namedMw.Name]; exists {
+			namedMw = override
+		}
+		merged = append(merged, namedMw)
+	}
+
+	return merged
+}
+
+// cleanPrefix normalizes a group prefix to a leading slash and no trailing slash
+func cleanPrefix(prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return prefix
+}
+
+// prefixPattern inserts the prefix in front of the path of a ServeMux pattern, keeping
+// the optional method and host parts ("GET example.com/users")
+func prefixPattern(prefix string, pattern string) string {
+	if prefix == "" {
+		return pattern
+	}
+
+	method, host, path := splitPattern(pattern)
+	if method != "" {
+		method += " "
+	}
+	return method + host + prefix + path
+}
+
+// splitPattern splits a ServeMux pattern into its method, host and path parts
+func splitPattern(pattern string) (method string, host string, path string) {
+	path = pattern
+	if index := strings.IndexAny(pattern, " \t"); index >= 0 {
+		method = pattern[:index]
+		path = strings.TrimLeft(pattern[index+1:], " \t")
+	}
+
+	if index := strings.Index(path, "/"); index > 0 {
+		host = path[:index]
+		path = path[index:]
+	}
+
+	return method, host, path
+}
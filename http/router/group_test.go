@@ -0,0 +1,163 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupTestSuite struct {
+	suite.Suite
+}
+
+func TestGroupSuite(t *testing.T) {
+	suite.Run(t, new(GroupTestSuite))
+}
+
+func (suite *GroupTestSuite) serve(mux *ServerMuxWrapper, method, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	return recorder
+}
+
+func (suite *GroupTestSuite) TestItCanPrefixGroupRoutes() {
+	mux := NewServerMuxWrapper(nil)
+	api := mux.Group("/api/v1/")
+	api.Handle("GET /users/{id}", testHandler())
+	api.Handle("/health", testHandler())
+	api.Group("admin").Handle("POST /reports", testHandler())
+
+	suite.Equal(http.StatusOK, suite.serve(mux, http.MethodGet, "/api/v1/users/42").Code)
+	suite.Equal(http.StatusOK, suite.serve(mux, http.MethodGet, "/api/v1/health").Code)
+	suite.Equal(http.StatusOK, suite.serve(mux, http.MethodPost, "/api/v1/admin/reports").Code)
+	suite.Equal(http.StatusNotFound, suite.serve(mux, http.MethodGet, "/users/42").Code)
+}
+
+func (suite *GroupTestSuite) TestItCanApplyGroupMiddlewares() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{
+			{Name: "auth", Middleware: createTestMiddleware("auth")},
+			{Name: "logging", Middleware: createTestMiddleware("logging")},
+		},
+	)
+	api := mux.Group(
+		"/api",
+		NamedMiddleware{Name: "auth", Middleware: createTestMiddleware("api-auth")},
+		NamedMiddleware{Name: "cors", Middleware: createTestMiddleware("cors")},
+	)
+	api.Handle("/users", testHandler())
+	api.HandleWithCustomMiddlewares(
+		"/public",
+		testHandler(),
+		[]NamedMiddleware{
+			{Name: "auth", Middleware: createTestMiddleware("no-auth")},
+			{Name: "cache", Middleware: createTestMiddleware("cache")},
+		},
+	)
+	api.Group("/admin", NamedMiddleware{Name: "audit", Middleware: createTestMiddleware("audit")}).
+		Handle("/stats", testHandler())
+	mux.Handle("/", testHandler())
+
+	testCases := map[string]struct {
+		target              string
+		expectedMiddlewares []string
+	}{
+		"group route": {
+			target:              "/api/users",
+			expectedMiddlewares: []string{"cors", "logging", "api-auth"},
+		},
+		"route overrides on top of group": {
+			target:              "/api/public",
+			expectedMiddlewares: []string{"cors", "cache", "logging", "no-auth"},
+		},
+		"nested group": {
+			target:              "/api/admin/stats",
+			expectedMiddlewares: []string{"cors", "audit", "logging", "api-auth"},
+		},
+		"route outside group": {
+			target:              "/other",
+			expectedMiddlewares: []string{"logging", "auth"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := suite.serve(mux, http.MethodGet, testCase.target)
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(testCase.expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+}
+
+func (suite *GroupTestSuite) TestItCanPrefixPatterns() {
+	testCases := map[string]struct {
+		prefix   string
+		pattern  string
+		expected string
+	}{
+		"path":          {prefix: "/api", pattern: "/users", expected: "/api/users"},
+		"method":        {prefix: "/api", pattern: "GET /users", expected: "GET /api/users"},
+		"host":          {prefix: "/api", pattern: "example.com/users", expected: "example.com/api/users"},
+		"method host":   {prefix: "/api", pattern: "GET example.com/", expected: "GET example.com/api/"},
+		"empty prefix":  {prefix: "", pattern: "GET /users", expected: "GET /users"},
+		"extra spacing": {prefix: "/api", pattern: "GET  /users", expected: "GET /api/users"},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(testCase.expected, prefixPattern(testCase.prefix, testCase.pattern))
+			},
+		)
+	}
+}
+
+func (suite *GroupTestSuite) TestItCanApplyGroupErrorCategories() {
+	errDeclined := errors.New("declined")
+	paymentCategory := httperr.NewErrorCategory(http.StatusPaymentRequired)
+	paymentCategory.AddSentinelError(errDeclined)
+	conflictCategory := httperr.NewErrorCategory(http.StatusConflict)
+	conflictCategory.AddSentinelError(errDeclined)
+
+	declining := middleware.NewErrorhandler(
+		middleware.CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error { return errDeclined },
+		),
+		context.Background(),
+		nil,
+		middleware.ErrorhandlerOptions{},
+	)
+
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("/orders", declining)
+	payments := mux.Group("/payments").WithOptions(WithErrorCategories(paymentCategory))
+	payments.Handle("/charge", declining)
+	payments.Handle("/refund", declining, WithErrorCategories(conflictCategory))
+	payments.Group("/cards").Handle("/verify", declining)
+	payments.Mount("/legacy", declining)
+
+	testCases := map[string]int{
+		"/orders":                http.StatusInternalServerError,
+		"/payments/charge":       http.StatusPaymentRequired,
+		"/payments/refund":       http.StatusConflict,
+		"/payments/cards/verify": http.StatusPaymentRequired,
+		"/payments/legacy/x":     http.StatusPaymentRequired,
+	}
+
+	for target, expectedCode := range testCases {
+		suite.Run(
+			target, func() {
+				suite.Equal(expectedCode, suite.serve(mux, http.MethodGet, target).Code)
+			},
+		)
+	}
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// headResponseWriter runs GET handlers for HEAD requests. The body is discarded but
+// counted, and the header is held back until the handler returns, so Content-Length
+// and a sniffed Content-Type match what the GET response would carry.
+type headResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	statusSet   bool
+	written     int64
+	wroteHeader bool
+}
+
+func newHeadResponseWriter(w http.ResponseWriter) *headResponseWriter {
+	return &headResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+// WriteHeader records the status code; it is sent when the handler completes
+func (hw *headResponseWriter) WriteHeader(statusCode int) {
+	if hw.statusSet {
+		return
+	}
+	hw.statusSet = true
+	hw.statusCode = statusCode
+}
+
+// Write discards the body, only counting its length
+func (hw *headResponseWriter) Write(b []byte) (int, error) {
+	if hw.written == 0 && len(b) > 0 && hw.Header().Get("Content-Type") == "" {
+		hw.Header().Set("Content-Type", http.DetectContentType(b))
+	}
+	hw.statusSet = true
+	hw.written += int64(len(b))
+	return len(b), nil
+}
+
+// Flush sends the header right away, without Content-Length as the body is not complete
+func (hw *headResponseWriter) Flush() {
+	hw.sendHeader(false)
+	httpInternal.Flush(hw.ResponseWriter)
+}
+
+// Hijack passes connection hijacking through
+func (hw *headResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return httpInternal.Hijack(hw.ResponseWriter)
+}
+
+// Push passes HTTP/2 server pushes through
+func (hw *headResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return httpInternal.Push(hw.ResponseWriter, target, opts)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (hw *headResponseWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
+
+// finish sends the header once the handler returned
+func (hw *headResponseWriter) finish() {
+	hw.sendHeader(true)
+}
+
+func (hw *headResponseWriter) sendHeader(complete bool) {
+	if hw.wroteHeader {
+		return
+	}
+	hw.wroteHeader = true
+
+	header := hw.Header()
+	if complete && header.Get("Content-Length") == "" && header.Get("Transfer-Encoding") == "" &&
+		hw.written > 0 && bodyAllowedForStatus(hw.statusCode) {
+		header.Set("Content-Length", strconv.FormatInt(hw.written, 10))
+	}
+	hw.ResponseWriter.WriteHeader(hw.statusCode)
+}
+
+// bodyAllowedForStatus reports whether a response with the status may carry a body
+func bodyAllowedForStatus(statusCode int) bool {
+	switch {
+	case statusCode >= 100 && statusCode <= 199:
+		return false
+	case statusCode == http.StatusNoContent, statusCode == http.StatusNotModified:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HeadTestSuite struct {
+	suite.Suite
+}
+
+func TestHeadSuite(t *testing.T) {
+	suite.Run(t, new(HeadTestSuite))
+}
+
+func (suite *HeadTestSuite) TestItServesHeadForGetRoutes() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /page",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				_, _ = w.Write([]byte("<html><body>"))
+				_, _ = w.Write([]byte("hello</body></html>"))
+			},
+		),
+	)
+	mux.Handle(
+		"GET /empty",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	)
+	mux.Handle(
+		"HEAD /custom",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Custom", "yes")
+				w.WriteHeader(http.StatusAccepted)
+			},
+		),
+	)
+
+	testCases := map[string]struct {
+		target                string
+		expectedStatus        int
+		expectedContentLength string
+		expectedContentType   string
+		expectedHeaders       map[string]string
+	}{
+		"get route": {
+			target:                "/page",
+			expectedStatus:        http.StatusOK,
+			expectedContentLength: "31",
+			expectedContentType:   "text/html; charset=utf-8",
+			expectedHeaders:       map[string]string{"ETag": `"v1"`},
+		},
+		"no content": {
+			target:         "/empty",
+			expectedStatus: http.StatusNoContent,
+		},
+		"explicit head route": {
+			target:          "/custom",
+			expectedStatus:  http.StatusAccepted,
+			expectedHeaders: map[string]string{"X-Custom": "yes"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodHead, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				suite.Empty(recorder.Body.String())
+				suite.Equal(testCase.expectedContentLength, recorder.Header().Get("Content-Length"))
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+				for key, value := range testCase.expectedHeaders {
+					suite.Equal(value, recorder.Header().Get(key))
+				}
+			},
+		)
+	}
+}
+
+func (suite *HeadTestSuite) TestItSendsHeaderOnFlush() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /stream",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("data: 1\n\n"))
+				w.(http.Flusher).Flush()
+				_, _ = w.Write([]byte("data: 2\n\n"))
+			},
+		),
+	)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodHead, "/stream", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.True(recorder.Flushed)
+	suite.Empty(recorder.Header().Get("Content-Length"))
+	suite.Empty(recorder.Body.String())
+}
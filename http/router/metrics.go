@@ -0,0 +1,155 @@
+package router
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/metrics"
+)
+
+// RouteObservation is the measurement of a single request
+//
+// Method: request method
+// Pattern: ServeMux pattern of the matched route, empty for unmatched requests. Label
+// metrics with it rather than with the raw path to keep cardinality bounded.
+// StatusCode: response status code
+// Duration: time spent serving the request, middlewares included
+// BytesWritten: response body size
+type RouteObservation struct {
+	Method       string
+	Pattern      string
+	StatusCode   int
+	Duration     time.Duration
+	BytesWritten int64
+}
+
+// MetricsSink receives the per-route observations, e.g. to feed latency histograms and
+// status counters. It is called synchronously once the request is served, so
+// implementations must be fast and safe for concurrent use.
+type MetricsSink interface {
+	ObserveRequest(r *http.Request, observation RouteObservation)
+}
+
+// MetricsSinkFunc adapts a function to MetricsSink
+type MetricsSinkFunc func(r *http.Request, observation RouteObservation)
+
+// ObserveRequest implements MetricsSink
+func (f MetricsSinkFunc) ObserveRequest(r *http.Request, observation RouteObservation) {
+	f(r, observation)
+}
+
+// RecorderSink adapts a metrics.Recorder to MetricsSink, recording the
+// metrics.RequestsTotal, metrics.RequestDuration and metrics.ResponseSize metrics
+// labeled by method, route pattern and, for the request count, status code
+func RecorderSink(recorder metrics.Recorder) MetricsSink {
+	return MetricsSinkFunc(
+		func(r *http.Request, observation RouteObservation) {
+			recorder.AddCounter(
+				metrics.RequestsTotal, 1, metrics.Labels{
+					"method": observation.Method,
+					"route":  observation.Pattern,
+					"status": strconv.Itoa(observation.StatusCode),
+				},
+			)
+			labels := metrics.Labels{"method": observation.Method, "route": observation.Pattern}
+			recorder.ObserveHistogram(metrics.RequestDuration, observation.Duration.Seconds(), labels)
+			recorder.ObserveHistogram(metrics.ResponseSize, float64(observation.BytesWritten), labels)
+		},
+	)
+}
+
+// SetMetricsSink records every request served by the mux, labeled by the matched route
+// pattern, into the sink. A nil sink disables the recording.
+func (mux *ServerMuxWrapper) SetMetricsSink(sink MetricsSink) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.metricsSink = sink
+}
+
+// metricsResponseWriter captures the status code and body size of a response
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	statusCode   int
+	bytesWritten int64
+}
+
+func newMetricsResponseWriter(w http.ResponseWriter) *metricsResponseWriter {
+	return &metricsResponseWriter{ResponseWriter: w}
+}
+
+func (mw *metricsResponseWriter) WriteHeader(statusCode int) {
+	if mw.statusCode == 0 {
+		mw.statusCode = statusCode
+	}
+	mw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (mw *metricsResponseWriter) Write(b []byte) (int, error) {
+	if mw.statusCode == 0 {
+		mw.statusCode = http.StatusOK
+	}
+	n, err := mw.ResponseWriter.Write(b)
+	mw.bytesWritten += int64(n)
+	return n, err
+}
+
+// Flush passes flushes through, so streaming handlers keep working when recorded
+func (mw *metricsResponseWriter) Flush() {
+	if mw.statusCode == 0 {
+		mw.statusCode = http.StatusOK
+	}
+	httpInternal.Flush(mw.ResponseWriter)
+}
+
+// Hijack passes connection hijacking through, e.g. for websockets
+func (mw *metricsResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return httpInternal.Hijack(mw.ResponseWriter)
+}
+
+// Push passes HTTP/2 server pushes through
+func (mw *metricsResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return httpInternal.Push(mw.ResponseWriter, target, opts)
+}
+
+// ReadFrom counts the copied body, keeping the underlying io.ReaderFrom optimization
+func (mw *metricsResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if mw.statusCode == 0 {
+		mw.statusCode = http.StatusOK
+	}
+	n, err := httpInternal.ReadFrom(mw.ResponseWriter, r)
+	mw.bytesWritten += n
+	return n, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (mw *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return mw.ResponseWriter
+}
+
+func (mw *metricsResponseWriter) observe(
+	sink MetricsSink,
+	r *http.Request,
+	pattern string,
+	duration time.Duration,
+) {
+	statusCode := mw.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	sink.ObserveRequest(
+		r, RouteObservation{
+			Method:       r.Method,
+			Pattern:      pattern,
+			StatusCode:   statusCode,
+			Duration:     duration,
+			BytesWritten: mw.bytesWritten,
+		},
+	)
+}
@@ -0,0 +1,148 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golibry/go-http/http/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (suite *MetricsTestSuite) TestItRecordsObservationsByRoutePattern() {
+	var mu sync.Mutex
+	var observations []RouteObservation
+
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /users/{id:[0-9]+}",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(5 * time.Millisecond)
+				_, _ = w.Write([]byte("user"))
+			},
+		),
+	)
+	mux.Handle(
+		"POST /users",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) }),
+	)
+	mux.SetMetricsSink(
+		MetricsSinkFunc(
+			func(r *http.Request, observation RouteObservation) {
+				mu.Lock()
+				defer mu.Unlock()
+				observations = append(observations, observation)
+			},
+		),
+	)
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/users/1", nil),
+		httptest.NewRequest(http.MethodGet, "/users/2", nil),
+		httptest.NewRequest(http.MethodPost, "/users", nil),
+		httptest.NewRequest(http.MethodGet, "/unknown", nil),
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	suite.Require().Len(observations, 4)
+
+	suite.Equal("GET /users/{id}", observations[0].Pattern)
+	suite.Equal("GET /users/{id}", observations[1].Pattern)
+	suite.Equal(http.StatusOK, observations[0].StatusCode)
+	suite.Equal(int64(4), observations[0].BytesWritten)
+	suite.GreaterOrEqual(observations[0].Duration, 5*time.Millisecond)
+
+	suite.Equal(
+		RouteObservation{Method: http.MethodPost, Pattern: "POST /users", StatusCode: http.StatusCreated},
+		suite.withoutDuration(observations[2]),
+	)
+	suite.Equal("", observations[3].Pattern)
+	suite.Equal(http.StatusNotFound, observations[3].StatusCode)
+}
+
+func (suite *MetricsTestSuite) TestItKeepsStreamingWorking() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /events",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("data: 1\n\n"))
+				suite.NoError(http.NewResponseController(w).Flush())
+			},
+		),
+	)
+	mux.SetMetricsSink(MetricsSinkFunc(func(r *http.Request, observation RouteObservation) {}))
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	suite.True(recorder.Flushed)
+}
+
+func (suite *MetricsTestSuite) TestItKeepsOptionalWriterInterfaces() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /download",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				suite.Require().Implements((*http.Hijacker)(nil), w)
+				suite.Require().Implements((*http.Pusher)(nil), w)
+				_, _ = w.(io.ReaderFrom).ReadFrom(strings.NewReader("content"))
+			},
+		),
+	)
+	var observation RouteObservation
+	mux.SetMetricsSink(
+		MetricsSinkFunc(func(r *http.Request, recorded RouteObservation) { observation = recorded }),
+	)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+
+	suite.Equal("content", recorder.Body.String())
+	suite.Equal(int64(7), observation.BytesWritten)
+	suite.Equal(http.StatusOK, observation.StatusCode)
+}
+
+func (suite *MetricsTestSuite) withoutDuration(observation RouteObservation) RouteObservation {
+	observation.Duration = 0
+	return observation
+}
+
+func (suite *MetricsTestSuite) TestItCanRecordIntoMetricsRecorders() {
+	recorder := metrics.NewMemory()
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /users/{id}",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("user")) }),
+	)
+	mux.SetMetricsSink(RecorderSink(recorder))
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/2", nil))
+
+	labels := metrics.Labels{"method": http.MethodGet, "route": "GET /users/{id}"}
+	suite.Equal(
+		2.0,
+		recorder.Value(
+			metrics.RequestsTotal,
+			metrics.Labels{"method": http.MethodGet, "route": "GET /users/{id}", "status": "200"},
+		),
+	)
+	suite.Len(recorder.Observations(metrics.RequestDuration, labels), 2)
+	suite.Equal([]float64{4, 4}, recorder.Observations(metrics.ResponseSize, labels))
+}
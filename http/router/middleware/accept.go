@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// NotAcceptableError is returned when the Accept header cannot be satisfied. It is an
+// alias of the http package error, shared with the negotiated response builder.
+type NotAcceptableError = httpInternal.NotAcceptableError
+
+type negotiatedTypeContextKey struct{}
+
+// NegotiatedContentType returns the media type selected by the AcceptEnforcer
+func NegotiatedContentType(ctx context.Context) (string, bool) {
+	contentType, ok := ctx.Value(negotiatedTypeContextKey{}).(string)
+	return contentType, ok
+}
+
+// AcceptEnforcer responds with 406 Not Acceptable, rendered through the error response
+// pipeline, when the Accept header cannot be satisfied by the producible content types.
+// The selected type is stored in the request context.
+type AcceptEnforcer struct {
+	next    http.Handler
+	logger  *slog.Logger
+	options AcceptOptions
+}
+
+// AcceptOptions configures the accept enforcement
+//
+// Produces: media types the route can produce, in order of preference (default: application/json)
+// Format: error response format (default: text)
+// Formatter: custom error body formatter taking precedence over Format
+type AcceptOptions struct {
+	Produces  []string
+	Format    ErrorFormat
+	Formatter httpInternal.ErrorFormatter
+}
+
+// NewAcceptEnforcer creates new accept enforcement middleware
+func NewAcceptEnforcer(
+	next http.Handler,
+	logger *slog.Logger,
+	options AcceptOptions,
+) *AcceptEnforcer {
+	if len(options.Produces) == 0 {
+		options.Produces = []string{"application/json"}
+	}
+	return &AcceptEnforcer{next: next, logger: logger, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (ae *AcceptEnforcer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The response depends on the Accept header, shared caches must know it
+	w.Header().Add("Vary", "Accept")
+
+	contentType, ok := httpInternal.NegotiateContentType(r, ae.options.Produces...)
+	if ok {
+		ctx := context.WithValue(r.Context(), negotiatedTypeContextKey{}, contentType)
+		ae.next.ServeHTTP(w, r.WithContext(ctx))
+		return
+	}
+
+	accept := r.Header.Get("Accept")
+	if ae.logger != nil {
+		ae.logger.WarnContext(
+			r.Context(),
+			"Request not acceptable",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("accept", accept),
+		)
+	}
+
+	builder := httpInternal.NewResponseBuilder(w).
+		Error().
+		WithError(NotAcceptableError{Accept: accept}).
+		WithRequest(r).
+		WithFormatter(ae.options.Formatter).
+		DisableLogging()
+	_ = ae.options.Format.apply(builder, r).Send()
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AcceptSuite struct {
+	suite.Suite
+}
+
+func TestAcceptSuite(t *testing.T) {
+	suite.Run(t, new(AcceptSuite))
+}
+
+func (suite *AcceptSuite) TestItEnforcesProducibleContentTypes() {
+	testCases := map[string]struct {
+		produces     []string
+		accept       string
+		expectedCode int
+		expectedType string
+	}{
+		"default json accepted":    {nil, "application/json", http.StatusOK, "application/json"},
+		"missing accept":           {nil, "", http.StatusOK, "application/json"},
+		"wildcard accepted":        {[]string{"text/csv", "application/json"}, "*/*", http.StatusOK, "text/csv"},
+		"best quality selected":    {[]string{"text/csv", "application/json"}, "text/csv;q=0.1, application/json", http.StatusOK, "application/json"},
+		"unsatisfiable":            {nil, "text/html", http.StatusNotAcceptable, ""},
+		"explicitly refused":       {nil, "application/json;q=0", http.StatusNotAcceptable, ""},
+		"subtype wildcard matches": {[]string{"text/csv"}, "text/*", http.StatusOK, "text/csv"},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				var negotiated string
+				handler := NewAcceptEnforcer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							negotiated, _ = NegotiatedContentType(r.Context())
+						},
+					),
+					nil,
+					AcceptOptions{Produces: testCase.produces},
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/report", nil)
+				request.Header.Set("Accept", testCase.accept)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(testCase.expectedType, negotiated)
+				suite.Equal("Accept", recorder.Header().Get("Vary"))
+			},
+		)
+	}
+}
+
+func (suite *AcceptSuite) TestItRendersNotAcceptableThroughErrorPipeline() {
+	handler := NewAcceptEnforcer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		nil,
+		AcceptOptions{Format: ErrorFormatProblem},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/report", nil)
+	request.Header.Set("Accept", "image/png")
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusNotAcceptable, recorder.Code)
+	suite.JSONEq(
+		`{"type":"about:blank","title":"Not Acceptable","status":406,`+
+			`"detail":"cannot produce a representation acceptable for \"image/png\"",`+
+			`"instance":"/report"}`,
+		recorder.Body.String(),
+	)
+}
@@ -7,11 +7,21 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 const AccessLogMessage = "HTTP Request"
 
+// accessLogAttrsPool recycles the attribute slices of the access log entries
+var accessLogAttrsPool = sync.Pool{
+	New: func() any {
+		entries := make([]slog.Attr, 0, 16)
+		return &entries
+	},
+}
+
 // extractClientIP safely extracts the client IP from RemoteAddr, handling both IPv4 and IPv6
 func extractClientIP(remoteAddr string) string {
 	host, _, err := net.SplitHostPort(remoteAddr)
@@ -30,7 +40,9 @@ type HTTPAccessLogger struct {
 }
 
 type AccessLogOptions struct {
-	LogClientIp bool
+	LogClientIp        bool
+	LogResponseBody    int      // Logs up to this many response body bytes (0 = disabled)
+	LogResponseHeaders []string // Logs these response headers, as sent when the response started
 }
 
 func NewHTTPAccessLogger(
@@ -42,12 +54,21 @@ func NewHTTPAccessLogger(
 }
 
 func (accessLogger *HTTPAccessLogger) ServeHTTP(rw http.ResponseWriter, rq *http.Request) {
-	logResponseWriter := httpInternal.NewResponseWriter(rw)
-	timeBeforeServe := time.Now().UnixMilli()
+	logResponseWriter := httpInternal.AcquireResponseWriter(rw)
+	defer logResponseWriter.Release()
+	if accessLogger.options.LogResponseBody > 0 {
+		logResponseWriter.CaptureBody(accessLogger.options.LogResponseBody)
+	}
 	accessLogger.next.ServeHTTP(logResponseWriter, rq)
-	timeAfterServe := time.Now().UnixMilli()
+	logResponseWriter.Finish()
 
-	var entries []slog.Attr
+	entriesPointer := accessLogAttrsPool.Get().(*[]slog.Attr)
+	entries := (*entriesPointer)[:0]
+	defer func() {
+		clear(entries)
+		*entriesPointer = entries[:0]
+		accessLogAttrsPool.Put(entriesPointer)
+	}()
 
 	if accessLogger.options.LogClientIp {
 		clientIP := extractClientIP(rq.RemoteAddr)
@@ -66,13 +87,29 @@ func (accessLogger *HTTPAccessLogger) ServeHTTP(rw http.ResponseWriter, rq *http
 			slog.String("Protocol", rq.Proto),
 			slog.String("User Agent", rq.UserAgent()),
 			slog.String("Response Status Code", strconv.Itoa(logResponseWriter.StatusCode())),
-			slog.String(
-				"Duration (s)",
-				fmt.Sprintf("%.2f", float64(timeAfterServe-timeBeforeServe)/1000),
-			),
+			slog.String("Time To First Byte (s)", formatSeconds(logResponseWriter.TimeToFirstByte())),
+			slog.String("Duration (s)", formatSeconds(logResponseWriter.Duration())),
 		}...,
 	)
 
+	if accessLogger.options.LogResponseBody > 0 {
+		entries = append(
+			entries,
+			slog.String("Response Body", string(logResponseWriter.CapturedBody())),
+			slog.Bool("Response Body Truncated", logResponseWriter.BodyTruncated()),
+		)
+	}
+
+	if len(accessLogger.options.LogResponseHeaders) > 0 {
+		entries = append(
+			entries,
+			responseHeadersAttr(
+				logResponseWriter.WrittenHeader(),
+				accessLogger.options.LogResponseHeaders,
+			),
+		)
+	}
+
 	accessLogger.logger.LogAttrs(
 		rq.Context(),
 		slog.LevelInfo,
@@ -80,3 +117,22 @@ func (accessLogger *HTTPAccessLogger) ServeHTTP(rw http.ResponseWriter, rq *http
 		entries...,
 	)
 }
+
+// responseHeadersAttr groups the present values of the named headers
+func responseHeadersAttr(header http.Header, names []string) slog.Attr {
+	var headers []any
+	for _, name := range names {
+		if values := header.Values(name); len(values) > 0 {
+			headers = append(
+				headers,
+				slog.String(http.CanonicalHeaderKey(name), strings.Join(values, ", ")),
+			)
+		}
+	}
+	return slog.Group("Response Headers", headers...)
+}
+
+// formatSeconds formats a duration as seconds with two decimals
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.2f", d.Seconds())
+}
@@ -30,6 +30,8 @@ type accessLog struct {
 	Protocol  string `json:"Protocol"`
 	UserAgent string `json:"User Agent"`
 	Code      string `json:"Response Status Code"`
+	TTFB      string `json:"Time To First Byte (s)"`
+	Duration  string `json:"Duration (s)"`
 }
 
 func (suite *AccessSuite) TestItCanLogAccessDetails() {
@@ -58,7 +60,7 @@ func (suite *AccessSuite) TestItCanLogAccessDetails() {
 			},
 		),
 		logger,
-		AccessLogOptions{true},
+		AccessLogOptions{LogClientIp: true},
 	)
 
 	middleware.ServeHTTP(
@@ -79,4 +81,74 @@ func (suite *AccessSuite) TestItCanLogAccessDetails() {
 	suite.Assert().Equal(expectedProtocol, loggedEntry.Protocol)
 	suite.Assert().Equal(expectedUserAgent, loggedEntry.UserAgent)
 	suite.Assert().Equal(strconv.Itoa(expectedCode), loggedEntry.Code)
+	suite.Assert().Equal("0.00", loggedEntry.TTFB)
+	suite.Assert().Equal("0.00", loggedEntry.Duration)
+}
+
+func (suite *AccessSuite) TestItCanLogTheResponseBody() {
+	testCases := map[string]struct {
+		limit             int
+		expectedBody      string
+		expectedTruncated bool
+	}{
+		"whole body":     {limit: 64, expectedBody: `{"id":7}`},
+		"truncated body": {limit: 4, expectedBody: `{"id`, expectedTruncated: true},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				outputBuffer := new(bytes.Buffer)
+				middleware := NewHTTPAccessLogger(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							_, _ = w.Write([]byte(`{"id":7}`))
+						},
+					),
+					slog.New(slog.NewJSONHandler(outputBuffer, nil)),
+					AccessLogOptions{LogResponseBody: testCase.limit},
+				)
+				recorder := httptest.NewRecorder()
+
+				middleware.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				var loggedEntry struct {
+					Body      string `json:"Response Body"`
+					Truncated bool   `json:"Response Body Truncated"`
+				}
+				suite.Require().NoError(json.Unmarshal(outputBuffer.Bytes(), &loggedEntry))
+				suite.Equal(testCase.expectedBody, loggedEntry.Body)
+				suite.Equal(testCase.expectedTruncated, loggedEntry.Truncated)
+				suite.Equal(`{"id":7}`, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *AccessSuite) TestItCanLogTheResponseHeadersAsSent() {
+	outputBuffer := new(bytes.Buffer)
+	middleware := NewHTTPAccessLogger(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Cache-Control", "no-store")
+				w.WriteHeader(http.StatusCreated)
+				// Changes after the response started never reach the client
+				w.Header().Set("Content-Type", "text/plain")
+			},
+		),
+		slog.New(slog.NewJSONHandler(outputBuffer, nil)),
+		AccessLogOptions{LogResponseHeaders: []string{"content-type", "Cache-Control", "ETag"}},
+	)
+
+	middleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var loggedEntry struct {
+		Headers map[string]string `json:"Response Headers"`
+	}
+	suite.Require().NoError(json.Unmarshal(outputBuffer.Bytes(), &loggedEntry))
+	suite.Equal(
+		map[string]string{"Content-Type": "application/json", "Cache-Control": "no-store"},
+		loggedEntry.Headers,
+	)
 }
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// DefaultMaxBodyBytes is the request body limit applied when none is configured
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// ErrRequestBodyTooLarge is rendered when the declared request body exceeds the limit
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+// BodyLimit caps the size of request bodies. Requests declaring a larger Content-Length
+// are answered with 413 Request Entity Too Large right away, other bodies are wrapped
+// with http.MaxBytesReader so reads past the limit fail with *http.MaxBytesError.
+type BodyLimit struct {
+	next    http.Handler
+	options BodyLimitOptions
+}
+
+// BodyLimitOptions configures the body limit middleware
+//
+// MaxBytes: maximum request body size (default: DefaultMaxBodyBytes, negative = unlimited)
+// Format: error response format used for rejected requests
+type BodyLimitOptions struct {
+	MaxBytes int64
+	Format   ErrorFormat
+}
+
+type bodyLimitContextKey struct{}
+
+// WithRequestBodyLimit returns a context carrying a body limit that takes precedence over
+// BodyLimitOptions.MaxBytes for the request served with it (used for per-route limits)
+func WithRequestBodyLimit(ctx context.Context, maxBytes int64) context.Context {
+	return context.WithValue(ctx, bodyLimitContextKey{}, maxBytes)
+}
+
+// RequestBodyLimitFromContext returns the per-request body limit override, if any
+func RequestBodyLimitFromContext(ctx context.Context) (int64, bool) {
+	maxBytes, ok := ctx.Value(bodyLimitContextKey{}).(int64)
+	return maxBytes, ok
+}
+
+// NewBodyLimit creates new body limit middleware
+func NewBodyLimit(next http.Handler, options BodyLimitOptions) *BodyLimit {
+	if options.MaxBytes == 0 {
+		options.MaxBytes = DefaultMaxBodyBytes
+	}
+	return &BodyLimit{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (bl *BodyLimit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	maxBytes := bl.options.MaxBytes
+	if requestLimit, ok := RequestBodyLimitFromContext(r.Context()); ok {
+		maxBytes = requestLimit
+	}
+
+	if maxBytes < 0 || r.Body == nil || r.Body == http.NoBody {
+		bl.next.ServeHTTP(w, r)
+		return
+	}
+
+	if r.ContentLength > maxBytes {
+		builder := httpInternal.NewResponseBuilder(w).
+			Status(http.StatusRequestEntityTooLarge).
+			Error().
+			WithError(ErrRequestBodyTooLarge).
+			WithRequest(r).
+			DisableLogging()
+		_ = bl.options.Format.apply(builder, r).Send()
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	bl.next.ServeHTTP(w, r)
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyLimitSuite struct {
+	suite.Suite
+}
+
+func TestBodyLimitSuite(t *testing.T) {
+	suite.Run(t, new(BodyLimitSuite))
+}
+
+func (suite *BodyLimitSuite) TestItCanLimitRequestBodies() {
+	readingHandler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			_, _ = w.Write(body)
+		},
+	)
+
+	testCases := map[string]struct {
+		options       BodyLimitOptions
+		body          string
+		unknownLength bool
+		requestLimit  *int64
+		expectedCode  int
+		expectedBody  string
+	}{
+		"within limit": {
+			options:      BodyLimitOptions{MaxBytes: 8},
+			body:         "12345678",
+			expectedCode: http.StatusOK,
+			expectedBody: "12345678",
+		},
+		"declared length over limit": {
+			options:      BodyLimitOptions{MaxBytes: 8},
+			body:         "123456789",
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedBody: "request body too large",
+		},
+		"streamed body over limit": {
+			options:       BodyLimitOptions{MaxBytes: 8},
+			body:          "123456789",
+			unknownLength: true,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+		},
+		"request limit override": {
+			options:      BodyLimitOptions{MaxBytes: 8},
+			body:         "123456789",
+			requestLimit: func() *int64 { limit := int64(16); return &limit }(),
+			expectedCode: http.StatusOK,
+			expectedBody: "123456789",
+		},
+		"unlimited": {
+			options:      BodyLimitOptions{MaxBytes: -1},
+			body:         strings.Repeat("x", int(DefaultMaxBodyBytes)+1),
+			expectedCode: http.StatusOK,
+			expectedBody: strings.Repeat("x", int(DefaultMaxBodyBytes)+1),
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+				if tc.unknownLength {
+					request.ContentLength = -1
+				}
+				if tc.requestLimit != nil {
+					request = request.WithContext(WithRequestBodyLimit(request.Context(), *tc.requestLimit))
+				}
+
+				recorder := httptest.NewRecorder()
+				NewBodyLimit(readingHandler, tc.options).ServeHTTP(recorder, request)
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				if tc.expectedBody != "" {
+					suite.Equal(tc.expectedBody, recorder.Body.String())
+				}
+			},
+		)
+	}
+}
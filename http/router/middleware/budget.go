@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ServerTimingHeader is the standard header used to report server side timing metrics
+const ServerTimingHeader = "Server-Timing"
+
+// BudgetReporter writes the elapsed and remaining time budget of a request on the
+// response, so callers in a service chain can adapt their own timeouts.
+// The remaining budget is derived from the request context deadline, place it inside
+// DeadlinePropagation and/or TimeoutMiddleware for the budget to be known.
+type BudgetReporter struct {
+	next    http.Handler
+	options BudgetOptions
+}
+
+// BudgetOptions configures the budget reporter
+//
+// HeaderName: header carrying the budget (default: "Server-Timing")
+//
+// With Server-Timing, "app;dur=<elapsed ms>" and, when a deadline is set,
+// "budget;dur=<remaining ms>" metrics are appended to the header.
+// With any other header, the remaining budget is written in grpc-timeout style
+// ("850m"), understood by DeadlinePropagation, and no header is set without a deadline.
+type BudgetOptions struct {
+	HeaderName string
+}
+
+// NewBudgetReporter creates new budget reporting middleware
+func NewBudgetReporter(next http.Handler, options BudgetOptions) *BudgetReporter {
+	if options.HeaderName == "" {
+		options.HeaderName = ServerTimingHeader
+	}
+	return &BudgetReporter{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (br *BudgetReporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	writer := httpInternal.AcquireResponseWriter(w).OnFirstWrite(
+		func(header http.Header) {
+			br.setHeader(header, r, start)
+		},
+	)
+	defer writer.Release()
+	br.next.ServeHTTP(writer, r)
+}
+
+// setHeader writes the budget header right before the response header is sent
+func (br *BudgetReporter) setHeader(header http.Header, r *http.Request, start time.Time) {
+	now := time.Now()
+	deadline, hasDeadline := r.Context().Deadline()
+	remaining := deadline.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if http.CanonicalHeaderKey(br.options.HeaderName) == ServerTimingHeader {
+		header.Add(br.options.HeaderName, "app;dur="+formatMilliseconds(now.Sub(start)))
+		if hasDeadline {
+			header.Add(br.options.HeaderName, "budget;dur="+formatMilliseconds(remaining))
+		}
+		return
+	}
+
+	if hasDeadline {
+		header.Set(br.options.HeaderName, strconv.FormatInt(remaining.Milliseconds(), 10)+"m")
+	}
+}
+
+// formatMilliseconds formats a duration as fractional milliseconds
+func formatMilliseconds(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 1, 64)
+}
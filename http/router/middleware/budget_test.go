@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetReporterSuite struct {
+	suite.Suite
+}
+
+func TestBudgetReporterSuite(t *testing.T) {
+	suite.Run(t, new(BudgetReporterSuite))
+}
+
+func (suite *BudgetReporterSuite) TestItReportsBudgetInServerTiming() {
+	handler := NewBudgetReporter(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add(ServerTimingHeader, "db;dur=2")
+				_, _ = w.Write([]byte("ok"))
+			},
+		),
+		BudgetOptions{},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	values := recorder.Header().Values(ServerTimingHeader)
+	suite.Require().Len(values, 3)
+	suite.Equal("db;dur=2", values[0])
+	suite.True(strings.HasPrefix(values[1], "app;dur="))
+	suite.True(strings.HasPrefix(values[2], "budget;dur="))
+
+	remaining, err := strconv.ParseFloat(strings.TrimPrefix(values[2], "budget;dur="), 64)
+	suite.Require().NoError(err)
+	suite.InDelta(1000, remaining, 50)
+}
+
+func (suite *BudgetReporterSuite) TestItOmitsBudgetWithoutDeadline() {
+	testCases := map[string]struct {
+		headerName     string
+		expectedValues []string
+	}{
+		"server timing": {
+			headerName:     ServerTimingHeader,
+			expectedValues: []string{"app"},
+		},
+		"custom header": {
+			headerName:     "X-Request-Timeout",
+			expectedValues: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewBudgetReporter(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+					BudgetOptions{HeaderName: testCase.headerName},
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				var metrics []string
+				for _, value := range recorder.Header().Values(testCase.headerName) {
+					metrics = append(metrics, strings.SplitN(value, ";", 2)[0])
+				}
+				suite.Equal(testCase.expectedValues, metrics)
+				suite.Equal(http.StatusNoContent, recorder.Code)
+			},
+		)
+	}
+}
+
+func (suite *BudgetReporterSuite) TestItReportsRemainingBudgetInGrpcTimeoutStyle() {
+	handler := NewDeadlinePropagation(
+		NewBudgetReporter(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) }),
+			BudgetOptions{HeaderName: "X-Request-Timeout"},
+		),
+		nil,
+		DeadlineOptions{},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Request-Timeout", "2S")
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	value := recorder.Header().Get("X-Request-Timeout")
+	suite.True(strings.HasSuffix(value, "m"))
+	remaining, err := parseTimeoutHeader(value)
+	suite.Require().NoError(err)
+	suite.InDelta(float64(2*time.Second), float64(remaining), float64(50*time.Millisecond))
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// CachePolicy describes the caching headers applied to matching responses
+//
+// Public/Private: response cacheability by shared caches
+// NoStore: forbid caching entirely (e.g. HTML pages with personal data)
+// NoCache: require revalidation before a cached copy is used
+// MaxAge: freshness lifetime (0 = no max-age directive, ZeroMaxAge or any negative
+// value = max-age=0, like http.Cookie MaxAge)
+// Immutable: the content never changes for the same URL (fingerprinted assets)
+// Expires: also set the legacy Expires header from MaxAge (past date with NoStore)
+type CachePolicy struct {
+	Public    bool
+	Private   bool
+	NoStore   bool
+	NoCache   bool
+	MaxAge    time.Duration
+	Immutable bool
+	Expires   bool
+}
+
+// ZeroMaxAge sends max-age=0, which a zero CachePolicy.MaxAge leaves out: the response
+// may be stored, but is stale right away
+const ZeroMaxAge time.Duration = -1
+
+// CacheRule binds a policy to the responses it applies to. All configured matchers
+// must match; a rule with no matchers applies to every response.
+//
+// PathPrefix: request path prefix (e.g. "/assets/")
+// PathPattern: path.Match pattern for the request path (e.g. "/static/*.*.js")
+// Extensions: request path extensions, including the dot (e.g. ".css")
+// ContentTypes: response media types (e.g. "text/html"), matched when the header is written
+type CacheRule struct {
+	PathPrefix   string
+	PathPattern  string
+	Extensions   []string
+	ContentTypes []string
+	Policy       CachePolicy
+}
+
+// CacheControl applies Cache-Control (and optionally Expires) policies to successful
+// responses, independently of how they are produced. The first matching rule wins and
+// a Cache-Control header set by the handler is left untouched.
+type CacheControl struct {
+	next    http.Handler
+	options CacheControlOptions
+}
+
+// CacheControlOptions configures the cache policy middleware
+//
+// Rules: policies evaluated in order
+type CacheControlOptions struct {
+	Rules []CacheRule
+}
+
+// NewCacheControl creates new cache policy middleware
+func NewCacheControl(next http.Handler, options CacheControlOptions) *CacheControl {
+	return &CacheControl{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (cc *CacheControl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cc.next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, cacheControl: cc, request: r}, r)
+}
+
+// apply sets the headers of the first rule matching the response
+func (cc *CacheControl) apply(header http.Header, r *http.Request, statusCode int) {
+	if header.Get("Cache-Control") != "" {
+		return
+	}
+	if (statusCode < 200 || statusCode >= 300) && statusCode != http.StatusNotModified {
+		return
+	}
+
+	for _, rule := range cc.options.Rules {
+		if rule.matches(r, header) {
+			rule.Policy.apply(header, time.Now())
+			return
+		}
+	}
+}
+
+// matches reports whether the rule applies to the request and response header
+func (rule CacheRule) matches(r *http.Request, header http.Header) bool {
+	if rule.PathPrefix != "" && !strings.HasPrefix(r.URL.Path, rule.PathPrefix) {
+		return false
+	}
+	if rule.PathPattern != "" {
+		if matched, err := path.Match(rule.PathPattern, r.URL.Path); err != nil || !matched {
+			return false
+		}
+	}
+	if len(rule.Extensions) > 0 && !containsFold(rule.Extensions, path.Ext(r.URL.Path)) {
+		return false
+	}
+	if len(rule.ContentTypes) > 0 {
+		mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+		if err != nil || !containsFold(rule.ContentTypes, mediaType) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply writes the policy headers
+func (policy CachePolicy) apply(header http.Header, now time.Time) {
+	var directives []string
+	if policy.Public {
+		directives = append(directives, "public")
+	}
+	if policy.Private {
+		directives = append(directives, "private")
+	}
+	if policy.NoStore {
+		directives = append(directives, "no-store")
+	}
+	if policy.NoCache {
+		directives = append(directives, "no-cache")
+	}
+	if policy.MaxAge != 0 {
+		maxAge := max(policy.MaxAge, 0)
+		directives = append(directives, "max-age="+strconv.FormatInt(int64(maxAge/time.Second), 10))
+	}
+	if policy.Immutable {
+		directives = append(directives, "immutable")
+	}
+
+	if len(directives) > 0 {
+		header.Set("Cache-Control", strings.Join(directives, ", "))
+	}
+
+	if policy.Expires {
+		expires := time.Unix(0, 0)
+		if !policy.NoStore && policy.MaxAge != 0 {
+			expires = now.Add(max(policy.MaxAge, 0))
+		}
+		header.Set("Expires", expires.UTC().Format(http.TimeFormat))
+	}
+}
+
+// containsFold reports whether values holds value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheControlWriter applies the cache policy right before the header is written
+type cacheControlWriter struct {
+	http.ResponseWriter
+	cacheControl *CacheControl
+	request      *http.Request
+	wroteHeader  bool
+}
+
+func (cw *cacheControlWriter) WriteHeader(statusCode int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		cw.cacheControl.apply(cw.ResponseWriter.Header(), cw.request, statusCode)
+	}
+	cw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (cw *cacheControlWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		if len(b) > 0 && cw.Header().Get("Content-Type") == "" {
+			cw.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *cacheControlWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	httpInternal.Flush(cw.ResponseWriter)
+}
+
+func (cw *cacheControlWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return httpInternal.Hijack(cw.ResponseWriter)
+}
+
+func (cw *cacheControlWriter) Push(target string, opts *http.PushOptions) error {
+	return httpInternal.Push(cw.ResponseWriter, target, opts)
+}
+
+func (cw *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *cacheControlWriter) ReadFrom(r io.Reader) (int64, error) {
+	if !cw.wroteHeader {
+		// net/http still sniffs the content type from the first bytes copied
+		cw.WriteHeader(http.StatusOK)
+	}
+	return httpInternal.ReadFrom(cw.ResponseWriter, r)
+}
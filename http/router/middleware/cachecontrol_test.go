@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheControlSuite struct {
+	suite.Suite
+}
+
+func TestCacheControlSuite(t *testing.T) {
+	suite.Run(t, new(CacheControlSuite))
+}
+
+func (suite *CacheControlSuite) newCacheControl(handler http.HandlerFunc) *CacheControl {
+	return NewCacheControl(
+		handler,
+		CacheControlOptions{
+			Rules: []CacheRule{
+				{
+					PathPrefix: "/assets/",
+					Extensions: []string{".js", ".css"},
+					Policy: CachePolicy{
+						Public: true, MaxAge: 365 * 24 * time.Hour, Immutable: true,
+					},
+				},
+				{
+					ContentTypes: []string{"text/html"},
+					Policy:       CachePolicy{NoStore: true, Expires: true},
+				},
+				{
+					PathPrefix: "/feed",
+					Policy:     CachePolicy{Private: true, MaxAge: ZeroMaxAge, Expires: true},
+				},
+				{
+					PathPattern: "/images/*.png",
+					Policy:      CachePolicy{Public: true, MaxAge: time.Hour, Expires: true},
+				},
+			},
+		},
+	)
+}
+
+func (suite *CacheControlSuite) TestItAppliesFirstMatchingPolicy() {
+	testCases := map[string]struct {
+		path                 string
+		contentType          string
+		body                 string
+		expectedCacheControl string
+		expectedExpires      bool
+	}{
+		"fingerprinted asset": {
+			path:                 "/assets/app.3f2a.js",
+			contentType:          "text/javascript",
+			expectedCacheControl: "public, max-age=31536000, immutable",
+		},
+		"asset with other extension": {
+			path:        "/assets/app.map",
+			contentType: "application/json",
+		},
+		"html by content type": {
+			path:                 "/",
+			contentType:          "text/html; charset=utf-8",
+			expectedCacheControl: "no-store",
+			expectedExpires:      true,
+		},
+		"html by sniffed content type": {
+			path:                 "/about",
+			body:                 "<html><body>about</body></html>",
+			expectedCacheControl: "no-store",
+			expectedExpires:      true,
+		},
+		"path pattern": {
+			path:                 "/images/logo.png",
+			contentType:          "image/png",
+			expectedCacheControl: "public, max-age=3600",
+			expectedExpires:      true,
+		},
+		"zero max age": {
+			path:                 "/feed",
+			contentType:          "application/json",
+			expectedCacheControl: "private, max-age=0",
+			expectedExpires:      true,
+		},
+		"no match": {
+			path:        "/api/users",
+			contentType: "application/json",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := suite.newCacheControl(
+					func(w http.ResponseWriter, r *http.Request) {
+						if testCase.contentType != "" {
+							w.Header().Set("Content-Type", testCase.contentType)
+						}
+						_, _ = w.Write([]byte(testCase.body))
+					},
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testCase.path, nil))
+
+				suite.Equal(testCase.expectedCacheControl, recorder.Header().Get("Cache-Control"))
+				suite.Equal(testCase.expectedExpires, recorder.Header().Get("Expires") != "")
+			},
+		)
+	}
+}
+
+func (suite *CacheControlSuite) TestItSetsExpiresFromMaxAge() {
+	handler := suite.newCacheControl(
+		func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) },
+	)
+	recorder := httptest.NewRecorder()
+	start := time.Now()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/images/logo.png", nil))
+
+	expires, err := http.ParseTime(recorder.Header().Get("Expires"))
+	suite.Require().NoError(err)
+	suite.WithinDuration(start.Add(time.Hour), expires, 2*time.Second)
+}
+
+func (suite *CacheControlSuite) TestItDoesNotSniffEmptyWrites() {
+	handler := suite.newCacheControl(
+		func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(nil) },
+	)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/about", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("Content-Type"))
+}
+
+func (suite *CacheControlSuite) TestItSkipsErrorsAndHandlerPolicies() {
+	testCases := map[string]struct {
+		handler              http.HandlerFunc
+		expectedCacheControl string
+	}{
+		"error response": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+		},
+		"handler policy": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "no-cache")
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedCacheControl: "no-cache",
+		},
+		"not modified": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotModified)
+			},
+			expectedCacheControl: "public, max-age=31536000, immutable",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+
+				suite.newCacheControl(testCase.handler).ServeHTTP(
+					recorder, httptest.NewRequest(http.MethodGet, "/assets/app.js", nil),
+				)
+
+				suite.Equal(testCase.expectedCacheControl, recorder.Header().Get("Cache-Control"))
+			},
+		)
+	}
+}
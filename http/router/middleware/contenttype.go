@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// UnsupportedMediaTypeError is returned for requests whose Content-Type is not allowed
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+func (e UnsupportedMediaTypeError) Error() string {
+	if e.ContentType == "" {
+		return "missing Content-Type"
+	}
+	return fmt.Sprintf("unsupported Content-Type %q", e.ContentType)
+}
+
+// StatusCode implements the HTTPError interface
+func (e UnsupportedMediaTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// ContentTypeEnforcer rejects requests whose Content-Type is not in an allowlist
+// with 415 Unsupported Media Type, rendered through the error response pipeline.
+// It protects JSON APIs from form or other unexpected payloads.
+type ContentTypeEnforcer struct {
+	next    http.Handler
+	logger  *slog.Logger
+	options ContentTypeOptions
+}
+
+// ContentTypeOptions configures the content type enforcement
+//
+// AllowedTypes: allowed media types, "type/*" matches a whole type (default: application/json)
+// Methods: methods whose requests are checked (default: POST, PUT, PATCH)
+// Format: error response format (default: text)
+// Formatter: custom error body formatter taking precedence over Format
+//
+// Requests without a body and without a Content-Type are always accepted.
+type ContentTypeOptions struct {
+	AllowedTypes []string
+	Methods      []string
+	Format       ErrorFormat
+	Formatter    httpInternal.ErrorFormatter
+}
+
+// NewContentTypeEnforcer creates new content type enforcement middleware
+func NewContentTypeEnforcer(
+	next http.Handler,
+	logger *slog.Logger,
+	options ContentTypeOptions,
+) *ContentTypeEnforcer {
+	if len(options.AllowedTypes) == 0 {
+		options.AllowedTypes = []string{"application/json"}
+	}
+	if len(options.Methods) == 0 {
+		options.Methods = []string{http.MethodPost, http.MethodPut, http.MethodPatch}
+	}
+	return &ContentTypeEnforcer{next: next, logger: logger, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (cte *ContentTypeEnforcer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if !cte.shouldCheck(r, contentType) || cte.isAllowed(contentType) {
+		cte.next.ServeHTTP(w, r)
+		return
+	}
+
+	if cte.logger != nil {
+		cte.logger.WarnContext(
+			r.Context(),
+			"Unsupported request content type",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("contentType", contentType),
+		)
+	}
+
+	builder := httpInternal.NewResponseBuilder(w).
+		Error().
+		WithError(UnsupportedMediaTypeError{ContentType: contentType}).
+		WithRequest(r).
+		WithFormatter(cte.options.Formatter).
+		DisableLogging()
+	_ = cte.options.Format.apply(builder, r).Send()
+}
+
+func (cte *ContentTypeEnforcer) shouldCheck(r *http.Request, contentType string) bool {
+	if r.ContentLength == 0 && contentType == "" {
+		return false
+	}
+	for _, method := range cte.options.Methods {
+		if strings.EqualFold(r.Method, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cte *ContentTypeEnforcer) isAllowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range cte.options.AllowedTypes {
+		allowed = strings.ToLower(allowed)
+		if mediaType == allowed {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok &&
+			strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
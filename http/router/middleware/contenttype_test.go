@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentTypeSuite struct {
+	suite.Suite
+}
+
+func TestContentTypeSuite(t *testing.T) {
+	suite.Run(t, new(ContentTypeSuite))
+}
+
+func (suite *ContentTypeSuite) TestItEnforcesAllowedContentTypes() {
+	testCases := map[string]struct {
+		options      ContentTypeOptions
+		method       string
+		contentType  string
+		body         string
+		expectedCode int
+	}{
+		"json allowed by default": {
+			method: http.MethodPost, contentType: "application/json; charset=utf-8",
+			body: "{}", expectedCode: http.StatusOK,
+		},
+		"form rejected by default": {
+			method: http.MethodPost, contentType: "application/x-www-form-urlencoded",
+			body: "a=b", expectedCode: http.StatusUnsupportedMediaType,
+		},
+		"missing content type with body rejected": {
+			method: http.MethodPut, body: "{}", expectedCode: http.StatusUnsupportedMediaType,
+		},
+		"malformed content type rejected": {
+			method: http.MethodPatch, contentType: "application/json;;", body: "{}",
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		"bodiless request accepted": {
+			method: http.MethodPost, expectedCode: http.StatusOK,
+		},
+		"unchecked method accepted": {
+			method: http.MethodGet, contentType: "text/plain", body: "x", expectedCode: http.StatusOK,
+		},
+		"wildcard allowlist": {
+			options:     ContentTypeOptions{AllowedTypes: []string{"multipart/*"}},
+			method:      http.MethodPost,
+			contentType: "multipart/form-data; boundary=x", body: "--x--",
+			expectedCode: http.StatusOK,
+		},
+		"custom methods": {
+			options:     ContentTypeOptions{Methods: []string{http.MethodDelete}},
+			method:      http.MethodDelete,
+			contentType: "text/plain", body: "x",
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewContentTypeEnforcer(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+					nil,
+					testCase.options,
+				)
+
+				request := httptest.NewRequest(
+					testCase.method, "/api", strings.NewReader(testCase.body),
+				)
+				if testCase.contentType != "" {
+					request.Header.Set("Content-Type", testCase.contentType)
+				}
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+			},
+		)
+	}
+}
+
+func (suite *ContentTypeSuite) TestItRendersRejectionThroughErrorPipeline() {
+	handler := NewContentTypeEnforcer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		nil,
+		ContentTypeOptions{Format: ErrorFormatJSON},
+	)
+
+	request := httptest.NewRequest(http.MethodPost, "/api", strings.NewReader("a=b"))
+	request.Header.Set("Content-Type", "text/plain")
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusUnsupportedMediaType, recorder.Code)
+	suite.JSONEq(
+		`{"error":"unsupported Content-Type \"text/plain\"","status":415}`,
+		recorder.Body.String(),
+	)
+}
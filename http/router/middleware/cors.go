@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS implements Cross-Origin Resource Sharing. Preflight requests from allowed origins
+// are answered with 204 No Content and the allowed methods and headers, actual requests
+// get the Access-Control-Allow-Origin header. Requests from other origins get no CORS
+// headers, letting the browser block them.
+type CORS struct {
+	next    http.Handler
+	options CORSOptions
+}
+
+// CORSOptions configures the CORS middleware
+//
+// AllowedOrigins: allowed origins, "*" for any and "https://*.example.com" for
+// subdomains (no origin is allowed when empty and AllowOriginFunc is nil)
+// AllowOriginFunc: custom origin check, consulted when AllowedOrigins does not match
+// AllowedMethods: methods allowed for preflight requests (default: GET, HEAD, POST,
+// PUT, PATCH, DELETE)
+// AllowedHeaders: request headers allowed for preflight requests (default: the headers
+// requested by the browser)
+// ExposedHeaders: response headers readable by the browser
+// AllowCredentials: allow cookies and authorization headers (the origin is then echoed
+// instead of "*")
+// MaxAge: how long browsers may cache preflight responses (0 = not sent)
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowOriginFunc  func(origin string) bool
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+type corsContextKey struct{}
+
+// WithRequestCORS returns a context carrying CORS options that take precedence over the
+// middleware options for the request served with it (used for per-route policies)
+func WithRequestCORS(ctx context.Context, options CORSOptions) context.Context {
+	return context.WithValue(ctx, corsContextKey{}, options.withDefaults())
+}
+
+// RequestCORSFromContext returns the per-request CORS options override, if any
+func RequestCORSFromContext(ctx context.Context) (CORSOptions, bool) {
+	options, ok := ctx.Value(corsContextKey{}).(CORSOptions)
+	return options, ok
+}
+
+// NewCORS creates new CORS middleware
+func NewCORS(next http.Handler, options CORSOptions) *CORS {
+	return &CORS{next: next, options: options.withDefaults()}
+}
+
+// ServeHTTP implements the middleware logic
+func (cors *CORS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	options := cors.options
+	if requestOptions, ok := RequestCORSFromContext(r.Context()); ok {
+		options = requestOptions
+	}
+
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+	headers := w.Header()
+	headers.Add("Vary", "Origin")
+	if preflight {
+		headers.Add("Vary", "Access-Control-Request-Method")
+		headers.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if origin == "" {
+		cors.next.ServeHTTP(w, r)
+		return
+	}
+
+	allowed := options.allowsOrigin(origin)
+	if allowed {
+		headers.Set("Access-Control-Allow-Origin", options.allowOriginValue(origin))
+		if options.AllowCredentials {
+			headers.Set("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	if !preflight {
+		if allowed && len(options.ExposedHeaders) > 0 {
+			headers.Set("Access-Control-Expose-Headers", strings.Join(options.ExposedHeaders, ", "))
+		}
+		cors.next.ServeHTTP(w, r)
+		return
+	}
+
+	if allowed {
+		headers.Set("Access-Control-Allow-Methods", strings.Join(options.AllowedMethods, ", "))
+		if len(options.AllowedHeaders) > 0 {
+			headers.Set("Access-Control-Allow-Headers", strings.Join(options.AllowedHeaders, ", "))
+		} else if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+			headers.Set("Access-Control-Allow-Headers", requested)
+		}
+		if options.MaxAge > 0 {
+			headers.Set("Access-Control-Max-Age", strconv.Itoa(int(options.MaxAge.Seconds())))
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (options CORSOptions) withDefaults() CORSOptions {
+	if len(options.AllowedMethods) == 0 {
+		options.AllowedMethods = []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		}
+	}
+	return options
+}
+
+// allowsOrigin reports whether the origin matches the allowed origins or the custom check
+func (options CORSOptions) allowsOrigin(origin string) bool {
+	for _, allowed := range options.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		if scheme, domain, ok := strings.Cut(allowed, "://*."); ok &&
+			strings.HasPrefix(strings.ToLower(origin), strings.ToLower(scheme)+"://") &&
+			strings.HasSuffix(strings.ToLower(origin), "."+strings.ToLower(domain)) {
+			return true
+		}
+	}
+	return options.AllowOriginFunc != nil && options.AllowOriginFunc(origin)
+}
+
+// allowOriginValue returns "*" for wildcard configurations without credentials, the
+// request origin otherwise
+func (options CORSOptions) allowOriginValue(origin string) string {
+	if !options.AllowCredentials {
+		for _, allowed := range options.AllowedOrigins {
+			if allowed == "*" {
+				return "*"
+			}
+		}
+	}
+	return origin
+}
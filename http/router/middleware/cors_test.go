@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CORSSuite struct {
+	suite.Suite
+}
+
+func TestCORSSuite(t *testing.T) {
+	suite.Run(t, new(CORSSuite))
+}
+
+func (suite *CORSSuite) TestItCanHandleCrossOriginRequests() {
+	testCases := map[string]struct {
+		options         CORSOptions
+		method          string
+		headers         map[string]string
+		expectedCode    int
+		expectedHeaders map[string]string
+	}{
+		"same origin": {
+			options:         CORSOptions{AllowedOrigins: []string{"https://app.example.com"}},
+			method:          http.MethodGet,
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		"allowed origin": {
+			options: CORSOptions{
+				AllowedOrigins: []string{"https://app.example.com"},
+				ExposedHeaders: []string{"X-Request-Id"},
+			},
+			method:       http.MethodGet,
+			headers:      map[string]string{"Origin": "https://app.example.com"},
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Expose-Headers": "X-Request-Id",
+			},
+		},
+		"subdomain wildcard": {
+			options:         CORSOptions{AllowedOrigins: []string{"https://*.example.com"}},
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "https://partner.example.com"},
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": "https://partner.example.com"},
+		},
+		"any origin": {
+			options:         CORSOptions{AllowedOrigins: []string{"*"}},
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "https://other.org"},
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": "*"},
+		},
+		"any origin with credentials": {
+			options:      CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method:       http.MethodGet,
+			headers:      map[string]string{"Origin": "https://other.org"},
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://other.org",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		"custom origin check": {
+			options: CORSOptions{
+				AllowOriginFunc: func(origin string) bool { return strings.HasSuffix(origin, ".test") },
+			},
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "http://local.test"},
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": "http://local.test"},
+		},
+		"disallowed origin": {
+			options:         CORSOptions{AllowedOrigins: []string{"https://app.example.com"}},
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "https://evil.example.org"},
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		"preflight": {
+			options: CORSOptions{
+				AllowedOrigins: []string{"https://app.example.com"},
+				MaxAge:         10 * time.Minute,
+			},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPut,
+				"Access-Control-Request-Headers": "Content-Type, X-Token",
+			},
+			expectedCode: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.com",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST, PUT, PATCH, DELETE",
+				"Access-Control-Allow-Headers": "Content-Type, X-Token",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		"preflight with allowed headers": {
+			options: CORSOptions{
+				AllowedOrigins: []string{"https://app.example.com"},
+				AllowedMethods: []string{http.MethodGet},
+				AllowedHeaders: []string{"Content-Type"},
+			},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			expectedCode: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "GET",
+				"Access-Control-Allow-Headers": "Content-Type",
+			},
+		},
+		"disallowed preflight": {
+			options: CORSOptions{AllowedOrigins: []string{"https://app.example.com"}},
+			method:  http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.org",
+				"Access-Control-Request-Method": http.MethodPut,
+			},
+			expectedCode: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(tc.method, "/", nil)
+				for key, value := range tc.headers {
+					request.Header.Set(key, value)
+				}
+
+				recorder := httptest.NewRecorder()
+				NewCORS(okHandler(), tc.options).ServeHTTP(recorder, request)
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				suite.Contains(recorder.Header().Values("Vary"), "Origin")
+				for key, value := range tc.expectedHeaders {
+					suite.Equal(value, recorder.Header().Get(key), key)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ErrClientDeadlineExceeded is rendered when the client deadline already expired on arrival
+var ErrClientDeadlineExceeded = errors.New("client deadline exceeded")
+
+// DeadlinePropagation narrows the request context deadline to the time budget sent by
+// the client, so work is abandoned once the caller stopped waiting. Deadlines only ever
+// narrow, a TimeoutMiddleware further down the chain keeps capping requests with its own
+// timeout and answers with its timeout response when the earlier deadline passes.
+type DeadlinePropagation struct {
+	next    http.Handler
+	logger  *slog.Logger
+	options DeadlineOptions
+}
+
+// DeadlineOptions configures the deadline propagation
+//
+// TimeoutHeader: relative budget header (default: "X-Request-Timeout"); accepts
+// grpc-timeout values only, up to 8 digits and a case-sensitive unit: H (hours),
+// M (minutes), S (seconds), m (milliseconds), u (microseconds), n (nanoseconds), so
+// "250m" is 250 milliseconds and ten minutes are "10M". Go durations ("1.5s") are invalid.
+// DeadlineHeader: absolute deadline header (default: "X-Request-Deadline"); accepts
+// RFC 3339 timestamps or unix milliseconds, subject to clock skew between hosts
+// MaxTimeout: upper bound applied to client budgets (0 = unbounded)
+// Format: error response format used when the deadline already expired
+//
+// When both headers are sent, the earliest deadline wins. Invalid values are ignored.
+type DeadlineOptions struct {
+	TimeoutHeader  string
+	DeadlineHeader string
+	MaxTimeout     time.Duration
+	Format         ErrorFormat
+}
+
+// NewDeadlinePropagation creates new deadline propagation middleware
+func NewDeadlinePropagation(
+	next http.Handler,
+	logger *slog.Logger,
+	options DeadlineOptions,
+) *DeadlinePropagation {
+	if options.TimeoutHeader == "" {
+		options.TimeoutHeader = "X-Request-Timeout"
+	}
+	if options.DeadlineHeader == "" {
+		options.DeadlineHeader = "X-Request-Deadline"
+	}
+	return &DeadlinePropagation{next: next, logger: logger, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (dp *DeadlinePropagation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	deadline, ok := dp.clientDeadline(r, now)
+	if !ok {
+		dp.next.ServeHTTP(w, r)
+		return
+	}
+
+	if !deadline.After(now) {
+		if dp.logger != nil {
+			dp.logger.WarnContext(
+				r.Context(),
+				"Client deadline exceeded on arrival",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Time("deadline", deadline),
+			)
+		}
+
+		builder := httpInternal.NewResponseBuilder(w).
+			Status(http.StatusGatewayTimeout).
+			Error().
+			WithError(ErrClientDeadlineExceeded).
+			WithRequest(r).
+			DisableLogging()
+		_ = dp.options.Format.apply(builder, r).Send()
+		return
+	}
+
+	ctx, cancel := context.WithDeadline(r.Context(), deadline)
+	defer cancel()
+
+	dp.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// clientDeadline resolves the deadline requested by the client, bounded by MaxTimeout
+func (dp *DeadlinePropagation) clientDeadline(r *http.Request, now time.Time) (time.Time, bool) {
+	var deadline time.Time
+
+	if value := r.Header.Get(dp.options.TimeoutHeader); value != "" {
+		if timeout, err := parseTimeoutHeader(value); err == nil {
+			deadline = now.Add(timeout)
+		}
+	}
+
+	if value := r.Header.Get(dp.options.DeadlineHeader); value != "" {
+		if absolute, err := parseDeadlineHeader(value); err == nil &&
+			(deadline.IsZero() || absolute.Before(deadline)) {
+			deadline = absolute
+		}
+	}
+
+	if deadline.IsZero() {
+		return deadline, false
+	}
+
+	if dp.options.MaxTimeout > 0 {
+		if maxDeadline := now.Add(dp.options.MaxTimeout); deadline.After(maxDeadline) {
+			deadline = maxDeadline
+		}
+	}
+
+	return deadline, true
+}
+
+// grpcTimeoutUnits maps grpc-timeout unit suffixes to durations
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// parseTimeoutHeader parses grpc-timeout values ("250m" for 250 milliseconds, "10M" for
+// 10 minutes), capping those beyond the longest time.Duration to it
+func parseTimeoutHeader(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("invalid timeout %q: expected 1 to 8 digits and a unit", value)
+	}
+
+	unit, ok := grpcTimeoutUnits[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid timeout %q: unknown unit", value)
+	}
+	amount, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", value, err)
+	}
+	if amount > uint64(math.MaxInt64/unit) {
+		// Up to 8 digits of hours exceed time.Duration, saturate instead of wrapping around
+		return time.Duration(math.MaxInt64), nil
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// parseDeadlineHeader parses RFC 3339 timestamps or unix milliseconds
+func parseDeadlineHeader(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis), nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
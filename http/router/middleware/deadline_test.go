@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DeadlineSuite struct {
+	suite.Suite
+}
+
+func TestDeadlineSuite(t *testing.T) {
+	suite.Run(t, new(DeadlineSuite))
+}
+
+func (suite *DeadlineSuite) TestItNarrowsRequestDeadline() {
+	testCases := map[string]struct {
+		options          DeadlineOptions
+		headers          map[string]string
+		expectedDeadline bool
+		expectedBudget   time.Duration
+	}{
+		"no headers": {
+			headers: map[string]string{},
+		},
+		"grpc timeout style": {
+			headers:          map[string]string{"X-Request-Timeout": "500m"},
+			expectedDeadline: true,
+			expectedBudget:   500 * time.Millisecond,
+		},
+		"grpc minutes": {
+			headers:          map[string]string{"X-Request-Timeout": "2M"},
+			expectedDeadline: true,
+			expectedBudget:   2 * time.Minute,
+		},
+		"go duration ignored": {
+			headers: map[string]string{"X-Request-Timeout": "2s"},
+		},
+		"invalid timeout ignored": {
+			headers: map[string]string{"X-Request-Timeout": "soon"},
+		},
+		"absolute unix millis": {
+			headers: map[string]string{
+				"X-Request-Deadline": strconv.FormatInt(
+					time.Now().Add(3*time.Second).UnixMilli(), 10,
+				),
+			},
+			expectedDeadline: true,
+			expectedBudget:   3 * time.Second,
+		},
+		"earliest deadline wins": {
+			headers: map[string]string{
+				"X-Request-Timeout":  "5S",
+				"X-Request-Deadline": time.Now().Add(time.Second).Format(time.RFC3339Nano),
+			},
+			expectedDeadline: true,
+			expectedBudget:   time.Second,
+		},
+		"bounded by max timeout": {
+			options:          DeadlineOptions{MaxTimeout: time.Second},
+			headers:          map[string]string{"X-Request-Timeout": "1H"},
+			expectedDeadline: true,
+			expectedBudget:   time.Second,
+		},
+		"overflowing timeout bounded by max timeout": {
+			options:          DeadlineOptions{MaxTimeout: time.Second},
+			headers:          map[string]string{"X-Request-Timeout": "99999999H"},
+			expectedDeadline: true,
+			expectedBudget:   time.Second,
+		},
+		"custom header": {
+			options:          DeadlineOptions{TimeoutHeader: "Grpc-Timeout"},
+			headers:          map[string]string{"Grpc-Timeout": "100m"},
+			expectedDeadline: true,
+			expectedBudget:   100 * time.Millisecond,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				var ctx context.Context
+				handler := NewDeadlinePropagation(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { ctx = r.Context() }),
+					nil,
+					testCase.options,
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				for key, value := range testCase.headers {
+					request.Header.Set(key, value)
+				}
+				start := time.Now()
+
+				handler.ServeHTTP(httptest.NewRecorder(), request)
+
+				deadline, ok := ctx.Deadline()
+				suite.Equal(testCase.expectedDeadline, ok)
+				if testCase.expectedDeadline {
+					suite.InDelta(
+						testCase.expectedBudget, deadline.Sub(start), float64(50*time.Millisecond),
+					)
+				}
+			},
+		)
+	}
+}
+
+func (suite *DeadlineSuite) TestItRejectsExpiredDeadlines() {
+	handlerCalled := false
+	handler := NewDeadlinePropagation(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { handlerCalled = true }),
+		nil,
+		DeadlineOptions{},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Request-Deadline", time.Now().Add(-time.Second).Format(time.RFC3339))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.False(handlerCalled)
+	suite.Equal(http.StatusGatewayTimeout, recorder.Code)
+	suite.Equal("client deadline exceeded", recorder.Body.String())
+}
+
+func (suite *DeadlineSuite) TestItCombinesWithTimeoutMiddleware() {
+	slowHandler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	)
+	handler := NewDeadlinePropagation(
+		NewTimeoutMiddleware(slowHandler, nil, TimeoutOptions{Timeout: 5 * time.Second}),
+		nil,
+		DeadlineOptions{},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Request-Timeout", "30m")
+	recorder := httptest.NewRecorder()
+	start := time.Now()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.Less(time.Since(start), time.Second)
+	suite.Equal(http.StatusRequestTimeout, recorder.Code)
+}
+
+func (suite *DeadlineSuite) TestItParsesGrpcTimeouts() {
+	testCases := map[string]struct {
+		value           string
+		expectedTimeout time.Duration
+		expectedError   bool
+	}{
+		"hours":             {value: "1H", expectedTimeout: time.Hour},
+		"minutes":           {value: "10M", expectedTimeout: 10 * time.Minute},
+		"seconds":           {value: "3S", expectedTimeout: 3 * time.Second},
+		"milliseconds":      {value: "10m", expectedTimeout: 10 * time.Millisecond},
+		"microseconds":      {value: "7u", expectedTimeout: 7 * time.Microsecond},
+		"nanoseconds":       {value: "99999999n", expectedTimeout: 99999999 * time.Nanosecond},
+		"overflowing hours": {value: "99999999H", expectedTimeout: time.Duration(math.MaxInt64)},
+		"go duration":       {value: "1.5s", expectedError: true},
+		"lowercase unit":    {value: "10h", expectedError: true},
+		"too many digits":   {value: "123456789S", expectedError: true},
+		"missing unit":      {value: "10", expectedError: true},
+		"negative":          {value: "-5S", expectedError: true},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				timeout, err := parseTimeoutHeader(testCase.value)
+
+				if testCase.expectedError {
+					suite.Error(err)
+					return
+				}
+				suite.Require().NoError(err)
+				suite.Equal(testCase.expectedTimeout, timeout)
+			},
+		)
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// Enveloper enables the response envelope (see httpInternal.Envelope) for the requests it
+// serves, so every JSON and error response built with the request is wrapped the same
+// way, e.g. for all the routes of an API mux
+type Enveloper struct {
+	next    http.Handler
+	options EnvelopeOptions
+}
+
+// EnvelopeOptions configures the response envelope
+//
+// Meta: members added to the meta object of every enveloped response
+type EnvelopeOptions struct {
+	Meta map[string]interface{}
+}
+
+// NewEnveloper creates new response envelope middleware
+func NewEnveloper(next http.Handler, options EnvelopeOptions) *Enveloper {
+	return &Enveloper{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (e *Enveloper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := httpInternal.WithEnvelope(r.Context(), e.options.Meta)
+	e.next.ServeHTTP(w, r.WithContext(ctx))
+}
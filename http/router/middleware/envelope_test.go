@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/stretchr/testify/suite"
+)
+
+type EnvelopeSuite struct {
+	suite.Suite
+}
+
+func TestEnvelopeSuite(t *testing.T) {
+	suite.Run(t, new(EnvelopeSuite))
+}
+
+func (suite *EnvelopeSuite) TestItEnvelopesTheResponsesOfTheRequests() {
+	testCases := map[string]struct {
+		handler      CustomHandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		"success": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return httpInternal.NewResponseBuilder(w).WithRequest(r).JSON().Data([]int{1}).Send()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[1],"error":null,"meta":{"version":"v2"}}`,
+		},
+		"error": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return errors.New("boom")
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"data":null,"error":{"message":"boom","status":500},"meta":{"version":"v2"}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewEnveloper(
+					NewErrorhandler(testCase.handler, context.Background(), nil, ErrorhandlerOptions{}),
+					EnvelopeOptions{Meta: map[string]interface{}{"version": "v2"}},
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+				suite.JSONEq(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
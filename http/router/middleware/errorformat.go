@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ErrorFormat selects how middlewares render error responses
+type ErrorFormat int
+
+const (
+	// ErrorFormatText renders errors in the httpInternal.DefaultErrorResponse format,
+	// plain text unless configured otherwise (default)
+	ErrorFormatText ErrorFormat = iota
+	// ErrorFormatJSON always renders errors as JSON
+	ErrorFormatJSON
+	// ErrorFormatNegotiate picks problem+json, JSON or text from the Accept header
+	ErrorFormatNegotiate
+	// ErrorFormatProblem always renders errors as RFC 7807 problem details
+	ErrorFormatProblem
+	// ErrorFormatJSONAPI always renders errors as a JSON:API errors document
+	ErrorFormatJSONAPI
+)
+
+// resolve returns the concrete format to use for the request
+func (format ErrorFormat) resolve(r *http.Request) ErrorFormat {
+	if format != ErrorFormatNegotiate {
+		return format
+	}
+
+	accepted := acceptedJSONTypes(r)
+	switch {
+	case accepted["application/problem+json"]:
+		return ErrorFormatProblem
+	case len(accepted) > 0:
+		return ErrorFormatJSON
+	default:
+		return ErrorFormatText
+	}
+}
+
+// apply configures the error response builder for the request
+func (format ErrorFormat) apply(
+	builder *httpInternal.ErrorResponseBuilder,
+	r *http.Request,
+) *httpInternal.ErrorResponseBuilder {
+	switch format.resolve(r) {
+	case ErrorFormatText:
+		// Only a negotiated text format overrides httpInternal.DefaultErrorResponse
+		if format == ErrorFormatNegotiate {
+			builder.AsText()
+		}
+	case ErrorFormatJSON:
+		builder.AsJSON()
+	case ErrorFormatProblem:
+		builder.AsProblem().WithInstance(r.URL.Path)
+	case ErrorFormatJSONAPI:
+		builder.AsJSONAPI()
+	}
+	return builder
+}
+
+// acceptedJSONTypes returns the JSON media types (application/json or any +json suffix)
+// listed in the Accept header lines with a non-zero quality
+func acceptedJSONTypes(r *http.Request) map[string]bool {
+	accepted := make(map[string]bool)
+	for _, mediaRange := range httpInternal.ParseAccept(r.Header.Values("Accept")) {
+		mediaType := mediaRange.MediaType
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			continue
+		}
+		if mediaRange.Quality > 0 {
+			accepted[mediaType] = true
+		}
+	}
+	return accepted
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/httperr"
+)
+
+// CustomHandler is a handler that returns an error instead of writing it,
+// leaving the error response to the Errorhandler middleware
+type CustomHandler interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request) error
+}
+
+// CustomHandlerFunc adapts an error-returning function to the CustomHandler interface
+type CustomHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f(w, r)
+func (f CustomHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	return f(w, r)
+}
+
+// errorHolder carries the error reported by a standard http.Handler through the request context
+type errorHolder struct {
+	mu  sync.Mutex
+	err error
+}
+
+type errorHolderContextKey struct{}
+
+// SetError reports an error from a standard http.Handler adapted with FromHTTPHandler.
+// The Errorhandler renders it once the handler returns; the handler must not write a
+// response itself. Returns false when the request is not served through the adapter.
+func SetError(r *http.Request, err error) bool {
+	holder, ok := r.Context().Value(errorHolderContextKey{}).(*errorHolder)
+	if !ok {
+		return false
+	}
+
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	holder.err = err
+	return true
+}
+
+// ErrorFromContext returns the error reported with SetError, if any
+func ErrorFromContext(ctx context.Context) error {
+	holder, ok := ctx.Value(errorHolderContextKey{}).(*errorHolder)
+	if !ok {
+		return nil
+	}
+
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	return holder.err
+}
+
+// FromHTTPHandler adapts a standard http.Handler to CustomHandler. The handler reports
+// errors with SetError instead of returning them, which lets existing handlers use
+// the Errorhandler without adopting the CustomHandler signature.
+func FromHTTPHandler(next http.Handler) CustomHandler {
+	return CustomHandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) error {
+			ctx := context.WithValue(r.Context(), errorHolderContextKey{}, &errorHolder{})
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+			return ErrorFromContext(ctx)
+		},
+	)
+}
+
+// RecoverPanics wraps a CustomHandler so panics are returned as *httperr.PanicError
+// carrying the recovered value and stack, letting the Errorhandler render panics and
+// returned errors through the same code path. http.ErrAbortHandler is re-panicked,
+// as the server relies on it to abort responses silently.
+func RecoverPanics(next CustomHandler) CustomHandler {
+	return CustomHandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) (err error) {
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					if rvr == http.ErrAbortHandler {
+						panic(rvr)
+					}
+					err = &httperr.PanicError{Value: rvr, Stack: captureStack(0)}
+				}
+			}()
+			return next.ServeHTTP(w, r)
+		},
+	)
+}
+
+// Errorhandler adapts a CustomHandler to http.Handler, rendering returned errors through
+// the shared ErrorResponseBuilder (status classification, logging and body format)
+type Errorhandler struct {
+	next    CustomHandler
+	ctx     context.Context
+	logger  *slog.Logger
+	options ErrorhandlerOptions
+}
+
+// ErrorhandlerOptions configures the error handler behavior
+//
+// Format: package default (text unless set), JSON, problem+json, or negotiated from the Accept header
+// ErrorCategories: categories used to map errors to status codes
+// DevMode: include the error chain, causes and panic stacks in responses (development only)
+// Formatter: custom body formatter taking precedence over Format
+// Translator: translates error messages into the request locale (see LanguageNegotiator)
+type ErrorhandlerOptions struct {
+	Format          ErrorFormat
+	ErrorCategories []*httperr.ErrorCategory
+	DevMode         bool
+	Formatter       httpInternal.ErrorFormatter
+	Translator      httpInternal.Translator
+}
+
+type errorCategoriesContextKey struct{}
+
+// WithRequestErrorCategories returns a context carrying error categories checked before
+// the middleware ones when rendering errors of the request served with it (used for
+// per-route categories). Categories already in the context are kept after the new ones.
+func WithRequestErrorCategories(
+	ctx context.Context,
+	categories ...*httperr.ErrorCategory,
+) context.Context {
+	existing := RequestErrorCategoriesFromContext(ctx)
+	merged := make([]*httperr.ErrorCategory, 0, len(categories)+len(existing))
+	merged = append(merged, categories...)
+	merged = append(merged, existing...)
+	return context.WithValue(ctx, errorCategoriesContextKey{}, merged)
+}
+
+// RequestErrorCategoriesFromContext returns the per-request error categories, if any
+func RequestErrorCategoriesFromContext(ctx context.Context) []*httperr.ErrorCategory {
+	categories, _ := ctx.Value(errorCategoriesContextKey{}).([]*httperr.ErrorCategory)
+	return categories
+}
+
+// NewErrorhandler creates new error handling middleware
+func NewErrorhandler(
+	next CustomHandler,
+	ctx context.Context,
+	logger *slog.Logger,
+	options ErrorhandlerOptions,
+) *Errorhandler {
+	return &Errorhandler{
+		next:    next,
+		ctx:     ctx,
+		logger:  logger,
+		options: options,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (eh *Errorhandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := httpInternal.AcquireResponseWriter(w).WithLogger(eh.logger)
+	defer rw.Release()
+	err := eh.next.ServeHTTP(rw, r)
+	if err == nil {
+		return
+	}
+
+	// A started response cannot be replaced, the error can only be logged
+	if rw.WroteHeader() {
+		eh.logStartedResponseError(r, rw, err)
+		return
+	}
+
+	builder := httpInternal.NewResponseBuilder(w).
+		Error().
+		WithError(err).
+		WithErrorCategories(RequestErrorCategoriesFromContext(r.Context())...).
+		WithErrorCategories(eh.options.ErrorCategories...).
+		WithLogger(eh.logger).
+		WithContext(eh.ctx).
+		WithRequest(r).
+		WithDevMode(eh.options.DevMode).
+		WithFormatter(eh.options.Formatter).
+		WithTranslator(eh.options.Translator)
+
+	_ = eh.options.Format.apply(builder, r).Send()
+}
+
+// logStartedResponseError logs an error returned after the handler started the response
+func (eh *Errorhandler) logStartedResponseError(
+	r *http.Request,
+	rw *httpInternal.ResponseWriter,
+	err error,
+) {
+	if eh.logger == nil {
+		// Fallback to stderr if no logger available
+		_, _ = fmt.Fprintf(
+			os.Stderr,
+			"Error after the response started: %+v (status=%d method=%s path=%s)\n",
+			err, rw.StatusCode(), r.Method, r.URL.Path,
+		)
+		return
+	}
+
+	eh.logger.LogAttrs(
+		r.Context(),
+		slog.LevelError,
+		"HTTP Request Error after the response started",
+		slog.String("Error", err.Error()),
+		slog.Int("StatusCode", rw.StatusCode()),
+		slog.String("Method", r.Method),
+		slog.String("Path", r.URL.Path),
+	)
+}
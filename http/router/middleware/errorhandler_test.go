@@ -0,0 +1,511 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorhandlerSuite struct {
+	suite.Suite
+}
+
+func TestErrorhandlerSuite(t *testing.T) {
+	suite.Run(t, new(ErrorhandlerSuite))
+}
+
+var errTestNotFound = errors.New("user not found")
+
+func (suite *ErrorhandlerSuite) newErrorhandler(
+	err error,
+	logger *slog.Logger,
+	options ErrorhandlerOptions,
+) *Errorhandler {
+	return NewErrorhandler(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				return err
+			},
+		),
+		context.Background(),
+		logger,
+		options,
+	)
+}
+
+func (suite *ErrorhandlerSuite) TestItPassesThroughSuccessfulResponses() {
+	handler := NewErrorhandler(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("created"))
+				return nil
+			},
+		),
+		context.Background(),
+		nil,
+		ErrorhandlerOptions{},
+	)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal("created", recorder.Body.String())
+}
+
+func (suite *ErrorhandlerSuite) TestItCanRenderErrorsInConfiguredFormat() {
+	category := httperr.NewErrorCategory(http.StatusNotFound)
+	category.AddSentinelError(errTestNotFound)
+
+	testCases := map[string]struct {
+		format              ErrorFormat
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		"text": {
+			format:              ErrorFormatText,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "user not found",
+		},
+		"json": {
+			format:              ErrorFormatJSON,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"user not found","status":404}`,
+		},
+		"problem": {
+			format:              ErrorFormatProblem,
+			expectedContentType: "application/problem+json",
+			expectedBody: `{"type":"about:blank","title":"Not Found","status":404,` +
+				`"detail":"user not found","instance":"/users/7"}`,
+		},
+		"negotiated problem": {
+			format:              ErrorFormatNegotiate,
+			accept:              "application/problem+json, application/json;q=0.8",
+			expectedContentType: "application/problem+json",
+			expectedBody: `{"type":"about:blank","title":"Not Found","status":404,` +
+				`"detail":"user not found","instance":"/users/7"}`,
+		},
+		"json api": {
+			format:              ErrorFormatJSONAPI,
+			expectedContentType: "application/vnd.api+json",
+			expectedBody:        `{"errors":[{"status":"404","title":"Not Found","detail":"user not found"}]}`,
+		},
+		"negotiated json": {
+			format:              ErrorFormatNegotiate,
+			accept:              "application/json",
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"user not found","status":404}`,
+		},
+		"negotiated text": {
+			format:              ErrorFormatNegotiate,
+			accept:              "text/html",
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "user not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := suite.newErrorhandler(
+					errTestNotFound,
+					nil,
+					ErrorhandlerOptions{
+						Format:          testCase.format,
+						ErrorCategories: []*httperr.ErrorCategory{category.DisableLogging()},
+					},
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+				request.Header.Set("Accept", testCase.accept)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(http.StatusNotFound, recorder.Code)
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+				if testCase.expectedContentType == "text/plain; charset=utf-8" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				} else {
+					suite.JSONEq(testCase.expectedBody, recorder.Body.String())
+				}
+			},
+		)
+	}
+}
+
+func (suite *ErrorhandlerSuite) TestItNegotiatesTheErrorFormat() {
+	testCases := map[string]struct {
+		accept         []string
+		expectedFormat ErrorFormat
+	}{
+		"no accept header":  {expectedFormat: ErrorFormatText},
+		"problem preferred": {accept: []string{"application/problem+json"}, expectedFormat: ErrorFormatProblem},
+		"json":              {accept: []string{"text/html, application/json"}, expectedFormat: ErrorFormatJSON},
+		"json excluded": {
+			accept:         []string{"application/json;q=0, text/html"},
+			expectedFormat: ErrorFormatText,
+		},
+		"problem excluded": {
+			accept:         []string{"application/problem+json; q=0, application/json"},
+			expectedFormat: ErrorFormatJSON,
+		},
+		"second header line": {accept: []string{"text/html", "application/json"}, expectedFormat: ErrorFormatJSON},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+				for _, accept := range testCase.accept {
+					request.Header.Add("Accept", accept)
+				}
+
+				suite.Equal(testCase.expectedFormat, ErrorFormatNegotiate.resolve(request))
+			},
+		)
+	}
+}
+
+func (suite *ErrorhandlerSuite) TestItCanRenderErrorsInTheDefaultFormat() {
+	defaults := httpInternal.DefaultErrorResponse
+	defer func() { httpInternal.DefaultErrorResponse = defaults }()
+	category := httperr.NewErrorCategory(http.StatusNotFound).DisableLogging()
+	category.AddSentinelError(errTestNotFound)
+	httpInternal.DefaultErrorResponse = httpInternal.ErrorResponseDefaults{
+		Format:     httpInternal.ErrorBodyJSON,
+		Categories: []*httperr.ErrorCategory{category},
+	}
+
+	testCases := map[string]struct {
+		format              ErrorFormat
+		expectedContentType string
+	}{
+		"default": {
+			format:              ErrorFormatText,
+			expectedContentType: "application/json",
+		},
+		"negotiated text": {
+			format:              ErrorFormatNegotiate,
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := suite.newErrorhandler(errTestNotFound, nil, ErrorhandlerOptions{Format: testCase.format})
+				request := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+				request.Header.Set("Accept", "text/html")
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(http.StatusNotFound, recorder.Code)
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+			},
+		)
+	}
+}
+
+func (suite *ErrorhandlerSuite) TestItLogsUnclassifiedErrors() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := suite.newErrorhandler(errors.New("boom"), logger, ErrorhandlerOptions{})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(outputBuffer.String(), "HTTP Request Error")
+	suite.Contains(outputBuffer.String(), "boom")
+}
+
+func (suite *ErrorhandlerSuite) TestItOnlyLogsErrorsAfterTheResponseStarted() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := NewErrorhandler(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				_, _ = w.Write([]byte("partial"))
+				return errors.New("stream broken")
+			},
+		),
+		context.Background(),
+		logger,
+		ErrorhandlerOptions{},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("partial", recorder.Body.String())
+	suite.Contains(outputBuffer.String(), "HTTP Request Error after the response started")
+	suite.Contains(outputBuffer.String(), "stream broken")
+	suite.NotContains(outputBuffer.String(), "Superfluous WriteHeader call")
+}
+
+func (suite *ErrorhandlerSuite) TestItFallsBackToStderrAfterTheResponseStarted() {
+	reader, writer, err := os.Pipe()
+	suite.Require().NoError(err)
+	stderr := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = stderr }()
+
+	handler := NewErrorhandler(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				_, _ = w.Write([]byte("partial"))
+				return errors.New("stream broken")
+			},
+		),
+		context.Background(),
+		nil,
+		ErrorhandlerOptions{},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/export", nil))
+	suite.Require().NoError(writer.Close())
+	output, err := io.ReadAll(reader)
+	suite.Require().NoError(err)
+
+	suite.Equal("partial", recorder.Body.String())
+	suite.Contains(string(output), "Error after the response started: stream broken")
+	suite.Contains(string(output), "path=/export")
+}
+
+func (suite *ErrorhandlerSuite) TestItCorrelatesErrorsWithTheRequestID() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := NewRequestID(
+		suite.newErrorhandler(errors.New("boom"), logger, ErrorhandlerOptions{Format: ErrorFormatProblem}),
+		RequestIDOptions{Generator: func() string { return "req-42" }},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal("req-42", recorder.Header().Get(httpInternal.RequestIDHeader))
+	suite.Contains(recorder.Body.String(), `"requestId":"req-42"`)
+	suite.Contains(outputBuffer.String(), `"RequestID":"req-42"`)
+}
+
+func (suite *ErrorhandlerSuite) TestItEmitsMatchedCategoryHeaders() {
+	errOverloaded := errors.New("overloaded")
+	category := httperr.NewErrorCategory(http.StatusServiceUnavailable).
+		WithRetryAfter(30 * time.Second).
+		DisableLogging()
+	category.AddSentinelError(errOverloaded)
+
+	handler := suite.newErrorhandler(
+		errOverloaded,
+		nil,
+		ErrorhandlerOptions{ErrorCategories: []*httperr.ErrorCategory{category}},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.Equal("30", recorder.Header().Get("Retry-After"))
+}
+
+func (suite *ErrorhandlerSuite) TestItRunsCategoryHooksOnMatch() {
+	type contextKey string
+	ctx := context.WithValue(context.Background(), contextKey("app"), "hooks")
+
+	var (
+		matchedCount int
+		matchedErr   error
+		matchedPath  string
+		matchedCtx   context.Context
+	)
+	category := httperr.NewErrorCategory(http.StatusNotFound).
+		DisableLogging().
+		OnMatch(
+			func(ctx context.Context, err error, r *http.Request) {
+				matchedCount++
+				matchedErr = err
+				matchedPath = r.URL.Path
+				matchedCtx = ctx
+			},
+		)
+	category.AddSentinelError(errTestNotFound)
+
+	handler := NewErrorhandler(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				if r.URL.Path == "/missing" {
+					return errTestNotFound
+				}
+				return errors.New("unrelated")
+			},
+		),
+		ctx,
+		nil,
+		ErrorhandlerOptions{ErrorCategories: []*httperr.ErrorCategory{category}},
+	)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/other", nil))
+
+	suite.Equal(1, matchedCount)
+	suite.ErrorIs(matchedErr, errTestNotFound)
+	suite.Equal("/missing", matchedPath)
+	suite.Equal("hooks", matchedCtx.Value(contextKey("app")))
+}
+
+func (suite *ErrorhandlerSuite) TestItCanExposeErrorChainInDevMode() {
+	handler := suite.newErrorhandler(
+		fmt.Errorf("saving order: %w", errTestNotFound),
+		slog.New(slog.NewTextHandler(new(bytes.Buffer), nil)),
+		ErrorhandlerOptions{Format: ErrorFormatJSON, DevMode: true},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.JSONEq(
+		`{"error":"saving order: user not found","status":500,"debug":{`+
+			`"error":"saving order: user not found","causes":["user not found"]}}`,
+		recorder.Body.String(),
+	)
+}
+
+func (suite *ErrorhandlerSuite) TestItRendersErrorsReportedThroughContext() {
+	category := httperr.NewErrorCategory(http.StatusNotFound).DisableLogging()
+	category.AddSentinelError(errTestNotFound)
+
+	var reported bool
+	standardHandler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				reported = SetError(r, errTestNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("found"))
+		},
+	)
+
+	handler := NewErrorhandler(
+		FromHTTPHandler(standardHandler),
+		context.Background(),
+		nil,
+		ErrorhandlerOptions{
+			Format:          ErrorFormatJSON,
+			ErrorCategories: []*httperr.ErrorCategory{category},
+		},
+	)
+
+	missingRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(missingRecorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	suite.True(reported)
+	suite.Equal(http.StatusNotFound, missingRecorder.Code)
+	suite.JSONEq(`{"error":"user not found","status":404}`, missingRecorder.Body.String())
+
+	foundRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(foundRecorder, httptest.NewRequest(http.MethodGet, "/found", nil))
+	suite.Equal(http.StatusOK, foundRecorder.Code)
+	suite.Equal("found", foundRecorder.Body.String())
+}
+
+func (suite *ErrorhandlerSuite) TestSetErrorRequiresAdapter() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	suite.False(SetError(request, errTestNotFound))
+	suite.Nil(ErrorFromContext(request.Context()))
+}
+
+func (suite *ErrorhandlerSuite) TestItRendersPanicsAsReturnedErrors() {
+	category := httperr.NewErrorCategory(http.StatusNotFound)
+	category.AddSentinelError(errTestNotFound)
+
+	handler := NewErrorhandler(
+		RecoverPanics(
+			CustomHandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) error {
+					panic(fmt.Errorf("loading user: %w", errTestNotFound))
+				},
+			),
+		),
+		context.Background(),
+		slog.New(slog.NewTextHandler(new(bytes.Buffer), nil)),
+		ErrorhandlerOptions{
+			ErrorCategories: []*httperr.ErrorCategory{category},
+			DevMode:         true,
+		},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Contains(recorder.Body.String(), "panic: loading user: user not found")
+	suite.Contains(recorder.Body.String(), "errorhandler_test.go")
+}
+
+func (suite *ErrorhandlerSuite) TestRecoverPanicsReturnsPanicError() {
+	handler := RecoverPanics(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				panic("boom")
+			},
+		),
+	)
+
+	err := handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var panicErr *httperr.PanicError
+	suite.Require().ErrorAs(err, &panicErr)
+	suite.Equal("boom", panicErr.Value)
+	suite.NotEmpty(panicErr.Stack)
+}
+
+func (suite *ErrorhandlerSuite) TestRecoverPanicsKeepsAbortHandlerPanics() {
+	handler := RecoverPanics(
+		CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				panic(http.ErrAbortHandler)
+			},
+		),
+	)
+
+	suite.PanicsWithValue(
+		http.ErrAbortHandler, func() {
+			_ = handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		},
+	)
+}
+
+func (suite *ErrorhandlerSuite) TestItCanUseCustomFormatter() {
+	formatter := httpInternal.ErrorFormatterFunc(
+		func(ctx context.Context, err error, statusCode int) ([]byte, string, error) {
+			return []byte(fmt.Sprintf("<error code=%q>%s</error>", fmt.Sprint(statusCode), err)),
+				"application/xml", nil
+		},
+	)
+
+	handler := suite.newErrorhandler(
+		errTestNotFound,
+		nil,
+		ErrorhandlerOptions{Format: ErrorFormatJSON, Formatter: formatter},
+	)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal("application/xml", recorder.Header().Get("Content-Type"))
+	suite.Equal(`<error code="500">user not found</error>`, recorder.Body.String())
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/golibry/go-http/http/metrics"
+)
+
+// InFlight tracks the requests being served, as an autoscaling signal or to shed load
+// (see Current). The count is reported as the metrics.RequestsInFlight gauge and, per
+// route, as the metrics.RouteRequestsInFlight gauge. Route labels are only known when
+// the middleware runs inside the router (route or group chains); outside, requests are
+// labeled with an empty route.
+type InFlight struct {
+	next     http.Handler
+	options  InFlightOptions
+	inFlight atomic.Int64
+}
+
+// InFlightOptions configures the in-flight middleware
+//
+// Metrics: recorder the gauges are reported to (optional)
+// PerRoute: also report the per-route gauge
+type InFlightOptions struct {
+	Metrics  metrics.Recorder
+	PerRoute bool
+}
+
+// NewInFlight creates new in-flight tracking middleware
+func NewInFlight(next http.Handler, options InFlightOptions) *InFlight {
+	return &InFlight{next: next, options: options}
+}
+
+// Current returns the number of requests being served by this middleware
+func (inf *InFlight) Current() int64 {
+	return inf.inFlight.Load()
+}
+
+// ServeHTTP implements the middleware logic
+func (inf *InFlight) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	inf.inFlight.Add(1)
+	defer inf.inFlight.Add(-1)
+
+	if inf.options.Metrics == nil {
+		inf.next.ServeHTTP(w, r)
+		return
+	}
+
+	inf.track(r, 1)
+	defer inf.track(r, -1)
+	inf.next.ServeHTTP(w, r)
+}
+
+// track moves the gauges by delta
+func (inf *InFlight) track(r *http.Request, delta float64) {
+	inf.options.Metrics.AddGauge(metrics.RequestsInFlight, delta, nil)
+	if inf.options.PerRoute {
+		inf.options.Metrics.AddGauge(
+			metrics.RouteRequestsInFlight, delta, metrics.Labels{"route": r.Pattern},
+		)
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type InFlightSuite struct {
+	suite.Suite
+}
+
+func TestInFlightSuite(t *testing.T) {
+	suite.Run(t, new(InFlightSuite))
+}
+
+func (suite *InFlightSuite) TestItCanTrackInFlightRequests() {
+	route := metrics.Labels{"route": "GET /users/{id}"}
+	testCases := map[string]struct {
+		options               InFlightOptions
+		expectedRouteInFlight float64
+	}{
+		"without metrics": {},
+		"global gauge": {
+			options: InFlightOptions{Metrics: metrics.NewMemory()},
+		},
+		"per route gauge": {
+			options:               InFlightOptions{Metrics: metrics.NewMemory(), PerRoute: true},
+			expectedRouteInFlight: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				var middleware *InFlight
+				middleware = NewInFlight(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							suite.Equal(int64(1), middleware.Current())
+							if recorder, ok := testCase.options.Metrics.(*metrics.Memory); ok {
+								suite.Equal(1.0, recorder.Value(metrics.RequestsInFlight, nil))
+								suite.Equal(
+									testCase.expectedRouteInFlight,
+									recorder.Value(metrics.RouteRequestsInFlight, route),
+								)
+							}
+						},
+					),
+					testCase.options,
+				)
+				request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+				request.Pattern = "GET /users/{id}"
+
+				middleware.ServeHTTP(httptest.NewRecorder(), request)
+
+				suite.Zero(middleware.Current())
+				if recorder, ok := testCase.options.Metrics.(*metrics.Memory); ok {
+					suite.Zero(recorder.Value(metrics.RequestsInFlight, nil))
+					suite.Zero(recorder.Value(metrics.RouteRequestsInFlight, route))
+				}
+			},
+		)
+	}
+}
+
+func (suite *InFlightSuite) TestItReleasesRequestsThatPanic() {
+	recorder := metrics.NewMemory()
+	middleware := NewInFlight(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { panic("boom") }),
+		InFlightOptions{Metrics: recorder},
+	)
+
+	suite.Panics(
+		func() {
+			middleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		},
+	)
+	suite.Zero(middleware.Current())
+	suite.Zero(recorder.Value(metrics.RequestsInFlight, nil))
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// LanguageNegotiator selects the locale of the request from the Accept-Language header
+// among the supported languages and stores it in the request context (see
+// httpInternal.LocaleFromContext), where the error pipeline picks it up to translate
+// error messages
+type LanguageNegotiator struct {
+	next    http.Handler
+	options LanguageOptions
+}
+
+// LanguageOptions configures the language negotiation
+//
+// Supported: language tags the application is translated into, in order of preference
+// Default: locale used when nothing matches (default: the first supported language)
+type LanguageOptions struct {
+	Supported []string
+	Default   string
+}
+
+// NewLanguageNegotiator creates new language negotiation middleware
+func NewLanguageNegotiator(next http.Handler, options LanguageOptions) *LanguageNegotiator {
+	if options.Default == "" && len(options.Supported) > 0 {
+		options.Default = options.Supported[0]
+	}
+	return &LanguageNegotiator{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (ln *LanguageNegotiator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The response depends on the Accept-Language header, shared caches must know it
+	w.Header().Add("Vary", "Accept-Language")
+
+	locale, ok := httpInternal.NegotiateLanguage(r, ln.options.Supported...)
+	if !ok {
+		locale = ln.options.Default
+	}
+	if locale == "" {
+		ln.next.ServeHTTP(w, r)
+		return
+	}
+
+	ln.next.ServeHTTP(w, r.WithContext(httpInternal.WithLocale(r.Context(), locale)))
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/stretchr/testify/suite"
+)
+
+type LanguageSuite struct {
+	suite.Suite
+}
+
+func TestLanguageSuite(t *testing.T) {
+	suite.Run(t, new(LanguageSuite))
+}
+
+func (suite *LanguageSuite) TestItNegotiatesTheRequestLocale() {
+	testCases := map[string]struct {
+		options        LanguageOptions
+		acceptLanguage string
+		expectedLocale string
+	}{
+		"best match":        {LanguageOptions{Supported: []string{"en", "fr"}}, "fr-CA, en;q=0.5", "fr"},
+		"default":           {LanguageOptions{Supported: []string{"en", "fr"}, Default: "fr"}, "de", "fr"},
+		"first supported":   {LanguageOptions{Supported: []string{"en", "fr"}}, "", "en"},
+		"nothing supported": {LanguageOptions{}, "fr", ""},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				locale := "unset"
+				handler := NewLanguageNegotiator(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							locale = httpInternal.LocaleFromContext(r.Context())
+						},
+					),
+					testCase.options,
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("Accept-Language", testCase.acceptLanguage)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedLocale, locale)
+				suite.Equal("Accept-Language", recorder.Header().Get("Vary"))
+			},
+		)
+	}
+}
+
+func (suite *LanguageSuite) TestItTranslatesErrorsIntoTheRequestLocale() {
+	category := httperr.NewErrorCategory(http.StatusNotFound).WithCode("user.not_found").DisableLogging()
+	category.AddSentinelError(errTestNotFound)
+
+	handler := NewLanguageNegotiator(
+		NewErrorhandler(
+			CustomHandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) error {
+					return errTestNotFound
+				},
+			),
+			context.Background(),
+			nil,
+			ErrorhandlerOptions{
+				Format:          ErrorFormatJSON,
+				ErrorCategories: []*httperr.ErrorCategory{category},
+				Translator: httpInternal.MessageCatalog{
+					"fr": {"user.not_found": "utilisateur introuvable"},
+				},
+			},
+		),
+		LanguageOptions{Supported: []string{"en", "fr"}},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	request.Header.Set("Accept-Language", "fr-FR")
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal("fr", recorder.Header().Get("Content-Language"))
+	suite.JSONEq(
+		`{"error":"utilisateur introuvable","status":404,"code":"user.not_found"}`,
+		recorder.Body.String(),
+	)
+
+	request.Header.Set("Accept-Language", "en")
+	recorder = httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	suite.Empty(recorder.Header().Get("Content-Language"))
+	suite.JSONEq(`{"error":"user not found","status":404,"code":"user.not_found"}`, recorder.Body.String())
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PassthroughSuite struct {
+	suite.Suite
+}
+
+func TestPassthroughSuite(t *testing.T) {
+	suite.Run(t, new(PassthroughSuite))
+}
+
+// capableRecorder implements the optional writer interfaces, recording their use
+type capableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	pushed   string
+	readFrom bool
+	deadline time.Time
+}
+
+func (cr *capableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	cr.hijacked = true
+	return nil, nil, nil
+}
+
+func (cr *capableRecorder) Push(target string, _ *http.PushOptions) error {
+	cr.pushed = target
+	return nil
+}
+
+func (cr *capableRecorder) SetWriteDeadline(deadline time.Time) error {
+	cr.deadline = deadline
+	return nil
+}
+
+func (cr *capableRecorder) ReadFrom(r io.Reader) (int64, error) {
+	cr.readFrom = true
+	return io.Copy(cr.ResponseRecorder, r)
+}
+
+func (suite *PassthroughSuite) TestItExposesTheOptionalWriterInterfaces() {
+	testCases := map[string]struct {
+		wrap           func(next http.Handler) http.Handler
+		expectedHeader string
+	}{
+		"access logger": {
+			wrap: func(next http.Handler) http.Handler {
+				return NewHTTPAccessLogger(next, slog.New(slog.DiscardHandler), AccessLogOptions{})
+			},
+		},
+		"budget reporter": {
+			wrap: func(next http.Handler) http.Handler {
+				return NewBudgetReporter(next, BudgetOptions{})
+			},
+			expectedHeader: ServerTimingHeader,
+		},
+		"cache control": {
+			wrap: func(next http.Handler) http.Handler {
+				return NewCacheControl(
+					next,
+					CacheControlOptions{Rules: []CacheRule{{Policy: CachePolicy{NoStore: true}}}},
+				)
+			},
+			expectedHeader: "Cache-Control",
+		},
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := testCase.wrap(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							suite.Require().Implements((*http.Flusher)(nil), w)
+							suite.Require().Implements((*http.Hijacker)(nil), w)
+							suite.Require().Implements((*http.Pusher)(nil), w)
+							suite.Require().Implements((*io.ReaderFrom)(nil), w)
+
+							suite.NoError(w.(http.Pusher).Push("/app.css", nil))
+							_, _ = w.(io.ReaderFrom).ReadFrom(strings.NewReader("body"))
+							w.(http.Flusher).Flush()
+							_, _, err := w.(http.Hijacker).Hijack()
+							suite.NoError(err)
+							suite.NoError(http.NewResponseController(w).SetWriteDeadline(deadline))
+						},
+					),
+				)
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+				recorder := &capableRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+				handler.ServeHTTP(recorder, request)
+
+				suite.Equal("/app.css", recorder.pushed)
+				suite.True(recorder.readFrom)
+				suite.True(recorder.Flushed)
+				suite.True(recorder.hijacked)
+				suite.Equal(deadline, recorder.deadline)
+				suite.Equal("body", recorder.Body.String())
+				if testCase.expectedHeader != "" {
+					suite.NotEmpty(recorder.Header().Get(testCase.expectedHeader))
+				}
+			},
+		)
+	}
+}
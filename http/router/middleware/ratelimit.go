@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/metrics"
+)
+
+// ErrRateLimitExceeded is rendered when a client exhausted its request allowance
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// RateLimit allows Requests requests per Period, refilled continuously (token bucket),
+// so bursts of up to Requests requests are accepted
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// rate returns the refill rate in requests per second
+func (limit RateLimit) rate() float64 {
+	return float64(limit.Requests) / limit.Period.Seconds()
+}
+
+// valid reports whether the limit can be enforced
+func (limit RateLimit) valid() bool {
+	return limit.Requests > 0 && limit.Period > 0
+}
+
+// RateLimiter rejects requests of clients exceeding their rate limit with
+// 429 Too Many Requests and a Retry-After header. Limits are tracked in memory, per
+// instance.
+type RateLimiter struct {
+	next    http.Handler
+	logger  *slog.Logger
+	options RateLimiterOptions
+
+	mu        sync.Mutex
+	buckets   map[rateLimitKey]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// RateLimiterOptions configures the rate limiter
+//
+// Limit: allowance of each client (requests are not limited when unset)
+// KeyFunc: identifies the client of a request (default: client IP)
+// Format: error response format used for rejected requests
+// Metrics: counts the rejected requests as metrics.RateLimitedTotal (optional)
+type RateLimiterOptions struct {
+	Limit   RateLimit
+	KeyFunc func(r *http.Request) string
+	Format  ErrorFormat
+	Metrics metrics.Recorder
+}
+
+type rateLimitContextKey struct{}
+
+// rateLimitKey identifies a bucket. Per-request limits get buckets scoped to the
+// pattern of the route, separate from the buckets of the default limit.
+type rateLimitKey struct {
+	scope  string
+	limit  RateLimit
+	client string
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// WithRequestRateLimit returns a context carrying a rate limit that takes precedence over
+// RateLimiterOptions.Limit for the request served with it (used for per-route limits)
+func WithRequestRateLimit(ctx context.Context, limit RateLimit) context.Context {
+	return context.WithValue(ctx, rateLimitContextKey{}, limit)
+}
+
+// RequestRateLimitFromContext returns the per-request rate limit override, if any
+func RequestRateLimitFromContext(ctx context.Context) (RateLimit, bool) {
+	limit, ok := ctx.Value(rateLimitContextKey{}).(RateLimit)
+	return limit, ok
+}
+
+// NewRateLimiter creates new rate limiter middleware
+func NewRateLimiter(
+	next http.Handler,
+	logger *slog.Logger,
+	options RateLimiterOptions,
+) *RateLimiter {
+	if options.KeyFunc == nil {
+		options.KeyFunc = func(r *http.Request) string {
+			return extractClientIP(r.RemoteAddr)
+		}
+	}
+	return &RateLimiter{
+		next:    next,
+		logger:  logger,
+		options: options,
+		buckets: make(map[rateLimitKey]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// ServeHTTP implements the middleware logic
+func (rl *RateLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := rateLimitKey{limit: rl.options.Limit}
+	if requestLimit, ok := RequestRateLimitFromContext(r.Context()); ok {
+		key = rateLimitKey{scope: r.Pattern, limit: requestLimit}
+	}
+
+	if !key.limit.valid() {
+		rl.next.ServeHTTP(w, r)
+		return
+	}
+
+	key.client = rl.options.KeyFunc(r)
+	retryAfter, allowed := rl.take(key)
+	if allowed {
+		rl.next.ServeHTTP(w, r)
+		return
+	}
+
+	if rl.logger != nil {
+		rl.logger.WarnContext(
+			r.Context(),
+			"Rate limit exceeded",
+			slog.String("client", key.client),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+		)
+	}
+
+	if rl.options.Metrics != nil {
+		rl.options.Metrics.AddCounter(
+			metrics.RateLimitedTotal, 1, metrics.Labels{"method": r.Method},
+		)
+	}
+
+	builder := httpInternal.NewResponseBuilder(w).
+		Status(http.StatusTooManyRequests).
+		Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))).
+		Error().
+		WithError(ErrRateLimitExceeded).
+		WithRequest(r).
+		DisableLogging()
+	_ = rl.options.Format.apply(builder, r).Send()
+}
+
+// take consumes a token from the bucket of the key, or reports how long until one
+// becomes available
+func (rl *RateLimiter) take(key rateLimitKey) (time.Duration, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	capacity := float64(key.limit.Requests)
+	bucket, exists := rl.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		rl.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(
+		capacity,
+		bucket.tokens+now.Sub(bucket.updated).Seconds()*key.limit.rate(),
+	)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0, true
+	}
+
+	missing := (1 - bucket.tokens) / key.limit.rate()
+	return time.Duration(missing * float64(time.Second)), false
+}
+
+// sweep drops, at most once per minute, the buckets refilled to capacity since they
+// are equivalent to new ones
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < time.Minute {
+		return
+	}
+	rl.lastSweep = now
+
+	for key, bucket := range rl.buckets {
+		missing := float64(key.limit.Requests) - bucket.tokens
+		if now.Sub(bucket.updated).Seconds()*key.limit.rate() >= missing {
+			delete(rl.buckets, key)
+		}
+	}
+}
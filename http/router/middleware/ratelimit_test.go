@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golibry/go-http/http/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimiterSuite struct {
+	suite.Suite
+}
+
+func TestRateLimiterSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterSuite))
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+}
+
+func (suite *RateLimiterSuite) TestItCanLimitRequestRates() {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(
+		okHandler(),
+		nil,
+		RateLimiterOptions{Limit: RateLimit{Requests: 2, Period: time.Second}},
+	)
+	limiter.now = func() time.Time { return now }
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		limiter.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	suite.Equal(http.StatusOK, serve("10.0.0.1:1000").Code)
+	suite.Equal(http.StatusOK, serve("10.0.0.1:1001").Code)
+
+	rejected := serve("10.0.0.1:1002")
+	suite.Equal(http.StatusTooManyRequests, rejected.Code)
+	suite.Equal("1", rejected.Header().Get("Retry-After"))
+	suite.Equal("rate limit exceeded", rejected.Body.String())
+
+	suite.Equal(http.StatusOK, serve("10.0.0.2:1000").Code)
+
+	now = now.Add(500 * time.Millisecond)
+	suite.Equal(http.StatusOK, serve("10.0.0.1:1003").Code)
+	suite.Equal(http.StatusTooManyRequests, serve("10.0.0.1:1004").Code)
+
+	now = now.Add(2 * time.Minute)
+	suite.Equal(http.StatusOK, serve("10.0.0.1:1005").Code)
+	suite.Len(limiter.buckets, 1)
+}
+
+func (suite *RateLimiterSuite) TestItCanApplyRequestRateLimits() {
+	limiter := NewRateLimiter(
+		okHandler(),
+		nil,
+		RateLimiterOptions{
+			KeyFunc: func(r *http.Request) string { return r.Header.Get("X-Api-Key") },
+		},
+	)
+
+	serve := func(pattern string, limit *RateLimit) int {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("X-Api-Key", "key")
+		request.Pattern = pattern
+		if limit != nil {
+			request = request.WithContext(WithRequestRateLimit(request.Context(), *limit))
+		}
+		recorder := httptest.NewRecorder()
+		limiter.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	limit := &RateLimit{Requests: 1, Period: time.Hour}
+	suite.Equal(http.StatusOK, serve("/unlimited", nil))
+	suite.Equal(http.StatusOK, serve("/unlimited", nil))
+	suite.Equal(http.StatusOK, serve("/login", limit))
+	suite.Equal(http.StatusTooManyRequests, serve("/login", limit))
+	suite.Equal(http.StatusOK, serve("/signup", limit))
+}
+
+func (suite *RateLimiterSuite) TestItCanCountRejectedRequests() {
+	recorder := metrics.NewMemory()
+	limiter := NewRateLimiter(
+		okHandler(),
+		nil,
+		RateLimiterOptions{Limit: RateLimit{Requests: 1, Period: time.Minute}, Metrics: recorder},
+	)
+
+	for range 3 {
+		limiter.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	}
+
+	suite.Equal(2.0, recorder.Value(metrics.RateLimitedTotal, metrics.Labels{"method": http.MethodPost}))
+}
@@ -7,54 +7,184 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"runtime"
 	"runtime/debug"
+	"strings"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/httperr"
 )
 
+// Recoverer logs panics and answers them with an error response. http.ErrAbortHandler
+// is re-panicked, as the server relies on it to abort responses silently.
 type Recoverer struct {
-	next   http.Handler
-	ctx    context.Context
-	logger *slog.Logger
+	next    http.Handler
+	ctx     context.Context
+	logger  *slog.Logger
+	options RecovererOptions
+}
+
+// RecovererOptions configures the recoverer behavior
+//
+// StackDepth: maximum number of stack frames captured for a panic (0 = full goroutine stack)
+// ExposeStack: include the panic value and stack in the response body; only enable it in
+// non-production environments, as stacks disclose internal details
+// ResponseFormat: text (default), JSON, problem+json, or negotiated from the Accept
+// header; structured bodies carry the status, message and request ID
+// ErrorCategories: categories used to classify panics raised with error values, the
+// response being rendered by the shared ErrorResponseBuilder like any other error
+// Formatter: custom body formatter taking precedence over ResponseFormat
+// PanicHandler: optional hook receiving every recovered panic, e.g. to forward it to
+// an error tracker or alerting system; it runs before the error response is written
+type RecovererOptions struct {
+	StackDepth      int
+	ExposeStack     bool
+	ResponseFormat  ErrorFormat
+	ErrorCategories []*httperr.ErrorCategory
+	Formatter       httpInternal.ErrorFormatter
+	PanicHandler    func(ctx context.Context, r *http.Request, recovered interface{}, stack []byte)
 }
 
 func NewRecoverer(
 	next http.Handler,
 	ctx context.Context,
 	logger *slog.Logger,
+) *Recoverer {
+	return NewRecovererWithOptions(next, ctx, logger, RecovererOptions{})
+}
+
+// NewRecovererWithOptions creates a recoverer with custom options
+func NewRecovererWithOptions(
+	next http.Handler,
+	ctx context.Context,
+	logger *slog.Logger,
+	options RecovererOptions,
 ) *Recoverer {
 	return &Recoverer{
-		next:   next,
-		ctx:    ctx,
-		logger: logger,
+		next:    next,
+		ctx:     ctx,
+		logger:  logger,
+		options: options,
 	}
 }
 
 func (recoverer *Recoverer) ServeHTTP(rw http.ResponseWriter, rq *http.Request) {
 	defer func() {
 		if rvr := recover(); rvr != nil {
-			var err error
-			switch v := rvr.(type) {
-			case string:
-				err = errors.New(v)
-			case error:
-				err = v
-			default:
-				err = errors.New(fmt.Sprint(v))
+			if rvr == http.ErrAbortHandler {
+				panic(rvr)
 			}
+			err := panicToError(rvr)
+			stack := captureStack(recoverer.options.StackDepth)
+			requestID := httpInternal.RequestIDFromRequest(rq)
 
 			if recoverer.logger != nil {
-				recoverer.logger.ErrorContext(recoverer.ctx, err.Error())
+				recoverer.logger.ErrorContext(
+					recoverer.ctx,
+					err.Error(),
+					slog.String("method", rq.Method),
+					slog.String("path", rq.URL.Path),
+					slog.String("requestId", requestID),
+					slog.String("stack", string(stack)),
+				)
 			} else {
-				_, _ = fmt.Fprintf(os.Stderr, "Panic: %+v\n", rvr)
-				debug.PrintStack()
+				_, _ = fmt.Fprintf(
+					os.Stderr,
+					"Panic: %+v (method=%s path=%s requestId=%s)\n%s",
+					rvr, rq.Method, rq.URL.Path, requestID, stack,
+				)
 			}
 
-			http.Error(
-				rw,
-				http.StatusText(http.StatusInternalServerError),
-				http.StatusInternalServerError,
-			)
+			recoverer.reportPanic(rq, rvr, stack)
+
+			recoverer.writeErrorResponse(rw, rq, rvr, stack, requestID)
 		}
 	}()
 
 	recoverer.next.ServeHTTP(rw, rq)
 }
+
+// writeErrorResponse converts the panic into an error and renders it through the
+// shared error response pipeline. The panic was already logged with its stack.
+func (recoverer *Recoverer) writeErrorResponse(
+	rw http.ResponseWriter,
+	rq *http.Request,
+	rvr interface{},
+	stack []byte,
+	requestID string,
+) {
+	builder := httpInternal.NewResponseBuilder(rw).
+		Error().
+		WithError(&httperr.PanicError{Value: rvr, Stack: stack}).
+		WithErrorCategories(RequestErrorCategoriesFromContext(rq.Context())...).
+		WithErrorCategories(recoverer.options.ErrorCategories...).
+		WithRequestID(requestID).
+		WithContext(recoverer.ctx).
+		WithRequest(rq).
+		WithDevMode(recoverer.options.ExposeStack).
+		WithFormatter(recoverer.options.Formatter).
+		DisableLogging()
+
+	recoverer.options.ResponseFormat.apply(builder, rq)
+
+	_ = builder.Send()
+}
+
+// reportPanic forwards the panic to the configured PanicHandler, shielding the
+// response from a failing hook
+func (recoverer *Recoverer) reportPanic(rq *http.Request, rvr interface{}, stack []byte) {
+	if recoverer.options.PanicHandler == nil {
+		return
+	}
+
+	defer func() {
+		if hookRvr := recover(); hookRvr != nil {
+			if recoverer.logger != nil {
+				recoverer.logger.ErrorContext(
+					recoverer.ctx,
+					"Panic handler failed",
+					slog.String("error", panicToError(hookRvr).Error()),
+				)
+			} else {
+				_, _ = fmt.Fprintf(os.Stderr, "Panic handler failed: %+v\n", hookRvr)
+			}
+		}
+	}()
+
+	recoverer.options.PanicHandler(recoverer.ctx, rq, rvr, stack)
+}
+
+// panicToError converts a recovered value into an error
+func panicToError(rvr interface{}) error {
+	switch v := rvr.(type) {
+	case string:
+		return errors.New(v)
+	case error:
+		return v
+	default:
+		return errors.New(fmt.Sprint(v))
+	}
+}
+
+// captureStack returns the stack of the panicking goroutine. It must be called from
+// the deferred recovery function. A positive depth limits the number of frames.
+func captureStack(depth int) []byte {
+	if depth <= 0 {
+		return debug.Stack()
+	}
+
+	// Skip runtime.Callers, captureStack and the deferred function
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var builder strings.Builder
+	for {
+		frame, more := frames.Next()
+		_, _ = fmt.Fprintf(&builder, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return []byte(builder.String())
+}
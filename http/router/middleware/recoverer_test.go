@@ -3,12 +3,17 @@ package middleware
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/suite"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/httperr"
 )
 
 type RecovererSuite struct {
@@ -164,3 +169,235 @@ func (suite *RecovererSuite) TestRecoverWithoutLogger() {
 		"Status code should be 500 Internal Server Error",
 	)
 }
+
+func (suite *RecovererSuite) TestItLogsStackAndRequestContext() {
+	request := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	request = request.WithContext(httpInternal.WithRequestID(request.Context(), "req-123"))
+	recorder := httptest.NewRecorder()
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("stack panic")
+		},
+	)
+
+	NewRecoverer(handler, context.Background(), logger).ServeHTTP(recorder, request)
+
+	loggedEntry := struct {
+		Msg       string `json:"msg"`
+		Method    string `json:"method"`
+		Path      string `json:"path"`
+		RequestID string `json:"requestId"`
+		Stack     string `json:"stack"`
+	}{}
+	suite.Require().NoError(json.Unmarshal(outputBuffer.Bytes(), &loggedEntry))
+	suite.Equal("stack panic", loggedEntry.Msg)
+	suite.Equal(http.MethodPost, loggedEntry.Method)
+	suite.Equal("/orders", loggedEntry.Path)
+	suite.Equal("req-123", loggedEntry.RequestID)
+	suite.Contains(loggedEntry.Stack, "TestItLogsStackAndRequestContext")
+	suite.Equal(http.StatusText(http.StatusInternalServerError), recorder.Body.String())
+}
+
+func (suite *RecovererSuite) TestItCanLimitStackDepthAndExposeStack() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+	request.Header.Set("X-Request-ID", "header-id")
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("exposed panic")
+		},
+	)
+
+	NewRecovererWithOptions(
+		handler,
+		context.Background(),
+		logger,
+		RecovererOptions{StackDepth: 2, ExposeStack: true},
+	).ServeHTTP(recorder, request)
+
+	loggedEntry := struct {
+		RequestID string `json:"requestId"`
+		Stack     string `json:"stack"`
+	}{}
+	suite.Require().NoError(json.Unmarshal(outputBuffer.Bytes(), &loggedEntry))
+	suite.Equal("header-id", loggedEntry.RequestID)
+	suite.Equal(2, strings.Count(loggedEntry.Stack, "\n\t"))
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(recorder.Body.String(), "panic: exposed panic")
+	suite.Contains(recorder.Body.String(), loggedEntry.Stack)
+}
+
+func (suite *RecovererSuite) TestItForwardsPanicsToPanicHandler() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/report", nil)
+	ctx := context.WithValue(context.Background(), "app", "test")
+
+	var (
+		reportedCtx       context.Context
+		reportedPath      string
+		reportedRecovered interface{}
+		reportedStack     []byte
+	)
+	options := RecovererOptions{
+		PanicHandler: func(
+			ctx context.Context,
+			r *http.Request,
+			recovered interface{},
+			stack []byte,
+		) {
+			reportedCtx = ctx
+			reportedPath = r.URL.Path
+			reportedRecovered = recovered
+			reportedStack = stack
+		},
+	}
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic(42)
+		},
+	)
+
+	NewRecovererWithOptions(handler, ctx, nil, options).ServeHTTP(recorder, request)
+
+	suite.Equal(ctx, reportedCtx)
+	suite.Equal("/report", reportedPath)
+	suite.Equal(42, reportedRecovered)
+	suite.Contains(string(reportedStack), "TestItForwardsPanicsToPanicHandler")
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+}
+
+func (suite *RecovererSuite) TestItSurvivesFailingPanicHandler() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/report", nil)
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	options := RecovererOptions{
+		PanicHandler: func(context.Context, *http.Request, interface{}, []byte) {
+			panic("reporter down")
+		},
+	}
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("original panic")
+		},
+	)
+
+	suite.NotPanics(
+		func() {
+			NewRecovererWithOptions(handler, context.Background(), logger, options).
+				ServeHTTP(recorder, request)
+		},
+	)
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(outputBuffer.String(), "reporter down")
+}
+
+func (suite *RecovererSuite) TestItCanRespondWithJSON() {
+	testCases := map[string]struct {
+		format              ErrorFormat
+		accept              string
+		expectedContentType string
+	}{
+		"text by default": {
+			format:              ErrorFormatText,
+			accept:              "application/json",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		"forced json": {
+			format:              ErrorFormatJSON,
+			accept:              "text/html",
+			expectedContentType: "application/json",
+		},
+		"negotiated json": {
+			format:              ErrorFormatNegotiate,
+			accept:              "text/html;q=0.9, application/vnd.api+json",
+			expectedContentType: "application/json",
+		},
+		"negotiated json refused": {
+			format:              ErrorFormatNegotiate,
+			accept:              "application/json;q=0, text/plain",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		"negotiated without accept": {
+			format:              ErrorFormatNegotiate,
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("json panic")
+		},
+	)
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodGet, "/api", nil)
+				request.Header.Set("Accept", testCase.accept)
+				request = request.WithContext(
+					httpInternal.WithRequestID(request.Context(), "req-json"),
+				)
+
+				NewRecovererWithOptions(
+					handler,
+					context.Background(),
+					nil,
+					RecovererOptions{ResponseFormat: testCase.format},
+				).ServeHTTP(recorder, request)
+
+				suite.Equal(http.StatusInternalServerError, recorder.Code)
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+				if testCase.expectedContentType == "application/json" {
+					suite.JSONEq(
+						`{"error":"Internal Server Error","status":500,"requestId":"req-json"}`,
+						recorder.Body.String(),
+					)
+				}
+			},
+		)
+	}
+}
+
+func (suite *RecovererSuite) TestItClassifiesPanicsWithErrorCategories() {
+	errUnavailable := errors.New("dependency unavailable")
+	category := httperr.NewErrorCategory(http.StatusServiceUnavailable)
+	category.AddSentinelError(errUnavailable)
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic(errUnavailable)
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+	request.Header.Set("Accept", "application/json")
+
+	NewRecovererWithOptions(
+		handler,
+		context.Background(),
+		nil,
+		RecovererOptions{
+			ResponseFormat:  ErrorFormatNegotiate,
+			ErrorCategories: []*httperr.ErrorCategory{category},
+		},
+	).ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.JSONEq(
+		`{"error":"Service Unavailable","status":503}`,
+		recorder.Body.String(),
+	)
+}
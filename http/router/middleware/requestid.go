@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// RequestID middleware assigns an ID to every request, stores it in the request context
+// and echoes it in the response headers so that logs and responses can be correlated
+type RequestID struct {
+	next    http.Handler
+	options RequestIDOptions
+}
+
+// RequestIDOptions configures the request ID middleware behavior
+//
+// HeaderName: header used to read and write the request ID (default: "X-Request-ID")
+// TrustIncoming: reuse a valid ID sent by the client instead of generating a new one
+// Generator: function generating new IDs (default: 16 random bytes, hex encoded)
+type RequestIDOptions struct {
+	HeaderName    string
+	TrustIncoming bool
+	Generator     func() string
+}
+
+// maxIncomingRequestIDLength limits the size of client provided IDs
+const maxIncomingRequestIDLength = 128
+
+// NewRequestID creates new request ID middleware
+func NewRequestID(next http.Handler, options RequestIDOptions) *RequestID {
+	if options.HeaderName == "" {
+		options.HeaderName = httpInternal.RequestIDHeader
+	}
+	if options.Generator == nil {
+		options.Generator = generateRequestID
+	}
+	return &RequestID{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (rid *RequestID) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := ""
+	if rid.options.TrustIncoming {
+		requestID = r.Header.Get(rid.options.HeaderName)
+		if !isValidRequestID(requestID) {
+			requestID = ""
+		}
+	}
+	if requestID == "" {
+		requestID = rid.options.Generator()
+	}
+
+	w.Header().Set(rid.options.HeaderName, requestID)
+	ctx := httpInternal.WithRequestID(r.Context(), requestID)
+	rid.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// isValidRequestID accepts only short IDs made of visible ASCII characters,
+// preventing log and header injection through client provided values
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxIncomingRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func generateRequestID() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestIDSuite struct {
+	suite.Suite
+}
+
+func TestRequestIDSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDSuite))
+}
+
+func (suite *RequestIDSuite) TestItCanAssignRequestIDs() {
+	testCases := map[string]struct {
+		options       RequestIDOptions
+		incomingID    string
+		expectedID    string
+		expectedLen   int
+		headerToCheck string
+	}{
+		"generates an id": {
+			options:       RequestIDOptions{},
+			expectedLen:   32,
+			headerToCheck: "X-Request-ID",
+		},
+		"ignores incoming id by default": {
+			options:       RequestIDOptions{},
+			incomingID:    "client-id",
+			expectedLen:   32,
+			headerToCheck: "X-Request-ID",
+		},
+		"trusts valid incoming id": {
+			options:       RequestIDOptions{TrustIncoming: true},
+			incomingID:    "client-id",
+			expectedID:    "client-id",
+			headerToCheck: "X-Request-ID",
+		},
+		"rejects invalid incoming id": {
+			options:       RequestIDOptions{TrustIncoming: true, Generator: func() string { return "gen" }},
+			incomingID:    "bad id\n",
+			expectedID:    "gen",
+			headerToCheck: "X-Request-ID",
+		},
+		"uses custom header": {
+			options: RequestIDOptions{
+				HeaderName: "X-Correlation-ID",
+				Generator:  func() string { return "custom" },
+			},
+			expectedID:    "custom",
+			headerToCheck: "X-Correlation-ID",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				var contextID string
+				handler := http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						contextID = httpInternal.RequestIDFromContext(r.Context())
+					},
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				if testCase.incomingID != "" {
+					request.Header.Set(testCase.headerToCheck, testCase.incomingID)
+				}
+				recorder := httptest.NewRecorder()
+
+				NewRequestID(handler, testCase.options).ServeHTTP(recorder, request)
+
+				suite.Equal(contextID, recorder.Header().Get(testCase.headerToCheck))
+				if testCase.expectedID != "" {
+					suite.Equal(testCase.expectedID, contextID)
+				} else {
+					suite.Len(contextID, testCase.expectedLen)
+					suite.NotEqual(testCase.incomingID, contextID)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ErrResponseTooLarge is returned by writes past the response limit, and rendered when
+// the handler exceeded the limit before sending anything
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ResponseLimit caps the size of response bodies, protecting against accidentally
+// unbounded responses (e.g. a list endpoint missing its pagination). Writes past the
+// limit fail with ErrResponseTooLarge and are logged once the handler returns. When
+// nothing was sent yet, a 500 Internal Server Error is rendered instead, otherwise the
+// connection is aborted with http.ErrAbortHandler so the client cannot mistake the
+// truncated body for a complete one.
+type ResponseLimit struct {
+	next    http.Handler
+	logger  *slog.Logger
+	options ResponseLimitOptions
+}
+
+// ResponseLimitOptions configures the response limit middleware
+//
+// MaxBytes: maximum response body size (zero or negative = unlimited)
+// Format: error response format used when nothing was sent yet
+type ResponseLimitOptions struct {
+	MaxBytes int64
+	Format   ErrorFormat
+}
+
+// NewResponseLimit creates new response limit middleware
+func NewResponseLimit(
+	next http.Handler,
+	logger *slog.Logger,
+	options ResponseLimitOptions,
+) *ResponseLimit {
+	return &ResponseLimit{next: next, logger: logger, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (rl *ResponseLimit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rl.options.MaxBytes <= 0 {
+		rl.next.ServeHTTP(w, r)
+		return
+	}
+
+	writer := &limitWriter{ResponseWriter: w, maxBytes: rl.options.MaxBytes}
+	rl.next.ServeHTTP(writer, r)
+	if !writer.exceeded {
+		return
+	}
+
+	if rl.logger != nil {
+		rl.logger.ErrorContext(
+			r.Context(),
+			"Response body too large",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int64("maxBytes", rl.options.MaxBytes),
+			slog.Int64("bytesWritten", writer.written),
+		)
+	}
+
+	if writer.wroteHeader {
+		panic(http.ErrAbortHandler)
+	}
+
+	w.Header().Del("Content-Length")
+	builder := httpInternal.NewResponseBuilder(w).
+		Error().
+		WithError(ErrResponseTooLarge).
+		WithRequest(r).
+		DisableLogging()
+	_ = rl.options.Format.apply(builder, r).Send()
+}
+
+// limitWriter counts the body bytes, rejecting writes past the limit
+type limitWriter struct {
+	http.ResponseWriter
+	maxBytes    int64
+	written     int64
+	wroteHeader bool
+	exceeded    bool
+}
+
+func (lw *limitWriter) WriteHeader(statusCode int) {
+	if lw.exceeded {
+		return
+	}
+	lw.wroteHeader = true
+	lw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (lw *limitWriter) Write(b []byte) (int, error) {
+	if lw.exceeded || lw.written+int64(len(b)) > lw.maxBytes {
+		lw.exceeded = true
+		return 0, ErrResponseTooLarge
+	}
+	lw.wroteHeader = true
+	n, err := lw.ResponseWriter.Write(b)
+	lw.written += int64(n)
+	return n, err
+}
+
+func (lw *limitWriter) Flush() {
+	if lw.exceeded {
+		return
+	}
+	lw.wroteHeader = true
+	httpInternal.Flush(lw.ResponseWriter)
+}
+
+func (lw *limitWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return httpInternal.Hijack(lw.ResponseWriter)
+}
+
+func (lw *limitWriter) Push(target string, opts *http.PushOptions) error {
+	return httpInternal.Push(lw.ResponseWriter, target, opts)
+}
+
+func (lw *limitWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+// ReadFrom copies through Write so the copied bytes are counted, giving up sendfile
+func (lw *limitWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(httpInternal.WriterOnly{Writer: lw}, r)
+}
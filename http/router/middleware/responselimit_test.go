@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseLimitSuite struct {
+	suite.Suite
+}
+
+func TestResponseLimitSuite(t *testing.T) {
+	suite.Run(t, new(ResponseLimitSuite))
+}
+
+func (suite *ResponseLimitSuite) TestItCanLimitResponseBodies() {
+	testCases := map[string]struct {
+		options          ResponseLimitOptions
+		chunks           []string
+		expectedCode     int
+		expectedBody     string
+		expectedWriteErr error
+		expectedLogged   bool
+	}{
+		"within limit": {
+			options:      ResponseLimitOptions{MaxBytes: 8},
+			chunks:       []string{"1234", "5678"},
+			expectedCode: http.StatusOK,
+			expectedBody: "12345678",
+		},
+		"over limit before sending": {
+			options:          ResponseLimitOptions{MaxBytes: 8},
+			chunks:           []string{"123456789"},
+			expectedCode:     http.StatusInternalServerError,
+			expectedWriteErr: ErrResponseTooLarge,
+			expectedLogged:   true,
+		},
+		"unlimited": {
+			chunks:       []string{strings.Repeat("x", 64)},
+			expectedCode: http.StatusOK,
+			expectedBody: strings.Repeat("x", 64),
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				outputBuffer := new(bytes.Buffer)
+				var writeErr error
+				handler := http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Content-Type", "application/json")
+						for _, chunk := range tc.chunks {
+							if _, err := w.Write([]byte(chunk)); err != nil {
+								writeErr = err
+								return
+							}
+						}
+					},
+				)
+				recorder := httptest.NewRecorder()
+
+				NewResponseLimit(
+					handler,
+					slog.New(slog.NewJSONHandler(outputBuffer, nil)),
+					tc.options,
+				).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				suite.Equal(tc.expectedWriteErr, writeErr)
+				if tc.expectedBody != "" {
+					suite.Equal(tc.expectedBody, recorder.Body.String())
+				}
+				if tc.expectedLogged {
+					suite.Contains(outputBuffer.String(), `"msg":"Response body too large"`)
+					suite.Contains(outputBuffer.String(), `"maxBytes":8`)
+				} else {
+					suite.Empty(outputBuffer.String())
+				}
+			},
+		)
+	}
+}
+
+func (suite *ResponseLimitSuite) TestItAbortsStartedResponsesOverTheLimit() {
+	outputBuffer := new(bytes.Buffer)
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("1234"))
+			_, _ = w.Write([]byte("56789"))
+			_, _ = w.Write([]byte("0"))
+		},
+	)
+	recorder := httptest.NewRecorder()
+	middleware := NewResponseLimit(
+		handler,
+		slog.New(slog.NewJSONHandler(outputBuffer, nil)),
+		ResponseLimitOptions{MaxBytes: 8},
+	)
+
+	suite.PanicsWithValue(
+		http.ErrAbortHandler, func() {
+			middleware.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		},
+	)
+	suite.Equal("1234", recorder.Body.String())
+	suite.Contains(outputBuffer.String(), `"bytesWritten":4`)
+}
+
+func (suite *ResponseLimitSuite) TestItAbortsThroughTheRecoverer() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, nil))
+	recorder := httptest.NewRecorder()
+	middleware := NewRecovererWithOptions(
+		NewResponseLimit(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte("1234"))
+					_, _ = w.Write([]byte("56789"))
+				},
+			),
+			logger,
+			ResponseLimitOptions{MaxBytes: 8},
+		),
+		context.Background(),
+		logger,
+		RecovererOptions{},
+	)
+
+	suite.PanicsWithValue(
+		http.ErrAbortHandler, func() {
+			middleware.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		},
+	)
+	suite.Equal("1234", recorder.Body.String())
+	suite.NotContains(outputBuffer.String(), "stack")
+}
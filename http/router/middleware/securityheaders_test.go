@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityHeadersSuite struct {
+	suite.Suite
+}
+
+func TestSecurityHeadersSuite(t *testing.T) {
+	suite.Run(t, new(SecurityHeadersSuite))
+}
+
+func (suite *SecurityHeadersSuite) TestItCanSetSecurityHeaders() {
+	base := SecurityHeadersOptions{
+		ContentSecurityPolicy:   "default-src 'self'",
+		StrictTransportSecurity: "max-age=63072000; includeSubDomains",
+	}
+
+	testCases := map[string]struct {
+		options         SecurityHeadersOptions
+		expectedHeaders map[string]string
+	}{
+		"defaults": {
+			options: SecurityHeadersOptions{},
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy":    "",
+				"X-Frame-Options":            "DENY",
+				"X-Content-Type-Options":     "nosniff",
+				"Referrer-Policy":            "strict-origin-when-cross-origin",
+				"Strict-Transport-Security":  "",
+				"Cross-Origin-Opener-Policy": "same-origin",
+			},
+		},
+		"base configuration": {
+			options: base,
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy":   "default-src 'self'",
+				"X-Frame-Options":           "DENY",
+				"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+			},
+		},
+		"merged override keeps base values": {
+			options: base.Merge(
+				SecurityHeadersOptions{
+					ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'unsafe-eval'",
+				},
+			),
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy":   "default-src 'self'; script-src 'self' 'unsafe-eval'",
+				"X-Frame-Options":           "DENY",
+				"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+			},
+		},
+		"merged override omits header": {
+			options: base.Merge(SecurityHeadersOptions{FrameOptions: SecurityHeaderOmit}),
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy": "default-src 'self'",
+				"X-Frame-Options":         "",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewSecurityHeaders(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+					testCase.options,
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				for key, value := range testCase.expectedHeaders {
+					suite.Equal(value, recorder.Header().Get(key), key)
+				}
+			},
+		)
+	}
+}
+
+func (suite *SecurityHeadersSuite) TestItLetsHandlersReplaceHeaders() {
+	handler := NewSecurityHeaders(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+			},
+		),
+		SecurityHeadersOptions{},
+	)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal("SAMEORIGIN", recorder.Header().Get("X-Frame-Options"))
+}
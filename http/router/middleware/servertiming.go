@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ServerTiming reports the handler duration and the durations contributed by other
+// components (see httpInternal.StartTiming) in the Server-Timing header. The header is
+// set right before the response header is written, so timings recorded afterward, e.g.
+// while streaming, are not reported, and responses nothing is written to carry none.
+type ServerTiming struct {
+	next    http.Handler
+	options ServerTimingOptions
+}
+
+// ServerTimingOptions configures the server timing middleware
+//
+// HandlerMetric: name of the handler duration metric (default: "handler")
+// Allow: tells whether the timings of the request are reported (nil reports all of
+// them); they disclose internals to clients, so restrict them on public services
+type ServerTimingOptions struct {
+	HandlerMetric string
+	Allow         func(r *http.Request) bool
+}
+
+// NewServerTiming creates new server timing middleware
+func NewServerTiming(next http.Handler, options ServerTimingOptions) *ServerTiming {
+	if options.HandlerMetric == "" {
+		options.HandlerMetric = "handler"
+	}
+	return &ServerTiming{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (st *ServerTiming) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if st.options.Allow != nil && !st.options.Allow(r) {
+		st.next.ServeHTTP(w, r)
+		return
+	}
+
+	start := time.Now()
+	timings := &httpInternal.ServerTimings{}
+	writer := httpInternal.AcquireResponseWriter(w).OnFirstWrite(
+		func(header http.Header) {
+			st.setHeader(header, timings, time.Since(start))
+		},
+	)
+	defer writer.Release()
+	st.next.ServeHTTP(writer, r.WithContext(httpInternal.WithServerTimings(r.Context(), timings)))
+}
+
+// setHeader appends the handler and collected timings to the Server-Timing header
+func (st *ServerTiming) setHeader(
+	header http.Header,
+	timings *httpInternal.ServerTimings,
+	elapsed time.Duration,
+) {
+	header.Add(ServerTimingHeader, st.options.HandlerMetric+";dur="+formatMilliseconds(elapsed))
+	for _, timing := range timings.Entries() {
+		header.Add(ServerTimingHeader, timing.Name+";dur="+formatMilliseconds(timing.Duration))
+	}
+}
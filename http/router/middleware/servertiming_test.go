@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/session"
+	"github.com/golibry/go-http/http/session/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTimingSuite struct {
+	suite.Suite
+}
+
+func TestServerTimingSuite(t *testing.T) {
+	suite.Run(t, new(ServerTimingSuite))
+}
+
+func (suite *ServerTimingSuite) TestItCanReportServerTimings() {
+	testCases := map[string]struct {
+		options          ServerTimingOptions
+		expectedPrefixes []string
+	}{
+		"default handler metric": {
+			expectedPrefixes: []string{"handler;dur=", "db;dur=5.0", "cache;dur=1.0"},
+		},
+		"custom handler metric": {
+			options:          ServerTimingOptions{HandlerMetric: "app"},
+			expectedPrefixes: []string{"app;dur=", "db;dur=5.0", "cache;dur=1.0"},
+		},
+		"not allowed": {
+			options: ServerTimingOptions{
+				Allow: func(r *http.Request) bool { return r.Header.Get("X-Debug") != "" },
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewServerTiming(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							timings := httpInternal.ServerTimingsFromContext(r.Context())
+							timings.Add("db", 2*time.Millisecond)
+							timings.Add("cache", time.Millisecond)
+							timings.Add("db", 3*time.Millisecond)
+							_, _ = w.Write([]byte("ok"))
+							timings.Add("late", time.Millisecond)
+						},
+					),
+					testCase.options,
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				values := recorder.Header().Values(ServerTimingHeader)
+				suite.Len(values, len(testCase.expectedPrefixes))
+				for i, prefix := range testCase.expectedPrefixes {
+					suite.True(strings.HasPrefix(values[i], prefix), values[i])
+				}
+				suite.Equal("ok", recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *ServerTimingSuite) TestItReportsSessionLoading() {
+	ctx := context.Background()
+	manager := session.NewManager(
+		storage.NewMemoryStorage(), ctx, slog.New(slog.DiscardHandler), session.DefaultOptions(),
+	)
+	handler := NewServerTiming(
+		NewSessionMiddleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+			ctx,
+			nil,
+			manager,
+		),
+		ServerTimingOptions{},
+	)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	values := recorder.Header().Values(ServerTimingHeader)
+	suite.Len(values, 2)
+	suite.True(strings.HasPrefix(values[1], httpInternal.TimingSession+";dur="), values[1])
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	httpInternal "github.com/golibry/go-http/http"
 	"github.com/golibry/go-http/http/session"
 )
 
@@ -37,7 +38,9 @@ func NewSessionMiddleware(
 // ServeHTTP implements the middleware logic
 func (sm *SessionMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Try to get an existing session
+	stopTiming := httpInternal.StartTiming(r.Context(), httpInternal.TimingSession)
 	sess, err := sm.manager.GetSession(sm.ctx, r)
+	stopTiming()
 	if err != nil && errors.Is(err, session.ErrSessionNotFound) {
 		if sm.logger != nil {
 			sm.logger.ErrorContext(sm.ctx, "Failed to get session", "error", err)
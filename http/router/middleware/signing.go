@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ResponseSigner signs response bodies with an HMAC emitted in a signature header (see
+// httpInternal.Signature), for webhook-provider style endpoints. Responses are buffered
+// until the handler returns, which rules out streaming handlers; HEAD responses are not
+// signed as they carry no body.
+type ResponseSigner struct {
+	next    http.Handler
+	options SigningOptions
+}
+
+// SigningOptions configures the response signing
+//
+// Key: secret and key ID used to sign the bodies
+// Header: header carrying the signature (default: httpInternal.SignatureHeader)
+type SigningOptions struct {
+	Key    httpInternal.SigningKey
+	Header string
+}
+
+// NewResponseSigner creates new response signing middleware
+func NewResponseSigner(next http.Handler, options SigningOptions) *ResponseSigner {
+	if options.Header == "" {
+		options.Header = httpInternal.SignatureHeader
+	}
+	return &ResponseSigner{next: next, options: options}
+}
+
+// ServeHTTP implements the middleware logic
+func (rs *ResponseSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodHead {
+		rs.next.ServeHTTP(w, r)
+		return
+	}
+
+	sw := &signingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+	rs.next.ServeHTTP(sw, r)
+
+	body := sw.buf.Bytes()
+	w.Header().Set(rs.options.Header, httpInternal.Signature(rs.options.Key, body))
+	w.WriteHeader(sw.statusCode)
+	_, _ = w.Write(body)
+}
+
+// signingWriter buffers the status code and body until they are signed, so it does not
+// implement http.Flusher nor http.Hijacker, nor Unwrap for http.ResponseController
+type signingWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+func (sw *signingWriter) WriteHeader(statusCode int) {
+	if !sw.wroteHeader {
+		sw.wroteHeader = true
+		sw.statusCode = statusCode
+	}
+}
+
+func (sw *signingWriter) Write(b []byte) (int, error) {
+	sw.wroteHeader = true
+	return sw.buf.Write(b)
+}
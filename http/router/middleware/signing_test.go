@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/stretchr/testify/suite"
+)
+
+type SigningSuite struct {
+	suite.Suite
+}
+
+func TestSigningSuite(t *testing.T) {
+	suite.Run(t, new(SigningSuite))
+}
+
+func (suite *SigningSuite) TestItSignsResponseBodies() {
+	key := httpInternal.SigningKey{ID: "key-1", Secret: []byte("webhook secret")}
+
+	testCases := map[string]struct {
+		method         string
+		options        SigningOptions
+		expectedHeader string
+		expectSigned   bool
+	}{
+		"default header": {
+			method:         http.MethodPost,
+			options:        SigningOptions{Key: key},
+			expectedHeader: httpInternal.SignatureHeader,
+			expectSigned:   true,
+		},
+		"custom header": {
+			method:         http.MethodGet,
+			options:        SigningOptions{Key: key, Header: "X-Webhook-Signature"},
+			expectedHeader: "X-Webhook-Signature",
+			expectSigned:   true,
+		},
+		"head request": {
+			method:         http.MethodHead,
+			options:        SigningOptions{Key: key},
+			expectedHeader: httpInternal.SignatureHeader,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				handler := NewResponseSigner(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "application/json")
+							w.WriteHeader(http.StatusAccepted)
+							_, _ = w.Write([]byte(`{"event":`))
+							_, _ = w.Write([]byte(`"order.paid"}`))
+						},
+					),
+					testCase.options,
+				)
+				recorder := httptest.NewRecorder()
+
+				handler.ServeHTTP(recorder, httptest.NewRequest(testCase.method, "/webhooks", nil))
+
+				suite.Equal(http.StatusAccepted, recorder.Code)
+				suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+				signature := recorder.Header().Get(testCase.expectedHeader)
+				if !testCase.expectSigned {
+					suite.Empty(signature)
+					return
+				}
+				suite.Equal(`{"event":"order.paid"}`, recorder.Body.String())
+				suite.True(httpInternal.VerifySignature(key, recorder.Body.Bytes(), signature))
+			},
+		)
+	}
+}
@@ -1,10 +1,16 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+	"github.com/golibry/go-http/http/metrics"
 )
 
 // TimeoutMiddleware provides request timeout handling middleware
@@ -18,6 +24,35 @@ type TimeoutMiddleware struct {
 type TimeoutOptions struct {
 	Timeout      time.Duration // Request timeout duration
 	ErrorMessage string        // Custom error message for timeout
+	StatusCode   int           // Response status code for timeout (default: 408)
+	RetryAfter   time.Duration // Optional Retry-After header value, rounded up to seconds
+	AsJSON       bool          // Respond with a JSON error body instead of plain text
+
+	// WarnAfter, when set below the timeout, logs requests still running after this
+	// duration together with the handler progress, without interrupting them.
+	// Useful to tune real timeout values before enforcing them.
+	WarnAfter time.Duration
+
+	// OnTimeout, when set, fully replaces the built-in timeout response.
+	// It receives the original response writer and the timed-out request.
+	OnTimeout func(w http.ResponseWriter, r *http.Request)
+
+	// Metrics, when set, counts the timed-out requests as metrics.TimeoutsTotal
+	Metrics metrics.Recorder
+}
+
+type timeoutContextKey struct{}
+
+// WithRequestTimeout returns a context carrying a timeout that takes precedence over
+// TimeoutOptions.Timeout for the request served with it (used for per-route timeouts)
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutContextKey{}, timeout)
+}
+
+// RequestTimeoutFromContext returns the per-request timeout override, if any
+func RequestTimeoutFromContext(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(timeoutContextKey{}).(time.Duration)
+	return timeout, ok && timeout > 0
 }
 
 // NewTimeoutMiddleware creates new timeout middleware
@@ -36,6 +71,11 @@ func NewTimeoutMiddleware(
 		options.ErrorMessage = "Request timeout"
 	}
 
+	// Set default status code if not specified
+	if options.StatusCode == 0 {
+		options.StatusCode = http.StatusRequestTimeout
+	}
+
 	return &TimeoutMiddleware{
 		next:    next,
 		logger:  logger,
@@ -45,13 +85,21 @@ func NewTimeoutMiddleware(
 
 // ServeHTTP implements the middleware logic
 func (tm *TimeoutMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	timeout := tm.options.Timeout
+	if requestTimeout, ok := RequestTimeoutFromContext(r.Context()); ok {
+		timeout = requestTimeout
+	}
+
 	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), tm.options.Timeout)
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
 	defer cancel()
 
 	// Create a new request with the timeout context
 	r = r.WithContext(ctx)
 
+	// The handler writes into a buffer which is only flushed if it finishes in time
+	tw := newTimeoutWriter(w)
+
 	// Channel to signal completion
 	done := make(chan struct{})
 	var panicValue interface{}
@@ -65,37 +113,184 @@ func (tm *TimeoutMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			close(done)
 		}()
 
-		tm.next.ServeHTTP(w, r)
+		tm.next.ServeHTTP(tw, r)
 	}()
 
+	// Optionally warn about slow requests before they time out
+	var warnC <-chan time.Time
+	if tm.options.WarnAfter > 0 && tm.options.WarnAfter < timeout {
+		warnTimer := time.NewTimer(tm.options.WarnAfter)
+		defer warnTimer.Stop()
+		warnC = warnTimer.C
+	}
+
 	// Wait for either completion or timeout
-	select {
-	case <-done:
-		// Request completed successfully
-		if panicValue != nil {
-			// Re-panic if there was a panic in the handler
-			panic(panicValue)
+	for {
+		select {
+		case <-warnC:
+			// Request is slow but still allowed to finish
+			warnC = nil
+			tm.logSlowRequest(r, tw, timeout)
+
+		case <-done:
+			// Request completed successfully
+			if panicValue != nil {
+				// Re-panic if there was a panic in the handler
+				panic(panicValue)
+			}
+			tw.flush()
+			return
+
+		case <-ctx.Done():
+			// Request timed out, discard anything the handler buffered so far
+			tw.timeout()
+
+			if tm.logger != nil {
+				tm.logger.WarnContext(
+					r.Context(),
+					"Request timeout",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Duration("timeout", timeout),
+				)
+			}
+
+			if tm.options.Metrics != nil {
+				tm.options.Metrics.AddCounter(
+					metrics.TimeoutsTotal, 1, metrics.Labels{"method": r.Method},
+				)
+			}
+
+			tm.writeTimeoutResponse(w, r)
+			return
 		}
+	}
+}
+
+// logSlowRequest logs a request that exceeded the warning threshold with the handler progress
+func (tm *TimeoutMiddleware) logSlowRequest(r *http.Request, tw *timeoutWriter, timeout time.Duration) {
+	if tm.logger == nil {
 		return
+	}
 
-	case <-ctx.Done():
-		// Request timed out
-		if tm.logger != nil {
-			tm.logger.WarnContext(
-				r.Context(),
-				"Request timeout",
-				slog.String("method", r.Method),
-				slog.String("path", r.URL.Path),
-				slog.Duration("timeout", tm.options.Timeout),
-			)
-		}
+	statusCode, bytesWritten, wroteHeader := tw.progress()
+	tm.logger.WarnContext(
+		r.Context(),
+		"Slow request",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Duration("warnAfter", tm.options.WarnAfter),
+		slog.Duration("timeout", timeout),
+		slog.Bool("wroteHeader", wroteHeader),
+		slog.Int("statusCode", statusCode),
+		slog.Int("bytesWritten", bytesWritten),
+	)
+}
 
-		// Check if the response has already been written
-		if w.Header().Get("Content-Type") == "" {
-			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-			w.WriteHeader(http.StatusRequestTimeout)
-			_, _ = w.Write([]byte(tm.options.ErrorMessage))
-		}
+// writeTimeoutResponse sends the configured timeout response
+func (tm *TimeoutMiddleware) writeTimeoutResponse(w http.ResponseWriter, r *http.Request) {
+	if tm.options.OnTimeout != nil {
+		tm.options.OnTimeout(w, r)
 		return
 	}
+
+	if tm.options.RetryAfter > 0 {
+		seconds := int((tm.options.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	builder := httpInternal.NewResponseBuilder(w).
+		Status(tm.options.StatusCode).
+		Error().
+		WithMessage(tm.options.ErrorMessage)
+	if tm.options.AsJSON {
+		builder.AsJSON()
+	}
+	_ = builder.Send()
+}
+
+// timeoutWriter buffers the handler response so that it can be either flushed when the
+// handler finishes in time or discarded when the timeout response wins.
+// Writes after the timeout fail with http.ErrHandlerTimeout. Like http.TimeoutHandler, it
+// does not implement http.Flusher nor http.Hijacker, nor Unwrap so http.ResponseController
+// cannot bypass the buffer: streaming and websocket handlers must be kept out of the
+// timeout middleware.
+type timeoutWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	buf         bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+	timedOut    bool
+	mu          sync.Mutex
+}
+
+func newTimeoutWriter(w http.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{
+		w:          w,
+		header:     w.Header().Clone(),
+		statusCode: http.StatusOK,
+	}
+}
+
+// Header returns the buffered header map, isolated from the underlying writer
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader records the status code to be sent when the buffer is flushed
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	tw.statusCode = code
+}
+
+// Write buffers the body bytes
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	tw.wroteHeader = true
+	return tw.buf.Write(p)
+}
+
+// timeout marks the writer as timed out so later handler writes are rejected
+func (tw *timeoutWriter) timeout() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+}
+
+// progress reports what the handler has written so far
+func (tw *timeoutWriter) progress() (statusCode int, bytesWritten int, wroteHeader bool) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	return tw.statusCode, tw.buf.Len(), tw.wroteHeader
+}
+
+// flush copies the buffered headers, status code and body to the underlying writer
+func (tw *timeoutWriter) flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	dst := tw.w.Header()
+	for key := range dst {
+		delete(dst, key)
+	}
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+
+	tw.w.WriteHeader(tw.statusCode)
+	_, _ = tw.w.Write(tw.buf.Bytes())
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golibry/go-http/http/metrics"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -109,6 +110,7 @@ func (suite *TimeoutSuite) TestItCanUseDefaultValues() {
 
 	suite.Equal(30*time.Second, middleware.options.Timeout)
 	suite.Equal("Request timeout", middleware.options.ErrorMessage)
+	suite.Equal(http.StatusRequestTimeout, middleware.options.StatusCode)
 }
 
 func (suite *TimeoutSuite) TestItCanHandlePanicInHandler() {
@@ -235,3 +237,226 @@ func (suite *TimeoutSuite) TestItCanHandleQuickSuccessfulRequest() {
 	suite.Equal("quick response", recorder.Body.String())
 	suite.Equal("test-value", recorder.Header().Get("X-Custom"))
 }
+
+func (suite *TimeoutSuite) TestItDiscardsHandlerOutputAfterTimeout() {
+	handlerFinished := make(chan error, 1)
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Handler", "late")
+			w.Header().Set("Content-Type", "application/json")
+			<-r.Context().Done()
+			time.Sleep(20 * time.Millisecond)
+			_, err := w.Write([]byte(`{"late":true}`))
+			handlerFinished <- err
+		},
+	)
+
+	middleware := NewTimeoutMiddleware(
+		handler,
+		nil,
+		TimeoutOptions{Timeout: 30 * time.Millisecond, ErrorMessage: "timed out"},
+	)
+
+	req := httptest.NewRequest("GET", "/late", nil)
+	recorder := httptest.NewRecorder()
+
+	middleware.ServeHTTP(recorder, req)
+
+	suite.Equal(http.StatusRequestTimeout, recorder.Code)
+	suite.Equal("timed out", recorder.Body.String())
+	suite.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Empty(recorder.Header().Get("X-Handler"))
+
+	suite.ErrorIs(<-handlerFinished, http.ErrHandlerTimeout)
+	suite.Equal("timed out", recorder.Body.String())
+}
+
+func (suite *TimeoutSuite) TestItFlushesBufferedResponseWhenHandlerWins() {
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal("outer", w.Header().Get("X-Outer"))
+			w.Header().Set("X-Handler", "in-time")
+			w.WriteHeader(http.StatusAccepted)
+			w.WriteHeader(http.StatusTeapot)
+			_, _ = w.Write([]byte("part1,"))
+			_, _ = w.Write([]byte("part2"))
+		},
+	)
+
+	middleware := NewTimeoutMiddleware(handler, nil, TimeoutOptions{Timeout: time.Second})
+
+	req := httptest.NewRequest("GET", "/buffered", nil)
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("X-Outer", "outer")
+
+	middleware.ServeHTTP(recorder, req)
+
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	suite.Equal("part1,part2", recorder.Body.String())
+	suite.Equal("in-time", recorder.Header().Get("X-Handler"))
+	suite.Equal("outer", recorder.Header().Get("X-Outer"))
+}
+
+func (suite *TimeoutSuite) TestItCanCustomizeTimeoutResponse() {
+	slowHandler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	)
+
+	testCases := map[string]struct {
+		options             TimeoutOptions
+		expectedCode        int
+		expectedContentType string
+		expectedRetryAfter  string
+		expectedBody        string
+	}{
+		"service unavailable with retry after": {
+			options: TimeoutOptions{
+				Timeout:    20 * time.Millisecond,
+				StatusCode: http.StatusServiceUnavailable,
+				RetryAfter: 1500 * time.Millisecond,
+			},
+			expectedCode:        http.StatusServiceUnavailable,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedRetryAfter:  "2",
+			expectedBody:        "Request timeout",
+		},
+		"gateway timeout as json": {
+			options: TimeoutOptions{
+				Timeout:      20 * time.Millisecond,
+				StatusCode:   http.StatusGatewayTimeout,
+				ErrorMessage: "upstream too slow",
+				AsJSON:       true,
+			},
+			expectedCode:        http.StatusGatewayTimeout,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"upstream too slow","status":504}` + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				middleware := NewTimeoutMiddleware(slowHandler, nil, testCase.options)
+				recorder := httptest.NewRecorder()
+
+				middleware.ServeHTTP(recorder, httptest.NewRequest("GET", "/slow", nil))
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedRetryAfter, recorder.Header().Get("Retry-After"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *TimeoutSuite) TestItCanDelegateTimeoutResponseToCallback() {
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	)
+
+	var callbackPath string
+	middleware := NewTimeoutMiddleware(
+		handler, nil, TimeoutOptions{
+			Timeout: 20 * time.Millisecond,
+			OnTimeout: func(w http.ResponseWriter, r *http.Request) {
+				callbackPath = r.URL.Path
+				w.WriteHeader(http.StatusTeapot)
+				_, _ = w.Write([]byte("custom"))
+			},
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	middleware.ServeHTTP(recorder, httptest.NewRequest("GET", "/callback", nil))
+
+	suite.Equal("/callback", callbackPath)
+	suite.Equal(http.StatusTeapot, recorder.Code)
+	suite.Equal("custom", recorder.Body.String())
+}
+
+func (suite *TimeoutSuite) TestItCanWarnAboutSlowRequestsBeforeTimeout() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("partial"))
+			time.Sleep(80 * time.Millisecond)
+			_, _ = w.Write([]byte(" done"))
+		},
+	)
+
+	middleware := NewTimeoutMiddleware(
+		handler, logger, TimeoutOptions{
+			Timeout:   time.Second,
+			WarnAfter: 20 * time.Millisecond,
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	middleware.ServeHTTP(recorder, httptest.NewRequest("GET", "/slow", nil))
+
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal("partial done", recorder.Body.String())
+
+	loggedEntry := struct {
+		Level        string `json:"level"`
+		Msg          string `json:"msg"`
+		Path         string `json:"path"`
+		WroteHeader  bool   `json:"wroteHeader"`
+		StatusCode   int    `json:"statusCode"`
+		BytesWritten int    `json:"bytesWritten"`
+	}{}
+	suite.NoError(json.Unmarshal(outputBuffer.Bytes(), &loggedEntry))
+	suite.Equal("WARN", loggedEntry.Level)
+	suite.Equal("Slow request", loggedEntry.Msg)
+	suite.Equal("/slow", loggedEntry.Path)
+	suite.True(loggedEntry.WroteHeader)
+	suite.Equal(http.StatusCreated, loggedEntry.StatusCode)
+	suite.Equal(len("partial"), loggedEntry.BytesWritten)
+}
+
+func (suite *TimeoutSuite) TestItDoesNotWarnAboutFastRequests() {
+	outputBuffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{}))
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+	)
+
+	middleware := NewTimeoutMiddleware(
+		handler, logger, TimeoutOptions{Timeout: time.Second, WarnAfter: 50 * time.Millisecond},
+	)
+
+	recorder := httptest.NewRecorder()
+	middleware.ServeHTTP(recorder, httptest.NewRequest("GET", "/fast", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(outputBuffer.String())
+}
+
+func (suite *TimeoutSuite) TestItCanCountTimeouts() {
+	recorder := metrics.NewMemory()
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	)
+	middleware := NewTimeoutMiddleware(
+		handler,
+		nil,
+		TimeoutOptions{Timeout: 10 * time.Millisecond, Metrics: recorder},
+	)
+
+	middleware.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(1.0, recorder.Value(metrics.TimeoutsTotal, metrics.Labels{"method": http.MethodGet}))
+}
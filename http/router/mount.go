@@ -0,0 +1,42 @@
+package router
+
+import (
+	"net/http"
+)
+
+// Mount attaches a handler, typically another ServerMuxWrapper, under the prefix. The
+// prefix is stripped from the request path before the mounted handler runs, so modules
+// can register their routes independently of where they are attached. The mux defaults
+// and the given middlewares (override semantics) wrap the mounted handler, whose own
+// middleware stack runs inside them.
+func (mux *ServerMuxWrapper) Mount(prefix string, handler http.Handler, middlewares ...NamedMiddleware) {
+	mountHandler(mux, cleanPrefix(prefix), handler, middlewares, nil)
+}
+
+// Mount attaches a handler under the group prefix followed by the given prefix, wrapped
+// by the defaults, the group middlewares and the given middlewares, and configured by
+// the group route options
+func (group *RouteGroup) Mount(prefix string, handler http.Handler, middlewares ...NamedMiddleware) {
+	mountHandler(
+		group.mux,
+		group.prefix+cleanPrefix(prefix),
+		handler,
+		mergeNamedMiddlewares(group.middlewares, middlewares),
+		group.options,
+	)
+}
+
+func mountHandler(
+	mux *ServerMuxWrapper,
+	prefix string,
+	handler http.Handler,
+	middlewares []NamedMiddleware,
+	options []RouteOption,
+) {
+	mux.handle(
+		prefix+"/",
+		http.StripPrefix(prefix, handler),
+		middlewares,
+		withIdentity(handler, options),
+	)
+}
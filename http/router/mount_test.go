@@ -0,0 +1,77 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MountTestSuite struct {
+	suite.Suite
+}
+
+func TestMountSuite(t *testing.T) {
+	suite.Run(t, new(MountTestSuite))
+}
+
+func (suite *MountTestSuite) TestItCanMountSubrouters() {
+	admin := NewServerMuxWrapper(
+		[]NamedMiddleware{{Name: "admin-auth", Middleware: createTestMiddleware("admin-auth")}},
+	)
+	admin.Handle(
+		"GET /users/{id}",
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.URL.Path + " " + r.PathValue("id")))
+			},
+		),
+	)
+
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{{Name: "logging", Middleware: createTestMiddleware("logging")}},
+	)
+	mux.Mount("/admin", admin, NamedMiddleware{Name: "audit", Middleware: createTestMiddleware("audit")})
+	mux.Group("/api").Mount("/v2/", admin)
+
+	testCases := map[string]struct {
+		target              string
+		expectedStatus      int
+		expectedBody        string
+		expectedMiddlewares []string
+	}{
+		"mounted route": {
+			target:              "/admin/users/42",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "/users/42 42",
+			expectedMiddlewares: []string{"audit", "logging", "admin-auth"},
+		},
+		"mounted in group": {
+			target:              "/api/v2/users/7",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "/users/7 7",
+			expectedMiddlewares: []string{"logging", "admin-auth"},
+		},
+		"unknown mounted route": {
+			target:              "/admin/other",
+			expectedStatus:      http.StatusNotFound,
+			expectedMiddlewares: []string{"audit", "logging"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				}
+				suite.Equal(testCase.expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+}
@@ -0,0 +1,274 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// OpenAPIPath is the path the OpenAPI document is served at by ServeOpenAPI
+const OpenAPIPath = "/openapi.json"
+
+// OpenAPIInfo holds the API level information of the generated document
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIDocument is an OpenAPI 3 document generated from the registered routes
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIOperation documents a single route
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Parameters  []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter documents a path parameter
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody documents a JSON request body
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse documents a response status
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType holds the schema of a body
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents holds the schemas of named struct types
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPISchema is the subset of JSON schema derived from Go types
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenAPI generates an OpenAPI 3 document from the registered routes and their
+// metadata. Routes registered without a method are not documented, as OpenAPI
+// operations are bound to a method.
+func (mux *ServerMuxWrapper) OpenAPI(info OpenAPIInfo) *OpenAPIDocument {
+	document := &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]map[string]*OpenAPIOperation),
+	}
+	schemas := newSchemaGenerator()
+
+	for _, route := range mux.Routes() {
+		if route.Method == "" {
+			continue
+		}
+
+		path, params := openAPIPath(route.Path)
+		operation := &OpenAPIOperation{
+			OperationID: route.Name,
+			Summary:     route.Metadata.Summary,
+			Description: route.Metadata.Description,
+			Tags:        route.Metadata.Tags,
+			Deprecated:  route.Metadata.Deprecated,
+			Responses:   make(map[string]*OpenAPIResponse),
+		}
+
+		for _, param := range params {
+			operation.Parameters = append(
+				operation.Parameters, OpenAPIParameter{
+					Name:     param,
+					In:       "path",
+					Required: true,
+					Schema:   &OpenAPISchema{Type: "string"},
+				},
+			)
+		}
+
+		if route.Metadata.RequestBody != nil {
+			operation.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  jsonContent(schemas.schema(reflect.TypeOf(route.Metadata.RequestBody))),
+			}
+		}
+
+		for statusCode, body := range route.Metadata.Responses {
+			response := &OpenAPIResponse{Description: http.StatusText(statusCode)}
+			if body != nil {
+				response.Content = jsonContent(schemas.schema(reflect.TypeOf(body)))
+			}
+			operation.Responses[strconv.Itoa(statusCode)] = response
+		}
+		if len(operation.Responses) == 0 {
+			operation.Responses["200"] = &OpenAPIResponse{Description: http.StatusText(http.StatusOK)}
+		}
+
+		if document.Paths[path] == nil {
+			document.Paths[path] = make(map[string]*OpenAPIOperation)
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	if len(schemas.components) > 0 {
+		document.Components = &OpenAPIComponents{Schemas: schemas.components}
+	}
+
+	return document
+}
+
+// OpenAPIHandler serves the OpenAPI document as JSON. The document is generated on
+// each request, so it reflects routes registered after the handler was created.
+func (mux *ServerMuxWrapper) OpenAPIHandler(info OpenAPIInfo) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_ = httpInternal.NewResponseBuilder(w).JSON().Data(mux.OpenAPI(info)).Send()
+		},
+	)
+}
+
+// ServeOpenAPI registers the OpenAPI document at GET /openapi.json
+func (mux *ServerMuxWrapper) ServeOpenAPI(info OpenAPIInfo, options ...RouteOption) {
+	mux.Handle(http.MethodGet+" "+OpenAPIPath, mux.OpenAPIHandler(info), options...)
+}
+
+// openAPIPath converts a ServeMux pattern path to an OpenAPI path template and
+// returns its parameter names
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+		if name == "$" {
+			segments[i] = ""
+			continue
+		}
+		segments[i] = "{" + name + "}"
+		params = append(params, name)
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func jsonContent(schema *OpenAPISchema) map[string]*OpenAPIMediaType {
+	return map[string]*OpenAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// schemaGenerator derives schemas from Go types, registering named structs as components
+type schemaGenerator struct {
+	components map[string]*OpenAPISchema
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{components: make(map[string]*OpenAPISchema)}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (generator *schemaGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: generator.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: generator.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return generator.structSchema(t)
+		}
+		if _, exists := generator.components[t.Name()]; !exists {
+			// Register a placeholder first so recursive types terminate
+			generator.components[t.Name()] = &OpenAPISchema{}
+			generator.components[t.Name()] = generator.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &OpenAPISchema{}
+	}
+}
+
+// structSchema follows encoding/json naming: json tags, "-" and omitempty
+func (generator *schemaGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, tagOptions, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = generator.schema(field.Type)
+		if !strings.Contains(tagOptions, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+	return schema
+}
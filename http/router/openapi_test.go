@@ -0,0 +1,146 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPITestSuite struct {
+	suite.Suite
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+type openAPITestUser struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Email     string            `json:"email,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Manager   *openAPITestUser  `json:"manager"`
+	internal  string
+}
+
+type openAPITestCreateUser struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	Token string   `json:"-"`
+}
+
+func (suite *OpenAPITestSuite) TestItCanGenerateOpenAPIDocument() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /users/{id}",
+		testHandler(),
+		WithName("user.show"),
+		WithMetadata(
+			RouteMetadata{
+				Summary:   "Show user",
+				Tags:      []string{"users"},
+				Responses: map[int]interface{}{http.StatusOK: openAPITestUser{}, http.StatusNotFound: nil},
+			},
+		),
+	)
+	mux.Handle(
+		"POST /users",
+		testHandler(),
+		WithMetadata(
+			RouteMetadata{
+				Deprecated:  true,
+				RequestBody: &openAPITestCreateUser{},
+				Responses:   map[int]interface{}{http.StatusCreated: openAPITestUser{}},
+			},
+		),
+	)
+	mux.Handle("GET /files/{path...}", testHandler())
+	mux.Handle("/legacy", testHandler())
+	mux.ServeOpenAPI(OpenAPIInfo{Title: "Users API", Version: "1.0.0"})
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+
+	suite.JSONEq(
+		`{
+			"openapi": "3.0.3",
+			"info": {"title": "Users API", "version": "1.0.0"},
+			"paths": {
+				"/users/{id}": {
+					"get": {
+						"operationId": "user.show",
+						"summary": "Show user",
+						"tags": ["users"],
+						"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+						"responses": {
+							"200": {
+								"description": "OK",
+								"content": {"application/json": {"schema": {"$ref": "#/components/schemas/openAPITestUser"}}}
+							},
+							"404": {"description": "Not Found"}
+						}
+					}
+				},
+				"/users": {
+					"post": {
+						"deprecated": true,
+						"requestBody": {
+							"required": true,
+							"content": {"application/json": {"schema": {"$ref": "#/components/schemas/openAPITestCreateUser"}}}
+						},
+						"responses": {
+							"201": {
+								"description": "Created",
+								"content": {"application/json": {"schema": {"$ref": "#/components/schemas/openAPITestUser"}}}
+							}
+						}
+					}
+				},
+				"/files/{path}": {
+					"get": {
+						"parameters": [{"name": "path", "in": "path", "required": true, "schema": {"type": "string"}}],
+						"responses": {"200": {"description": "OK"}}
+					}
+				},
+				"/openapi.json": {
+					"get": {"responses": {"200": {"description": "OK"}}}
+				}
+			},
+			"components": {
+				"schemas": {
+					"openAPITestUser": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "integer", "format": "int64"},
+							"name": {"type": "string"},
+							"email": {"type": "string"},
+							"createdAt": {"type": "string", "format": "date-time"},
+							"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+							"manager": {"$ref": "#/components/schemas/openAPITestUser"}
+						},
+						"required": ["createdAt", "id", "name"]
+					},
+					"openAPITestCreateUser": {
+						"type": "object",
+						"properties": {
+							"name": {"type": "string"},
+							"roles": {"type": "array", "items": {"type": "string"}}
+						},
+						"required": ["name", "roles"]
+					}
+				}
+			}
+		}`,
+		recorder.Body.String(),
+	)
+
+	var document OpenAPIDocument
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &document))
+	suite.NotContains(document.Paths, "/legacy")
+}
@@ -0,0 +1,160 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// RouteOption configures a single route at registration time
+type RouteOption func(*routeConfig)
+
+// routeConfig holds the per-route settings collected from RouteOption values
+type routeConfig struct {
+	name            string
+	timeout         time.Duration
+	rateLimit       *middleware.RateLimit
+	bodyLimit       *int64
+	errorCategories []*httperr.ErrorCategory
+	cors            *middleware.CORSOptions
+	identity        interface{}
+	metadata        *RouteMetadata
+}
+
+// RouteMetadata describes a route for documentation and policy decisions
+//
+// Summary, Description, Tags: documentation of the route
+// Public: the route does not require authentication
+// Scopes: authorization scopes required by the route
+// Deprecated: the route is scheduled for removal
+// RequestBody: value of the JSON request body type, used for API documentation
+// Responses: value of the JSON response body type per status code (nil for no body)
+// Extra: any application specific value
+type RouteMetadata struct {
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Public      bool                   `json:"public,omitempty"`
+	Scopes      []string               `json:"scopes,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+	RequestBody interface{}            `json:"requestBody,omitempty"`
+	Responses   map[int]interface{}    `json:"responses,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+type routeMetadataContextKey struct{}
+
+func newRouteConfig(options []RouteOption) *routeConfig {
+	config := &routeConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// WithName names the route so its URL can be built with ServerMuxWrapper.URL.
+// Names must be unique within a mux.
+func WithName(name string) RouteOption {
+	return func(config *routeConfig) {
+		config.name = name
+	}
+}
+
+// WithMetadata attaches metadata to the route. It is listed by
+// ServerMuxWrapper.Routes and exposed to middlewares through MetadataFromContext.
+func WithMetadata(metadata RouteMetadata) RouteOption {
+	return func(config *routeConfig) {
+		config.metadata = &metadata
+	}
+}
+
+// MetadataFromContext returns the metadata of the route serving the request, e.g.
+// to skip authentication for public routes
+func MetadataFromContext(ctx context.Context) (RouteMetadata, bool) {
+	metadata, ok := ctx.Value(routeMetadataContextKey{}).(*RouteMetadata)
+	if !ok {
+		return RouteMetadata{}, false
+	}
+	return *metadata, true
+}
+
+// WithTimeout sets the request timeout for the route. It is honored by the
+// middleware.TimeoutMiddleware present in the route's middleware chain.
+func WithTimeout(timeout time.Duration) RouteOption {
+	return func(config *routeConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithRateLimit sets the rate limit of the route, tracked separately from other routes.
+// It is honored by the middleware.RateLimiter present in the route's middleware chain.
+func WithRateLimit(requests int, period time.Duration) RouteOption {
+	return func(config *routeConfig) {
+		config.rateLimit = &middleware.RateLimit{Requests: requests, Period: period}
+	}
+}
+
+// WithBodyLimit sets the maximum request body size of the route (negative = unlimited).
+// It is honored by the middleware.BodyLimit present in the route's middleware chain.
+func WithBodyLimit(maxBytes int64) RouteOption {
+	return func(config *routeConfig) {
+		config.bodyLimit = &maxBytes
+	}
+}
+
+// WithErrorCategories adds error categories used to classify the errors of the route,
+// checked before the ones of the middleware.Errorhandler and middleware.Recoverer
+// present in the route's middleware chain. Categories of later options, e.g. route
+// options following group options, are checked first.
+func WithErrorCategories(categories ...*httperr.ErrorCategory) RouteOption {
+	return func(config *routeConfig) {
+		merged := make([]*httperr.ErrorCategory, 0, len(categories)+len(config.errorCategories))
+		merged = append(merged, categories...)
+		config.errorCategories = append(merged, config.errorCategories...)
+	}
+}
+
+// WithCORS sets the CORS policy of the route, honored by the middleware.CORS present in
+// the route's middleware chain. Preflight requests for routes restricted to a method are
+// answered with this policy too, through the route's middleware chain.
+func WithCORS(options middleware.CORSOptions) RouteOption {
+	return func(config *routeConfig) {
+		config.cors = &options
+	}
+}
+
+// wrap exposes the route settings to the middleware chain through the request context
+func (config *routeConfig) wrap(next http.Handler) http.Handler {
+	if config.timeout <= 0 && config.rateLimit == nil && config.bodyLimit == nil &&
+		len(config.errorCategories) == 0 && config.cors == nil && config.metadata == nil {
+		return next
+	}
+
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if config.timeout > 0 {
+				ctx = middleware.WithRequestTimeout(ctx, config.timeout)
+			}
+			if config.rateLimit != nil {
+				ctx = middleware.WithRequestRateLimit(ctx, *config.rateLimit)
+			}
+			if config.bodyLimit != nil {
+				ctx = middleware.WithRequestBodyLimit(ctx, *config.bodyLimit)
+			}
+			if len(config.errorCategories) > 0 {
+				ctx = middleware.WithRequestErrorCategories(ctx, config.errorCategories...)
+			}
+			if config.cors != nil {
+				ctx = middleware.WithRequestCORS(ctx, *config.cors)
+			}
+			if config.metadata != nil {
+				ctx = context.WithValue(ctx, routeMetadataContextKey{}, config.metadata)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		},
+	)
+}
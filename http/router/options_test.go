@@ -0,0 +1,201 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golibry/go-http/http/httperr"
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type RouteOptionsTestSuite struct {
+	suite.Suite
+}
+
+func TestRouteOptionsSuite(t *testing.T) {
+	suite.Run(t, new(RouteOptionsTestSuite))
+}
+
+func (suite *RouteOptionsTestSuite) TestItCanDeclareTimeoutAtRegistration() {
+	namedMiddlewares := []NamedMiddleware{
+		{
+			Name: "timeout",
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewTimeoutMiddleware(
+					next,
+					nil,
+					middleware.TimeoutOptions{Timeout: time.Second},
+				)
+			},
+		},
+	}
+
+	slowHandler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(100 * time.Millisecond):
+				w.WriteHeader(http.StatusOK)
+			}
+		},
+	)
+
+	mux := NewServerMuxWrapper(namedMiddlewares)
+	mux.Handle("/default", slowHandler)
+	mux.Handle("/short", slowHandler, WithTimeout(20*time.Millisecond))
+	mux.HandleWithCustomMiddlewares(
+		"/custom", slowHandler, nil, WithTimeout(20*time.Millisecond),
+	)
+
+	testCases := map[string]int{
+		"/default": http.StatusOK,
+		"/short":   http.StatusRequestTimeout,
+		"/custom":  http.StatusRequestTimeout,
+	}
+
+	for path, expectedCode := range testCases {
+		suite.Run(
+			path, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+				suite.Equal(expectedCode, recorder.Code)
+			},
+		)
+	}
+}
+
+func (suite *RouteOptionsTestSuite) TestItCanAttachRouteMetadata() {
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if metadata, ok := MetadataFromContext(r.Context()); ok && metadata.Public {
+					next.ServeHTTP(w, r)
+					return
+				}
+				if r.Header.Get("Authorization") == "" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+
+	metadata := RouteMetadata{
+		Summary: "Health check",
+		Tags:    []string{"ops"},
+		Public:  true,
+		Extra:   map[string]interface{}{"owner": "platform"},
+	}
+
+	mux := NewServerMuxWrapper([]NamedMiddleware{{Name: "auth", Middleware: authMiddleware}})
+	mux.Handle("GET /health", testHandler(), WithMetadata(metadata))
+	mux.Handle("GET /users", testHandler())
+
+	testCases := map[string]int{
+		"/health": http.StatusOK,
+		"/users":  http.StatusUnauthorized,
+	}
+
+	for path, expectedCode := range testCases {
+		suite.Run(
+			path, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+				suite.Equal(expectedCode, recorder.Code)
+			},
+		)
+	}
+
+	routes := mux.Routes()
+	suite.Require().Len(routes, 2)
+	suite.Equal(metadata, routes[0].Metadata)
+	suite.Equal(RouteMetadata{}, routes[1].Metadata)
+}
+
+func (suite *RouteOptionsTestSuite) TestItCanConfigureMiddlewaresPerRoute() {
+	errPaymentDeclined := errors.New("payment declined")
+	paymentCategory := httperr.NewErrorCategory(http.StatusPaymentRequired)
+	paymentCategory.AddSentinelError(errPaymentDeclined)
+
+	namedMiddlewares := []NamedMiddleware{
+		{
+			Name: "bodylimit",
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewBodyLimit(next, middleware.BodyLimitOptions{MaxBytes: 8})
+			},
+		},
+		{
+			Name: "ratelimit",
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewRateLimiter(next, nil, middleware.RateLimiterOptions{})
+			},
+		},
+	}
+
+	failing := middleware.NewErrorhandler(
+		middleware.CustomHandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) error {
+				return errPaymentDeclined
+			},
+		),
+		context.Background(),
+		nil,
+		middleware.ErrorhandlerOptions{},
+	)
+
+	mux := NewServerMuxWrapper(namedMiddlewares)
+	mux.Handle("POST /upload", testHandler())
+	mux.Handle("POST /upload/large", testHandler(), WithBodyLimit(1024))
+	mux.Handle("GET /login", testHandler(), WithRateLimit(1, time.Minute))
+	mux.Handle("GET /pay", failing, WithErrorCategories(paymentCategory))
+	mux.Handle("GET /fail", failing)
+
+	testCases := map[string]struct {
+		requests     []*http.Request
+		expectedCode int
+	}{
+		"default body limit": {
+			requests:     []*http.Request{httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("0123456789"))},
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		"route body limit": {
+			requests:     []*http.Request{httptest.NewRequest(http.MethodPost, "/upload/large", strings.NewReader("0123456789"))},
+			expectedCode: http.StatusOK,
+		},
+		"route rate limit": {
+			requests: []*http.Request{
+				httptest.NewRequest(http.MethodGet, "/login", nil),
+				httptest.NewRequest(http.MethodGet, "/login", nil),
+			},
+			expectedCode: http.StatusTooManyRequests,
+		},
+		"route error categories": {
+			requests:     []*http.Request{httptest.NewRequest(http.MethodGet, "/pay", nil)},
+			expectedCode: http.StatusPaymentRequired,
+		},
+		"default error categories": {
+			requests:     []*http.Request{httptest.NewRequest(http.MethodGet, "/fail", nil)},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				var recorder *httptest.ResponseRecorder
+				for _, request := range tc.requests {
+					recorder = httptest.NewRecorder()
+					mux.ServeHTTP(recorder, request)
+				}
+				suite.Equal(tc.expectedCode, recorder.Code)
+			},
+		)
+	}
+}
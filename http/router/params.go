@@ -0,0 +1,138 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingParam is wrapped by ParamError when the path parameter is absent or empty
+var ErrMissingParam = errors.New("missing value")
+
+// ParamError reports a path parameter that is missing or failed conversion/validation.
+// It implements httperr.HTTPError with a 400 status, so returning it from a
+// CustomHandler (or passing it to the error response builder) answers with 400.
+type ParamError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid path parameter %q: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the conversion or validation error
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements the HTTPError interface
+func (e *ParamError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Param returns the path parameter matched by a {name} wildcard of the route pattern,
+// failing with a ParamError when it is empty
+func Param(r *http.Request, name string) (string, error) {
+	value := r.PathValue(name)
+	if value == "" {
+		return "", &ParamError{Name: name, Err: ErrMissingParam}
+	}
+	return value, nil
+}
+
+// ParamInt returns the path parameter converted to int
+func ParamInt(r *http.Request, name string) (int, error) {
+	return convertParam(r, name, "an integer", strconv.Atoi)
+}
+
+// ParamInt64 returns the path parameter converted to int64
+func ParamInt64(r *http.Request, name string) (int64, error) {
+	return convertParam(
+		r, name, "an integer", func(value string) (int64, error) {
+			return strconv.ParseInt(value, 10, 64)
+		},
+	)
+}
+
+// ParamUint64 returns the path parameter converted to uint64
+func ParamUint64(r *http.Request, name string) (uint64, error) {
+	return convertParam(
+		r, name, "a non-negative integer", func(value string) (uint64, error) {
+			return strconv.ParseUint(value, 10, 64)
+		},
+	)
+}
+
+// ParamFloat64 returns the path parameter converted to float64
+func ParamFloat64(r *http.Request, name string) (float64, error) {
+	return convertParam(
+		r, name, "a number", func(value string) (float64, error) {
+			return strconv.ParseFloat(value, 64)
+		},
+	)
+}
+
+// ParamBool returns the path parameter converted with strconv.ParseBool
+func ParamBool(r *http.Request, name string) (bool, error) {
+	return convertParam(r, name, "a boolean", strconv.ParseBool)
+}
+
+// ParamIntRange returns the path parameter converted to int, validated to be within
+// [minValue, maxValue]
+func ParamIntRange(r *http.Request, name string, minValue, maxValue int) (int, error) {
+	value, err := ParamInt(r, name)
+	if err != nil {
+		return 0, err
+	}
+	if value < minValue || value > maxValue {
+		return 0, &ParamError{
+			Name:  name,
+			Value: r.PathValue(name),
+			Err:   fmt.Errorf("must be between %d and %d", minValue, maxValue),
+		}
+	}
+	return value, nil
+}
+
+// ParamOneOf returns the path parameter, validated to be one of the allowed values
+func ParamOneOf(r *http.Request, name string, allowed ...string) (string, error) {
+	value, err := Param(r, name)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return value, nil
+		}
+	}
+	return "", &ParamError{
+		Name:  name,
+		Value: value,
+		Err:   fmt.Errorf("must be one of %s", strings.Join(allowed, ", ")),
+	}
+}
+
+// convertParam reads the path parameter and converts it, wrapping failures in ParamError
+func convertParam[T any](
+	r *http.Request,
+	name string,
+	expected string,
+	convert func(string) (T, error),
+) (T, error) {
+	var zero T
+
+	value, err := Param(r, name)
+	if err != nil {
+		return zero, err
+	}
+
+	converted, err := convert(value)
+	if err != nil {
+		return zero, &ParamError{Name: name, Value: value, Err: fmt.Errorf("must be %s", expected)}
+	}
+	return converted, nil
+}
@@ -0,0 +1,153 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParamsTestSuite struct {
+	suite.Suite
+}
+
+func TestParamsSuite(t *testing.T) {
+	suite.Run(t, new(ParamsTestSuite))
+}
+
+func (suite *ParamsTestSuite) requestWithParam(name, value string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.SetPathValue(name, value)
+	return request
+}
+
+func (suite *ParamsTestSuite) TestItCanConvertParams() {
+	request := suite.requestWithParam("id", "42")
+
+	intValue, err := ParamInt(request, "id")
+	suite.Require().NoError(err)
+	suite.Equal(42, intValue)
+
+	int64Value, err := ParamInt64(request, "id")
+	suite.Require().NoError(err)
+	suite.Equal(int64(42), int64Value)
+
+	uintValue, err := ParamUint64(request, "id")
+	suite.Require().NoError(err)
+	suite.Equal(uint64(42), uintValue)
+
+	floatValue, err := ParamFloat64(request, "id")
+	suite.Require().NoError(err)
+	suite.Equal(42.0, floatValue)
+
+	rangeValue, err := ParamIntRange(request, "id", 1, 100)
+	suite.Require().NoError(err)
+	suite.Equal(42, rangeValue)
+
+	boolValue, err := ParamBool(suite.requestWithParam("active", "true"), "active")
+	suite.Require().NoError(err)
+	suite.True(boolValue)
+
+	oneOfValue, err := ParamOneOf(suite.requestWithParam("sort", "asc"), "sort", "asc", "desc")
+	suite.Require().NoError(err)
+	suite.Equal("asc", oneOfValue)
+}
+
+func (suite *ParamsTestSuite) TestItReportsInvalidParams() {
+	testCases := map[string]struct {
+		extract       func(r *http.Request) error
+		value         string
+		expectedError string
+	}{
+		"missing": {
+			extract: func(r *http.Request) error {
+				_, err := ParamInt(r, "other")
+				return err
+			},
+			value:         "42",
+			expectedError: `invalid path parameter "other": missing value`,
+		},
+		"not an integer": {
+			extract: func(r *http.Request) error {
+				_, err := ParamInt(r, "id")
+				return err
+			},
+			value:         "abc",
+			expectedError: `invalid path parameter "id": must be an integer`,
+		},
+		"negative unsigned": {
+			extract: func(r *http.Request) error {
+				_, err := ParamUint64(r, "id")
+				return err
+			},
+			value:         "-1",
+			expectedError: `invalid path parameter "id": must be a non-negative integer`,
+		},
+		"out of range": {
+			extract: func(r *http.Request) error {
+				_, err := ParamIntRange(r, "id", 1, 10)
+				return err
+			},
+			value:         "42",
+			expectedError: `invalid path parameter "id": must be between 1 and 10`,
+		},
+		"not allowed": {
+			extract: func(r *http.Request) error {
+				_, err := ParamOneOf(r, "id", "asc", "desc")
+				return err
+			},
+			value:         "random",
+			expectedError: `invalid path parameter "id": must be one of asc, desc`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				err := testCase.extract(suite.requestWithParam("id", testCase.value))
+
+				var paramErr *ParamError
+				suite.Require().ErrorAs(err, &paramErr)
+				suite.Equal(testCase.expectedError, err.Error())
+				suite.Equal(http.StatusBadRequest, paramErr.StatusCode())
+			},
+		)
+	}
+
+	_, err := Param(suite.requestWithParam("id", ""), "id")
+	suite.True(errors.Is(err, ErrMissingParam))
+}
+
+func (suite *ParamsTestSuite) TestItAnswersBadRequestOnConversionFailure() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle(
+		"GET /users/{id}",
+		middleware.NewErrorhandler(
+			middleware.CustomHandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) error {
+					if _, err := ParamInt(r, "id"); err != nil {
+						return err
+					}
+					w.WriteHeader(http.StatusOK)
+					return nil
+				},
+			),
+			context.Background(),
+			nil,
+			middleware.ErrorhandlerOptions{},
+		),
+	)
+
+	badRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(badRecorder, httptest.NewRequest(http.MethodGet, "/users/abc", nil))
+	suite.Equal(http.StatusBadRequest, badRecorder.Code)
+	suite.Equal(`invalid path parameter "id": must be an integer`, badRecorder.Body.String())
+
+	okRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(okRecorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	suite.Equal(http.StatusOK, okRecorder.Code)
+}
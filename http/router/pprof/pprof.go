@@ -0,0 +1,51 @@
+// Package pprof mounts the net/http/pprof profiling handlers on a router mux. It lives
+// apart from the router as importing net/http/pprof registers unauthenticated handlers
+// on http.DefaultServeMux, which only the programs importing this package opt into.
+package pprof
+
+import (
+	"net/http"
+	netpprof "net/http/pprof"
+
+	"github.com/golibry/go-http/http/router"
+)
+
+// Prefix is the path the profiling handlers are mounted at. net/http/pprof resolves the
+// profile names relative to it, so it cannot be changed.
+const Prefix = "/debug/pprof"
+
+// Mount registers the net/http/pprof handlers under Prefix, behind the auth middleware
+// (see router.Authorize and router.AllowNetworks), which is required as profiles expose
+// the application internals and CPU profiling is costly. The overrides apply to the mux
+// defaults as for any route, e.g. router.Disable(router.MiddlewareTimeout) to allow CPU
+// profiles and traces longer than the request timeout.
+// The handlers are also registered on http.DefaultServeMux, which must therefore never
+// be served (e.g. by a server created with a nil handler).
+func Mount(
+	mux *router.ServerMuxWrapper,
+	auth router.NamedMiddleware,
+	overrides ...router.NamedMiddleware,
+) {
+	if auth.Middleware == nil {
+		panic("pprof: Mount requires an auth middleware")
+	}
+
+	middlewares := append([]router.NamedMiddleware{auth}, overrides...)
+	mux.HandleWithCustomMiddlewares(
+		http.MethodGet+" "+Prefix+"/", http.HandlerFunc(netpprof.Index), middlewares,
+	)
+	mux.HandleWithCustomMiddlewares(
+		http.MethodGet+" "+Prefix+"/cmdline", http.HandlerFunc(netpprof.Cmdline), middlewares,
+	)
+	mux.HandleWithCustomMiddlewares(
+		http.MethodGet+" "+Prefix+"/profile", http.HandlerFunc(netpprof.Profile), middlewares,
+	)
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		mux.HandleWithCustomMiddlewares(
+			method+" "+Prefix+"/symbol", http.HandlerFunc(netpprof.Symbol), middlewares,
+		)
+	}
+	mux.HandleWithCustomMiddlewares(
+		http.MethodGet+" "+Prefix+"/trace", http.HandlerFunc(netpprof.Trace), middlewares,
+	)
+}
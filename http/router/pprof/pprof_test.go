@@ -0,0 +1,88 @@
+package pprof
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/router"
+	"github.com/stretchr/testify/suite"
+)
+
+type PprofSuite struct {
+	suite.Suite
+}
+
+func TestPprofSuite(t *testing.T) {
+	suite.Run(t, new(PprofSuite))
+}
+
+func (suite *PprofSuite) TestItCanMountProtectedProfilingHandlers() {
+	mux := router.NewServerMuxWrapper(nil)
+	Mount(
+		mux, router.Authorize(
+			func(r *http.Request) bool {
+				user, pass, ok := r.BasicAuth()
+				return ok && user == "admin" && pass == "secret"
+			},
+		),
+	)
+
+	testCases := map[string]struct {
+		path             string
+		authenticate     bool
+		expectedCode     int
+		expectedContains string
+	}{
+		"index": {
+			path:             Prefix + "/",
+			authenticate:     true,
+			expectedCode:     http.StatusOK,
+			expectedContains: "goroutine",
+		},
+		"named profile": {
+			path:             Prefix + "/goroutine?debug=1",
+			authenticate:     true,
+			expectedCode:     http.StatusOK,
+			expectedContains: "goroutine profile",
+		},
+		"command line": {
+			path:         Prefix + "/cmdline",
+			authenticate: true,
+			expectedCode: http.StatusOK,
+		},
+		"unauthenticated": {
+			path:         Prefix + "/",
+			expectedCode: http.StatusUnauthorized,
+		},
+		"unauthenticated profile": {
+			path:         Prefix + "/heap",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+				if testCase.authenticate {
+					request.SetBasicAuth("admin", "secret")
+				}
+				recorder := httptest.NewRecorder()
+
+				mux.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedCode, recorder.Code)
+				suite.Contains(recorder.Body.String(), testCase.expectedContains)
+			},
+		)
+	}
+}
+
+func (suite *PprofSuite) TestItRequiresAnAuthMiddleware() {
+	suite.PanicsWithValue(
+		"pprof: Mount requires an auth middleware", func() {
+			Mount(router.NewServerMuxWrapper(nil), router.NamedMiddleware{})
+		},
+	)
+}
@@ -0,0 +1,151 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrAmbiguousRoute is wrapped by the panic raised in strict routing mode when a route
+// overlaps an already registered one
+var ErrAmbiguousRoute = errors.New("ambiguous route")
+
+// SetStrictRouting controls how overlapping routes are registered. By default the
+// http.ServeMux precedence applies, so the most specific pattern serves the requests
+// matched by several routes:
+//   - a literal segment wins over a {name} wildcard, which wins over a {name...} wildcard
+//     or a trailing slash ("/users/me" over "/users/{id}" over "/users/")
+//   - a pattern with a host wins over a pattern without one
+//   - a pattern with a method wins over a pattern without one (GET patterns also
+//     match HEAD requests)
+//
+// Patterns none of which is more specific than the other panic at registration.
+// With strict routing enabled, registering a route able to match a request also matched
+// by an existing route panics with an error wrapping ErrAmbiguousRoute, instead of
+// letting the more specific one silently shadow the other. Routes only differing by
+// their wildcard names and constraints are exempted, since they are tried in turn (see
+// Handle), and so are root catch-all routes ("/", e.g. Mount("/", ...)), which only
+// serve the requests matching no other route. Routes registered before enabling it
+// are checked against routes registered after.
+func (mux *ServerMuxWrapper) SetStrictRouting(enabled bool) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.strictRouting = enabled
+}
+
+// checkAmbiguity panics when strict routing is enabled and the ServeMux pattern overlaps
+// a registered route
+func (mux *ServerMuxWrapper) checkAmbiguity(muxPattern string) {
+	mux.routesMu.RLock()
+	defer mux.routesMu.RUnlock()
+
+	if !mux.strictRouting {
+		return
+	}
+
+	method, host, path := splitPattern(muxPattern)
+	if isRootCatchAll(path) {
+		return
+	}
+	shape, _ := patternShape(path)
+	for _, route := range mux.routes {
+		if isRootCatchAll(route.Path) {
+			continue
+		}
+		// Routes only differing by their wildcard names fall through their constraints
+		if method == route.Method && host == route.Host {
+			if routeShape, _ := patternShape(route.Path); routeShape == shape {
+				continue
+			}
+		}
+		if methodsOverlap(method, route.Method) && hostsOverlap(host, route.Host) &&
+			segmentsOverlap(patternSegments(path), patternSegments(route.Path)) {
+			panic(
+				fmt.Errorf(
+					"%w: pattern %q overlaps the pattern %q of an existing route",
+					ErrAmbiguousRoute, muxPattern, route.Pattern,
+				),
+			)
+		}
+	}
+}
+
+// isRootCatchAll reports whether a pattern path matches every path ("/" or
+// "/{path...}"), as a root mount or static file server does, leaving it the requests
+// no other route matches
+func isRootCatchAll(path string) bool {
+	segments := patternSegments(path)
+	return len(segments) == 1 && segments[0].kind == remainderSegment
+}
+
+func methodsOverlap(a string, b string) bool {
+	if a == "" || b == "" || a == b {
+		return true
+	}
+	return (a == http.MethodGet && b == http.MethodHead) ||
+		(a == http.MethodHead && b == http.MethodGet)
+}
+
+func hostsOverlap(a string, b string) bool {
+	return a == "" || b == "" || strings.EqualFold(a, b)
+}
+
+// segmentKind classifies a pattern path segment
+type segmentKind int
+
+const (
+	literalSegment segmentKind = iota
+	wildcardSegment
+	remainderSegment
+)
+
+type patternSegment struct {
+	kind  segmentKind
+	value string
+}
+
+// patternSegments splits a ServeMux pattern path into segments. A trailing slash is an
+// anonymous remainder wildcard and {$} an empty literal segment, matching the exact path.
+func patternSegments(path string) []patternSegment {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	segments := make([]patternSegment, 0, len(parts))
+	for i, part := range parts {
+		switch {
+		case part == "{$}":
+			segments = append(segments, patternSegment{kind: literalSegment})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "...}"):
+			segments = append(segments, patternSegment{kind: remainderSegment})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			segments = append(segments, patternSegment{kind: wildcardSegment})
+		case part == "" && i == len(parts)-1:
+			segments = append(segments, patternSegment{kind: remainderSegment})
+		default:
+			segments = append(segments, patternSegment{kind: literalSegment, value: part})
+		}
+	}
+	return segments
+}
+
+// segmentsOverlap reports whether a request path can match both segment lists
+func segmentsOverlap(a []patternSegment, b []patternSegment) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	if a[0].kind == remainderSegment || b[0].kind == remainderSegment {
+		return true
+	}
+
+	switch {
+	case a[0].kind == literalSegment && b[0].kind == literalSegment:
+		if a[0].value != b[0].value {
+			return false
+		}
+	case a[0].kind == literalSegment && a[0].value == "",
+		b[0].kind == literalSegment && b[0].value == "":
+		// {name} wildcards do not match empty segments
+		return false
+	}
+	return segmentsOverlap(a[1:], b[1:])
+}
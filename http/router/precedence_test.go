@@ -0,0 +1,140 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrecedenceTestSuite struct {
+	suite.Suite
+}
+
+func TestPrecedenceSuite(t *testing.T) {
+	suite.Run(t, new(PrecedenceTestSuite))
+}
+
+func namedHandler(name string) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(name))
+		},
+	)
+}
+
+func (suite *PrecedenceTestSuite) TestItCanServeTheMostSpecificRoute() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("/users/", namedHandler("remainder"))
+	mux.Handle("/users/{id}", namedHandler("wildcard"))
+	mux.Handle("/users/me", namedHandler("literal"))
+	mux.Handle("GET /users/{id}/avatar", namedHandler("method"))
+	mux.Handle("/users/{id}/avatar", namedHandler("any method"))
+
+	testCases := map[string]struct {
+		method   string
+		expected string
+	}{
+		"/users/me":        {method: http.MethodGet, expected: "literal"},
+		"/users/42":        {method: http.MethodGet, expected: "wildcard"},
+		"/users/42/posts":  {method: http.MethodGet, expected: "remainder"},
+		"/users/42/avatar": {method: http.MethodGet, expected: "method"},
+		"/users/7/avatar":  {method: http.MethodPut, expected: "any method"},
+	}
+
+	for target, tc := range testCases {
+		suite.Run(
+			target, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(tc.method, target, nil))
+				suite.Equal(tc.expected, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *PrecedenceTestSuite) TestItCanRejectAmbiguousRoutesInStrictMode() {
+	testCases := map[string]struct {
+		existing  string
+		pattern   string
+		ambiguous bool
+	}{
+		"literal and wildcard":          {existing: "/users/me", pattern: "/users/{id}", ambiguous: true},
+		"wildcard and remainder":        {existing: "/users/{id}", pattern: "/users/{path...}", ambiguous: true},
+		"trailing slash":                {existing: "/users/{id}", pattern: "/users/", ambiguous: true},
+		"root catch all":                {existing: "/health", pattern: "/"},
+		"route after root catch all":    {existing: "GET /{path...}", pattern: "GET /health"},
+		"root remainder":                {existing: "GET /health", pattern: "GET /{path...}"},
+		"root exact path":               {existing: "/{$}", pattern: "/{id}"},
+		"root wildcard":                 {existing: "/health", pattern: "/{page}", ambiguous: true},
+		"method and methodless":         {existing: "GET /users/{id}", pattern: "/users/me", ambiguous: true},
+		"get and head":                  {existing: "GET /users/{id}", pattern: "HEAD /users/me", ambiguous: true},
+		"host and hostless":             {existing: "api.example.com/users", pattern: "/users", ambiguous: true},
+		"different literals":            {existing: "/users/me", pattern: "/users/all"},
+		"different methods":             {existing: "GET /users/{id}", pattern: "POST /users/{id}"},
+		"different hosts":               {existing: "a.example.com/users", pattern: "b.example.com/users"},
+		"different lengths":             {existing: "/users/{id}", pattern: "/users/{id}/posts"},
+		"exact and wildcard":            {existing: "/users/{$}", pattern: "/users/{id}"},
+		"exact and remainder":           {existing: "/users/{$}", pattern: "/users/{path...}", ambiguous: true},
+		"exact path and trailing slash": {existing: "/users", pattern: "/users/"},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				mux := NewServerMuxWrapper(nil)
+				mux.Handle(tc.existing, testHandler())
+				mux.SetStrictRouting(true)
+
+				register := func() { mux.Handle(tc.pattern, testHandler()) }
+				if !tc.ambiguous {
+					suite.NotPanics(register)
+					return
+				}
+
+				defer func() {
+					err, ok := recover().(error)
+					suite.Require().True(ok)
+					suite.True(errors.Is(err, ErrAmbiguousRoute))
+					suite.Contains(err.Error(), tc.existing)
+				}()
+				register()
+			},
+		)
+	}
+}
+
+func (suite *PrecedenceTestSuite) TestItAllowsARootCatchAllInStrictMode() {
+	mux := NewServerMuxWrapper(nil)
+	mux.SetStrictRouting(true)
+	mux.Handle("GET /health", testHandler())
+	mux.Mount(
+		"/", http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("app")) },
+		),
+	)
+	mux.Handle("GET /users/{id}", testHandler())
+
+	testCases := map[string]struct {
+		target       string
+		expectedBody string
+	}{
+		"route registered before": {target: "/health", expectedBody: "OK"},
+		"route registered after":  {target: "/users/42", expectedBody: "OK"},
+		"unmatched path":          {target: "/about", expectedBody: "app"},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(tc.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
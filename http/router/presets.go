@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/golibry/go-http/http/session"
+)
+
+// Names of the middlewares of the predefined stacks, also used by DefaultOrderingRules
+const (
+	MiddlewareRecoverer       = "recoverer"
+	MiddlewareRequestID       = "requestid"
+	MiddlewareAccess          = "access"
+	MiddlewareSession         = "session"
+	MiddlewareCSRF            = "csrf"
+	MiddlewareSecurityHeaders = "security"
+	MiddlewareCORS            = "cors"
+	MiddlewareRateLimit       = "ratelimit"
+	MiddlewareTimeout         = "timeout"
+)
+
+// PresetOptions configures the middlewares of the predefined stacks
+//
+// Context: context given to the recoverer and session middlewares (default: background)
+// SessionManager: session manager of WebDefaults (the session middleware is left out when nil)
+// RateLimit: per client allowance of APIDefaults (default: 100 requests per second)
+// The other fields are the options of the respective middlewares.
+type PresetOptions struct {
+	Context         context.Context
+	SessionManager  session.Manager
+	Recoverer       middleware.RecovererOptions
+	RequestID       middleware.RequestIDOptions
+	AccessLog       middleware.AccessLogOptions
+	CSRF            middleware.CSRFOptions
+	SecurityHeaders middleware.SecurityHeadersOptions
+	CORS            middleware.CORSOptions
+	RateLimit       middleware.RateLimit
+	Timeout         middleware.TimeoutOptions
+}
+
+// WebDefaults returns the stack of a server rendered web application: security headers,
+// request ID, access log, recoverer, session and CSRF protection (outermost first).
+// The chain can be tweaked with the Chain methods before use.
+func WebDefaults(logger *slog.Logger, options PresetOptions) Chain {
+	options = options.withDefaults()
+
+	chain := NewChain(
+		NamedMiddleware{
+			Name: MiddlewareCSRF,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewCSRFMiddleware(next, logger, options.CSRF)
+			},
+		},
+	)
+	if options.SessionManager != nil {
+		chain = chain.Append(
+			NamedMiddleware{
+				Name: MiddlewareSession,
+				Middleware: func(next http.Handler) http.Handler {
+					return middleware.NewSessionMiddleware(
+						next, options.Context, logger, options.SessionManager,
+					)
+				},
+			},
+		)
+	}
+
+	return chain.Append(options.commonMiddlewares(logger)...).Append(
+		NamedMiddleware{
+			Name: MiddlewareSecurityHeaders,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewSecurityHeaders(next, options.SecurityHeaders)
+			},
+		},
+	)
+}
+
+// APIDefaults returns the stack of a JSON API: request ID, access log, recoverer, CORS,
+// rate limit and timeout (outermost first). The chain can be tweaked with the Chain
+// methods before use.
+func APIDefaults(logger *slog.Logger, options PresetOptions) Chain {
+	options = options.withDefaults()
+
+	return NewChain(
+		NamedMiddleware{
+			Name: MiddlewareTimeout,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewTimeoutMiddleware(next, logger, options.Timeout)
+			},
+		},
+		NamedMiddleware{
+			Name: MiddlewareRateLimit,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewRateLimiter(
+					next, logger, middleware.RateLimiterOptions{Limit: options.RateLimit},
+				)
+			},
+		},
+		NamedMiddleware{
+			Name: MiddlewareCORS,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewCORS(next, options.CORS)
+			},
+		},
+	).Append(options.commonMiddlewares(logger)...)
+}
+
+// commonMiddlewares returns the recoverer, access log and request ID middlewares shared
+// by the stacks, innermost first
+func (options PresetOptions) commonMiddlewares(logger *slog.Logger) []NamedMiddleware {
+	return []NamedMiddleware{
+		{
+			Name: MiddlewareRecoverer,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewRecovererWithOptions(
+					next, options.Context, logger, options.Recoverer,
+				)
+			},
+		},
+		{
+			Name: MiddlewareAccess,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewHTTPAccessLogger(next, logger, options.AccessLog)
+			},
+		},
+		{
+			Name: MiddlewareRequestID,
+			Middleware: func(next http.Handler) http.Handler {
+				return middleware.NewRequestID(next, options.RequestID)
+			},
+		},
+	}
+}
+
+func (options PresetOptions) withDefaults() PresetOptions {
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+	if options.RateLimit.Requests == 0 {
+		options.RateLimit = middleware.RateLimit{Requests: 100, Period: time.Second}
+	}
+	return options
+}
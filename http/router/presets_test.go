@@ -0,0 +1,96 @@
+package router
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type PresetsTestSuite struct {
+	suite.Suite
+}
+
+func TestPresetsSuite(t *testing.T) {
+	suite.Run(t, new(PresetsTestSuite))
+}
+
+func (suite *PresetsTestSuite) TestItCanBuildPredefinedStacks() {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	testCases := map[string]struct {
+		chain           Chain
+		expectedNames   []string
+		request         func() *http.Request
+		expectedCode    int
+		expectedHeaders []string
+	}{
+		"web": {
+			chain: WebDefaults(logger, PresetOptions{}),
+			expectedNames: []string{
+				MiddlewareCSRF, MiddlewareRecoverer, MiddlewareAccess, MiddlewareRequestID,
+				MiddlewareSecurityHeaders,
+			},
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/", nil)
+			},
+			expectedCode:    http.StatusForbidden,
+			expectedHeaders: []string{"X-Request-Id", "X-Frame-Options", "Cross-Origin-Opener-Policy"},
+		},
+		"api": {
+			chain: APIDefaults(
+				logger,
+				PresetOptions{CORS: middleware.CORSOptions{AllowedOrigins: []string{"https://app.example.com"}}},
+			),
+			expectedNames: []string{
+				MiddlewareTimeout, MiddlewareRateLimit, MiddlewareCORS, MiddlewareRecoverer,
+				MiddlewareAccess, MiddlewareRequestID,
+			},
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodOptions, "/", nil)
+				request.Header.Set("Origin", "https://app.example.com")
+				request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+				return request
+			},
+			expectedCode:    http.StatusNoContent,
+			expectedHeaders: []string{"X-Request-Id", "Access-Control-Allow-Origin"},
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(tc.expectedNames, tc.chain.Names())
+
+				mux := NewServerMuxWrapper(tc.chain)
+				mux.Handle("/", testHandler())
+				suite.NoError(mux.Validate())
+
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, tc.request())
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				for _, header := range tc.expectedHeaders {
+					suite.NotEmpty(recorder.Header().Get(header), header)
+				}
+			},
+		)
+	}
+}
+
+func (suite *PresetsTestSuite) TestItCanTweakPredefinedStacks() {
+	chain := APIDefaults(slog.New(slog.NewTextHandler(io.Discard, nil)), PresetOptions{}).
+		Remove(MiddlewareCORS).
+		Replace(testNamedMiddleware(MiddlewareTimeout))
+
+	recorder := httptest.NewRecorder()
+	chain.Then(testHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(MiddlewareTimeout, recorder.Header().Get("X-Middleware"))
+	suite.NotContains(chain.Names(), MiddlewareCORS)
+}
@@ -0,0 +1,143 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrUnknownMiddleware        = errors.New("unknown middleware")
+	ErrInvalidMiddlewareOptions = errors.New("invalid middleware options")
+)
+
+// DefaultMiddlewareRegistry is the registry used by the package level registry functions
+var DefaultMiddlewareRegistry = NewMiddlewareRegistry()
+
+// middlewareFactory builds a middleware from the given options, nil meaning the
+// registered default options
+type middlewareFactory func(options interface{}) (func(http.Handler) http.Handler, error)
+
+// MiddlewareRegistry maps middleware names to factories, so chains can be declared as
+// a list of names (e.g. loaded from configuration) and resolved at startup
+type MiddlewareRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]middlewareFactory
+}
+
+// NewMiddlewareRegistry creates an empty registry
+func NewMiddlewareRegistry() *MiddlewareRegistry {
+	return &MiddlewareRegistry{factories: make(map[string]middlewareFactory)}
+}
+
+// Register adds a middleware factory to the registry under the given name, along with
+// the options used when the chain declaration does not provide any. It panics when
+// the name is already registered.
+//
+// Example:
+//
+//	router.Register(registry, "access", func(options middleware.AccessLogOptions) func(http.Handler) http.Handler {
+//		return func(next http.Handler) http.Handler {
+//			return middleware.NewHTTPAccessLogger(next, logger, options)
+//		}
+//	}, middleware.AccessLogOptions{LogClientIp: true})
+func Register[T any](
+	registry *MiddlewareRegistry,
+	name string,
+	factory func(options T) func(http.Handler) http.Handler,
+	defaultOptions T,
+) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, exists := registry.factories[name]; exists {
+		panic(fmt.Sprintf("router: middleware %q is already registered", name))
+	}
+
+	registry.factories[name] = func(options interface{}) (func(http.Handler) http.Handler, error) {
+		if options == nil {
+			return factory(defaultOptions), nil
+		}
+
+		typed, ok := options.(T)
+		if !ok {
+			return nil, fmt.Errorf(
+				"%w: middleware %q expects %T, got %T",
+				ErrInvalidMiddlewareOptions, name, defaultOptions, options,
+			)
+		}
+		return factory(typed), nil
+	}
+}
+
+// RegisterMiddleware registers the factory in DefaultMiddlewareRegistry. See Register.
+func RegisterMiddleware[T any](
+	name string,
+	factory func(options T) func(http.Handler) http.Handler,
+	defaultOptions T,
+) {
+	Register(DefaultMiddlewareRegistry, name, factory, defaultOptions)
+}
+
+// Resolve builds the chain of the named middlewares, the first name being the innermost,
+// using their default options
+func (registry *MiddlewareRegistry) Resolve(names ...string) (Chain, error) {
+	return registry.ResolveWithOptions(names, nil)
+}
+
+// ResolveWithOptions builds the chain of the named middlewares, the first name being
+// the innermost. Options given by name replace the registered default options and must
+// have the type the factory was registered with.
+func (registry *MiddlewareRegistry) ResolveWithOptions(
+	names []string,
+	options map[string]interface{},
+) (Chain, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	chain := make(Chain, 0, len(names))
+	for _, name := range names {
+		factory, exists := registry.factories[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownMiddleware, name)
+		}
+
+		middleware, err := factory(options[name])
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, NamedMiddleware{Name: name, Middleware: middleware})
+	}
+
+	return chain, nil
+}
+
+// MustResolve is like Resolve but panics on error, for chains declared in code
+func (registry *MiddlewareRegistry) MustResolve(names ...string) Chain {
+	chain, err := registry.Resolve(names...)
+	if err != nil {
+		panic(err)
+	}
+	return chain
+}
+
+// Names returns the registered middleware names, sorted
+func (registry *MiddlewareRegistry) Names() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveMiddlewares resolves the names against DefaultMiddlewareRegistry. See
+// MiddlewareRegistry.ResolveWithOptions.
+func ResolveMiddlewares(names []string, options map[string]interface{}) (Chain, error) {
+	return DefaultMiddlewareRegistry.ResolveWithOptions(names, options)
+}
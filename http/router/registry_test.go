@@ -0,0 +1,106 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RegistryTestSuite struct {
+	suite.Suite
+}
+
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func newTestRegistry() *MiddlewareRegistry {
+	registry := NewMiddlewareRegistry()
+	Register(registry, "recoverer", createTestMiddleware, "recoverer")
+	Register(registry, "access", createTestMiddleware, "access")
+	Register(
+		registry, "tag", func(tag int) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("X-Tag", http.StatusText(tag))
+						next.ServeHTTP(w, r)
+					},
+				)
+			}
+		}, http.StatusOK,
+	)
+	return registry
+}
+
+func (suite *RegistryTestSuite) TestItCanResolveChainsByName() {
+	registry := newTestRegistry()
+
+	testCases := map[string]struct {
+		names              []string
+		options            map[string]interface{}
+		expectedMiddleware []string
+		expectedTag        string
+	}{
+		"default options": {
+			names:              []string{"recoverer", "access", "tag"},
+			expectedMiddleware: []string{"access", "recoverer"},
+			expectedTag:        "OK",
+		},
+		"custom options": {
+			names:              []string{"access", "tag"},
+			options:            map[string]interface{}{"access": "audit", "tag": http.StatusAccepted},
+			expectedMiddleware: []string{"audit"},
+			expectedTag:        "Accepted",
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				chain, err := registry.ResolveWithOptions(tc.names, tc.options)
+				suite.Require().NoError(err)
+				suite.Equal(tc.names, chain.Names())
+
+				recorder := httptest.NewRecorder()
+				chain.Then(testHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				suite.Equal(tc.expectedMiddleware, recorder.Header().Values("X-Middleware"))
+				suite.Equal(tc.expectedTag, recorder.Header().Get("X-Tag"))
+			},
+		)
+	}
+}
+
+func (suite *RegistryTestSuite) TestItCanReportResolutionErrors() {
+	registry := newTestRegistry()
+
+	_, err := registry.Resolve("recoverer", "csrf")
+	suite.True(errors.Is(err, ErrUnknownMiddleware))
+	suite.EqualError(err, `unknown middleware: "csrf"`)
+
+	_, err = registry.ResolveWithOptions([]string{"tag"}, map[string]interface{}{"tag": "Accepted"})
+	suite.True(errors.Is(err, ErrInvalidMiddlewareOptions))
+	suite.EqualError(err, `invalid middleware options: middleware "tag" expects int, got string`)
+
+	suite.Panics(func() { registry.MustResolve("csrf") })
+	suite.Panics(func() { Register(registry, "access", createTestMiddleware, "") })
+	suite.Equal([]string{"access", "recoverer", "tag"}, registry.Names())
+}
+
+func (suite *RegistryTestSuite) TestItCanUseTheDefaultRegistry() {
+	RegisterMiddleware("registry-test", createTestMiddleware, "registry-test")
+
+	chain, err := ResolveMiddlewares([]string{"registry-test"}, nil)
+	suite.Require().NoError(err)
+
+	mux := NewServerMuxWrapper(chain)
+	mux.Handle("/", testHandler())
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.Equal("registry-test", recorder.Header().Get("X-Middleware"))
+}
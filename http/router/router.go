@@ -2,6 +2,7 @@ package router
 
 import (
 	"net/http"
+	"sync"
 )
 
 // NamedMiddleware represents middleware with an identifier
@@ -10,7 +11,14 @@ type NamedMiddleware struct {
 	Middleware func(http.Handler) http.Handler
 }
 
-// WithNamedMiddlewares applies named middlewares with selective override capability
+// Disable returns an override removing the named default middleware from the chain of
+// a route or group. An override with a nil Middleware has the same effect.
+func Disable(name string) NamedMiddleware {
+	return NamedMiddleware{Name: name}
+}
+
+// WithNamedMiddlewares applies named middlewares with selective override capability.
+// Overrides with a nil Middleware remove the same-named middleware from the chain.
 func WithNamedMiddlewares(
 	handler http.Handler,
 	namedMiddlewares []NamedMiddleware,
@@ -29,12 +37,15 @@ func WithNamedMiddlewares(
 	// Apply middlewares in the order they appear in namedMiddlewares
 	// This preserves the intended middleware chain order
 	for _, namedMw := range namedMiddlewares {
+		middleware := namedMw.Middleware
 		if overrideMiddleware, exists := overrideMap[namedMw.Name]; exists {
 			// Use override middleware if available
-			handler = overrideMiddleware(handler)
-		} else {
-			// Use original middleware
-			handler = namedMw.Middleware(handler)
+			middleware = overrideMiddleware
+		}
+
+		// Disabled middlewares are left out of the chain
+		if middleware != nil {
+			handler = middleware(handler)
 		}
 	}
 
@@ -50,7 +61,7 @@ func WithNamedMiddlewares(
 					break
 				}
 			}
-			if !found {
+			if !found && override.Middleware != nil {
 				handler = override.Middleware(handler)
 			}
 		}
@@ -62,6 +73,24 @@ func WithNamedMiddlewares(
 type ServerMuxWrapper struct {
 	http.ServeMux
 	defaultNamedMiddlewares []NamedMiddleware
+	routesMu                sync.RWMutex
+	namedRoutes             map[string]string
+	routes                  []Route
+	routeOverrides          [][]NamedMiddleware
+	methods                 map[string]bool
+	notFoundHandler         http.Handler
+	methodNotAllowedHandler http.Handler
+	metricsSink             MetricsSink
+	errorhandler            *errorhandlerConfig
+	strictRouting           bool
+	preflightHandlers       map[string]http.Handler
+	sharedPatterns          map[string]sharedPattern
+}
+
+// sharedPattern is a ServeMux pattern served by constrainedRoutes
+type sharedPattern struct {
+	muxPattern string
+	routes     *constrainedRoutes
 }
 
 // NewServerMuxWrapper creates a new ServerMuxWrapper with named middlewares
@@ -72,9 +101,10 @@ func NewServerMuxWrapper(namedMiddlewares []NamedMiddleware) *ServerMuxWrapper {
 	}
 }
 
-func (mux *ServerMuxWrapper) Handle(pattern string, handler http.Handler) {
-	finalHandler := WithNamedMiddlewares(handler, mux.defaultNamedMiddlewares, nil)
-	mux.ServeMux.Handle(pattern, finalHandler)
+// Handle registers the handler wrapped by the default middlewares and configured by
+// the given route options
+func (mux *ServerMuxWrapper) Handle(pattern string, handler http.Handler, options ...RouteOption) {
+	mux.handle(pattern, handler, nil, options)
 }
 
 // HandleWithCustomMiddlewares allows selective override of default middlewares
@@ -83,7 +113,86 @@ func (mux *ServerMuxWrapper) HandleWithCustomMiddlewares(
 	pattern string,
 	handler http.Handler,
 	overrides []NamedMiddleware,
+	options ...RouteOption,
 ) {
+	mux.handle(pattern, handler, overrides, options)
+}
+
+func (mux *ServerMuxWrapper) handle(
+	pattern string,
+	handler http.Handler,
+	overrides []NamedMiddleware,
+	options []RouteOption,
+) {
+	config := newRouteConfig(options)
+	muxPattern, constraints := parseConstraints(pattern)
+	mux.checkAmbiguity(muxPattern)
 	finalHandler := WithNamedMiddlewares(handler, mux.defaultNamedMiddlewares, overrides)
-	mux.ServeMux.Handle(pattern, finalHandler)
+	servedPattern := mux.serve(muxPattern, constraints, config.wrap(finalHandler))
+
+	mux.registerMethod(muxPattern)
+	if config.cors != nil {
+		mux.registerPreflight(servedPattern, config, overrides)
+	}
+	if config.name != "" {
+		mux.registerName(config.name, muxPattern)
+	}
+
+	var identified interface{} = handler
+	if config.identity != nil {
+		identified = config.identity
+	}
+	mux.registerRoute(
+		pattern,
+		muxPattern,
+		config,
+		overrides,
+		identified,
+	)
+}
+
+// serve registers the handler with ServeMux and returns the ServeMux pattern serving it.
+// Patterns with wildcards are served by constrainedRoutes, which the routes only
+// differing from them by their wildcard names and constraints join, so requests fall
+// through their constraints.
+func (mux *ServerMuxWrapper) serve(
+	muxPattern string,
+	constraints paramConstraints,
+	handler http.Handler,
+) string {
+	shape, names := patternShape(muxPattern)
+	if len(names) == 0 {
+		mux.ServeMux.Handle(muxPattern, handler)
+		return muxPattern
+	}
+
+	route := constrainedRoute{
+		pattern:     muxPattern,
+		names:       names,
+		constraints: constraints,
+		handler:     handler,
+	}
+
+	mux.routesMu.Lock()
+	shared, ok := mux.sharedPatterns[shape]
+	if !ok {
+		if mux.sharedPatterns == nil {
+			mux.sharedPatterns = make(map[string]sharedPattern)
+		}
+		shared = sharedPattern{
+			muxPattern: muxPattern,
+			routes: &constrainedRoutes{
+				names:  names,
+				reject: http.HandlerFunc(mux.serveNotFound),
+			},
+		}
+		mux.sharedPatterns[shape] = shared
+	}
+	mux.routesMu.Unlock()
+
+	shared.routes.add(route)
+	if !ok {
+		mux.ServeMux.Handle(muxPattern, shared.routes)
+	}
+	return shared.muxPattern
 }
@@ -131,4 +131,39 @@ func (suite *RouterTestSuite) TestItHandlesEmptyOverrides() {
 	middlewareHeaders := recorder.Header().Values("X-Middleware")
 	// Middlewares are applied in reverse order (last wraps first)
 	assert.Equal(suite.T(), []string{"second", "first"}, middlewareHeaders)
-}
\ No newline at end of file
+}
+
+func (suite *RouterTestSuite) TestItCanDisableNamedMiddlewaresPerRoute() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{
+			{Name: "session", Middleware: createTestMiddleware("session")},
+			{Name: "csrf", Middleware: createTestMiddleware("csrf")},
+			{Name: "logging", Middleware: createTestMiddleware("logging")},
+		},
+	)
+	mux.Handle("/default", testHandler())
+	mux.HandleWithCustomMiddlewares("/api", testHandler(), []NamedMiddleware{Disable("session"), Disable("csrf")})
+	mux.HandleWithCustomMiddlewares("/webhook", testHandler(), []NamedMiddleware{{Name: "csrf"}, Disable("unknown")})
+	mux.Group("/public", Disable("session")).Handle("/page", testHandler())
+
+	testCases := map[string][]string{
+		"/default":     {"logging", "csrf", "session"},
+		"/api":         {"logging"},
+		"/webhook":     {"logging", "session"},
+		"/public/page": {"logging", "csrf"},
+	}
+
+	for path, expectedMiddlewares := range testCases {
+		suite.Run(
+			path, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+
+	suite.Equal([]string{"logging"}, mux.Routes()[1].Middlewares)
+}
@@ -0,0 +1,109 @@
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// Route describes a registered route
+//
+// Pattern: the registered pattern, including the group prefix and constraints
+// Method, Host, Path: the ServeMux pattern parts, constraints stripped (Method and Host
+// are empty when not restricted)
+// Name: the route name given with WithName
+// Middlewares: names of the effective middleware chain, innermost first
+// Handler: handler identifier, the function name for function values (e.g.
+// http.HandlerFunc), the type otherwise (for mounted handlers, the handler given to Mount)
+// Metadata: the metadata given with WithMetadata
+type Route struct {
+	Pattern     string        `json:"pattern"`
+	Method      string        `json:"method,omitempty"`
+	Host        string        `json:"host,omitempty"`
+	Path        string        `json:"path"`
+	Name        string        `json:"name,omitempty"`
+	Middlewares []string      `json:"middlewares"`
+	Handler     string        `json:"handler"`
+	Metadata    RouteMetadata `json:"metadata"`
+}
+
+// Routes returns the registered routes in registration order
+func (mux *ServerMuxWrapper) Routes() []Route {
+	mux.routesMu.RLock()
+	defer mux.routesMu.RUnlock()
+
+	routes := make([]Route, len(mux.routes))
+	for i, route := range mux.routes {
+		route.Middlewares = append([]string(nil), route.Middlewares...)
+		routes[i] = route
+	}
+	return routes
+}
+
+func (mux *ServerMuxWrapper) registerRoute(
+	pattern string,
+	muxPattern string,
+	config *routeConfig,
+	overrides []NamedMiddleware,
+	handler interface{},
+) {
+	method, host, path := splitPattern(muxPattern)
+	var metadata RouteMetadata
+	if config.metadata != nil {
+		metadata = *config.metadata
+	}
+
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	mux.routes = append(
+		mux.routes, Route{
+			Pattern:     pattern,
+			Method:      method,
+			Host:        host,
+			Path:        path,
+			Name:        config.name,
+			Middlewares: middlewareNames(mux.defaultNamedMiddlewares, overrides),
+			Handler:     handlerName(handler),
+			Metadata:    metadata,
+		},
+	)
+	mux.routeOverrides = append(mux.routeOverrides, overrides)
+}
+
+// middlewareNames returns the names of the chain built by WithNamedMiddlewares,
+// leaving out disabled middlewares
+func middlewareNames(namedMiddlewares []NamedMiddleware, overrides []NamedMiddleware) []string {
+	overrideMap := make(map[string]NamedMiddleware, len(overrides))
+	for _, override := range overrides {
+		overrideMap[override.Name] = override
+	}
+
+	names := make([]string, 0, len(namedMiddlewares)+len(overrides))
+	known := make(map[string]bool, len(namedMiddlewares))
+	for _, namedMw := range namedMiddlewares {
+		known[namedMw.Name] = true
+		if override, exists := overrideMap[namedMw.Name]; exists {
+			namedMw = override
+		}
+		if namedMw.Middleware != nil {
+			names = append(names, namedMw.Name)
+		}
+	}
+	for _, override := range overrides {
+		if !known[override.Name] && override.Middleware != nil {
+			names = append(names, override.Name)
+		}
+	}
+	return names
+}
+
+// handlerName identifies a handler for introspection
+func handlerName(handler interface{}) string {
+	if value := reflect.ValueOf(handler); value.Kind() == reflect.Func {
+		if fn := runtime.FuncForPC(value.Pointer()); fn != nil {
+			return fn.Name()
+		}
+	}
+	return fmt.Sprintf("%T", handler)
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RoutesTestSuite struct {
+	suite.Suite
+}
+
+func TestRoutesSuite(t *testing.T) {
+	suite.Run(t, new(RoutesTestSuite))
+}
+
+func showUser(w http.ResponseWriter, r *http.Request) {}
+
+func (suite *RoutesTestSuite) TestItCanListRegisteredRoutes() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{
+			{Name: "recoverer", Middleware: createTestMiddleware("recoverer")},
+			{Name: "logging", Middleware: createTestMiddleware("logging")},
+		},
+	)
+	mux.Handle("GET /users/{id}", http.HandlerFunc(showUser), WithName("user.show"))
+	mux.Group("/api", NamedMiddleware{Name: "auth", Middleware: createTestMiddleware("auth")}).
+		HandleWithCustomMiddlewares(
+			"POST example.com/orders",
+			http.NotFoundHandler(),
+			[]NamedMiddleware{{Name: "logging", Middleware: createTestMiddleware("quiet")}},
+		)
+	mux.Mount("/admin", NewServerMuxWrapper(nil))
+
+	suite.Equal(
+		[]Route{
+			{
+				Pattern:     "GET /users/{id}",
+				Method:      http.MethodGet,
+				Path:        "/users/{id}",
+				Name:        "user.show",
+				Middlewares: []string{"recoverer", "logging"},
+				Handler:     "github.com/golibry/go-http/http/router.showUser",
+			},
+			{
+				Pattern:     "POST example.com/api/orders",
+				Method:      http.MethodPost,
+				Host:        "example.com",
+				Path:        "/api/orders",
+				Middlewares: []string{"recoverer", "logging", "auth"},
+				Handler:     "net/http.NotFound",
+			},
+			{
+				Pattern:     "/admin/",
+				Path:        "/admin/",
+				Middlewares: []string{"recoverer", "logging"},
+				Handler:     "*router.ServerMuxWrapper",
+			},
+		},
+		mux.Routes(),
+	)
+}
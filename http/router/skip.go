@@ -0,0 +1,52 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Skip returns the named middleware bypassed for requests matching the predicate, e.g.
+// CSRF protection skipped for webhooks. The name is kept, so the result can be used
+// in the default list or as an override.
+func Skip(namedMiddleware NamedMiddleware, skip func(r *http.Request) bool) NamedMiddleware {
+	return NamedMiddleware{
+		Name: namedMiddleware.Name,
+		Middleware: func(next http.Handler) http.Handler {
+			wrapped := namedMiddleware.Middleware(next)
+			return http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if skip(r) {
+						next.ServeHTTP(w, r)
+						return
+					}
+					wrapped.ServeHTTP(w, r)
+				},
+			)
+		},
+	}
+}
+
+// MatchPaths returns a predicate matching requests for exactly one of the paths
+func MatchPaths(paths ...string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		for _, path := range paths {
+			if r.URL.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchPathPrefixes returns a predicate matching requests whose path starts with one
+// of the prefixes
+func MatchPathPrefixes(prefixes ...string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
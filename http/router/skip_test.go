@@ -0,0 +1,53 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SkipTestSuite struct {
+	suite.Suite
+}
+
+func TestSkipSuite(t *testing.T) {
+	suite.Run(t, new(SkipTestSuite))
+}
+
+func (suite *SkipTestSuite) TestItCanSkipMiddlewaresForMatchingRequests() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{
+			Skip(
+				NamedMiddleware{Name: "csrf", Middleware: createTestMiddleware("csrf")},
+				MatchPathPrefixes("/webhooks/"),
+			),
+			Skip(
+				NamedMiddleware{Name: "access", Middleware: createTestMiddleware("access")},
+				MatchPaths("/healthz"),
+			),
+		},
+	)
+	mux.Handle("/", testHandler())
+
+	testCases := map[string][]string{
+		"/users":           {"access", "csrf"},
+		"/webhooks/stripe": {"access"},
+		"/healthz":         {"csrf"},
+	}
+
+	for path, expectedMiddlewares := range testCases {
+		suite.Run(
+			path, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(expectedMiddlewares, recorder.Header().Values("X-Middleware"))
+			},
+		)
+	}
+
+	suite.Equal([]string{"csrf", "access"}, mux.Routes()[0].Middlewares)
+}
@@ -0,0 +1,125 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/golibry/go-http/http/router/middleware"
+)
+
+// StaticOptions configures static file serving
+//
+// Browse: list directories without an index file (default: disabled, answering 404)
+// IndexFiles: files served for directory requests, in order (default: "index.html")
+// DisableETag: do not send the weak ETag derived from file size and modification time
+// CacheRules: Cache-Control policies applied through middleware.CacheControl, matched
+// against the full request path (prefix included)
+// Middlewares: overrides of the default middlewares, as for HandleWithCustomMiddlewares
+type StaticOptions struct {
+	Browse      bool
+	IndexFiles  []string
+	DisableETag bool
+	CacheRules  []middleware.CacheRule
+	Middlewares []NamedMiddleware
+}
+
+// Static serves the files of fsys under the prefix for GET and HEAD requests, with
+// content types, Last-Modified/ETag validation and range requests handled by
+// http.ServeContent. Missing files are answered by the NotFound handler when set.
+func (mux *ServerMuxWrapper) Static(prefix string, fsys fs.FS, options StaticOptions) {
+	if len(options.IndexFiles) == 0 {
+		options.IndexFiles = []string{"index.html"}
+	}
+
+	prefix = cleanPrefix(prefix)
+	var handler http.Handler = http.StripPrefix(
+		prefix,
+		&staticHandler{mux: mux, fsys: fsys, options: options},
+	)
+	if len(options.CacheRules) > 0 {
+		handler = middleware.NewCacheControl(
+			handler,
+			middleware.CacheControlOptions{Rules: options.CacheRules},
+		)
+	}
+
+	mux.handle(http.MethodGet+" "+prefix+"/", handler, options.Middlewares, nil)
+}
+
+// staticHandler serves files of a fs.FS for requests already stripped of the prefix
+type staticHandler struct {
+	mux     *ServerMuxWrapper
+	fsys    fs.FS
+	options StaticOptions
+}
+
+func (sh *staticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(sh.fsys, name)
+	if err != nil {
+		sh.mux.serveNotFound(w, r)
+		return
+	}
+
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			// Relative redirect, as the request path no longer holds the prefix
+			w.Header().Set("Location", path.Base(r.URL.Path)+"/")
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+
+		for _, indexFile := range sh.options.IndexFiles {
+			indexName := path.Join(name, indexFile)
+			if indexInfo, err := fs.Stat(sh.fsys, indexName); err == nil && !indexInfo.IsDir() {
+				sh.serveFile(w, r, indexName, indexInfo)
+				return
+			}
+		}
+
+		if sh.options.Browse {
+			http.FileServerFS(sh.fsys).ServeHTTP(w, r)
+			return
+		}
+
+		sh.mux.serveNotFound(w, r)
+		return
+	}
+
+	sh.serveFile(w, r, name, info)
+}
+
+func (sh *staticHandler) serveFile(w http.ResponseWriter, r *http.Request, name string, info fs.FileInfo) {
+	file, err := sh.fsys.Open(name)
+	if err != nil {
+		sh.mux.serveNotFound(w, r)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	// Files of some fs.FS implementations cannot seek, they are buffered instead
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+
+	if !sh.options.DisableETag && w.Header().Get("ETag") == "" {
+		w.Header().Set("ETag", fmt.Sprintf(`W/"%x-%x"`, info.Size(), info.ModTime().UnixNano()))
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
+}
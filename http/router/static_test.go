@@ -0,0 +1,151 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/golibry/go-http/http/router/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type StaticTestSuite struct {
+	suite.Suite
+	fsys fstest.MapFS
+}
+
+func TestStaticSuite(t *testing.T) {
+	suite.Run(t, new(StaticTestSuite))
+}
+
+func (suite *StaticTestSuite) SetupTest() {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	suite.fsys = fstest.MapFS{
+		"app.3f2a.js":        {Data: []byte("console.log(1)"), ModTime: modTime},
+		"index.html":         {Data: []byte("<html>home</html>"), ModTime: modTime},
+		"docs/readme.txt":    {Data: []byte("read me"), ModTime: modTime},
+		"images/logo.svg":    {Data: []byte("<svg></svg>"), ModTime: modTime},
+		"images/nested/a.js": {Data: []byte("a"), ModTime: modTime},
+	}
+}
+
+func (suite *StaticTestSuite) serve(mux *ServerMuxWrapper, r *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, r)
+	return recorder
+}
+
+func (suite *StaticTestSuite) TestItCanServeStaticFiles() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Static(
+		"/assets/",
+		suite.fsys,
+		StaticOptions{
+			CacheRules: []middleware.CacheRule{
+				{
+					PathPrefix: "/assets/",
+					Extensions: []string{".js"},
+					Policy:     middleware.CachePolicy{Public: true, MaxAge: time.Hour, Immutable: true},
+				},
+				{
+					ContentTypes: []string{"text/html"},
+					Policy:       middleware.CachePolicy{NoStore: true},
+				},
+			},
+		},
+	)
+
+	testCases := map[string]struct {
+		target               string
+		expectedStatus       int
+		expectedBody         string
+		expectedContentType  string
+		expectedCacheControl string
+		expectedLocation     string
+	}{
+		"javascript asset": {
+			target:               "/assets/app.3f2a.js",
+			expectedStatus:       http.StatusOK,
+			expectedBody:         "console.log(1)",
+			expectedContentType:  "text/javascript; charset=utf-8",
+			expectedCacheControl: "public, max-age=3600, immutable",
+		},
+		"index file": {
+			target:               "/assets/",
+			expectedStatus:       http.StatusOK,
+			expectedBody:         "<html>home</html>",
+			expectedContentType:  "text/html; charset=utf-8",
+			expectedCacheControl: "no-store",
+		},
+		"directory without index": {
+			target:         "/assets/docs/",
+			expectedStatus: http.StatusNotFound,
+		},
+		"directory without trailing slash": {
+			target:           "/assets/docs",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "docs/",
+		},
+		"missing file": {
+			target:         "/assets/missing.css",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := suite.serve(mux, httptest.NewRequest(http.MethodGet, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+					suite.Equal(testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+					suite.NotEmpty(recorder.Header().Get("ETag"))
+					suite.Equal("Tue, 02 Jan 2024 03:04:05 GMT", recorder.Header().Get("Last-Modified"))
+				}
+				suite.Equal(testCase.expectedCacheControl, recorder.Header().Get("Cache-Control"))
+				suite.Equal(testCase.expectedLocation, recorder.Header().Get("Location"))
+			},
+		)
+	}
+}
+
+func (suite *StaticTestSuite) TestItHandlesConditionalRequests() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Static("/assets", suite.fsys, StaticOptions{})
+
+	first := suite.serve(mux, httptest.NewRequest(http.MethodGet, "/assets/docs/readme.txt", nil))
+	suite.Require().Equal(http.StatusOK, first.Code)
+
+	conditional := httptest.NewRequest(http.MethodGet, "/assets/docs/readme.txt", nil)
+	conditional.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	suite.Equal(http.StatusNotModified, suite.serve(mux, conditional).Code)
+
+	ranged := httptest.NewRequest(http.MethodGet, "/assets/docs/readme.txt", nil)
+	ranged.Header.Set("Range", "bytes=0-3")
+	rangedRecorder := suite.serve(mux, ranged)
+	suite.Equal(http.StatusPartialContent, rangedRecorder.Code)
+	suite.Equal("read", rangedRecorder.Body.String())
+
+	head := suite.serve(mux, httptest.NewRequest(http.MethodHead, "/assets/docs/readme.txt", nil))
+	suite.Equal(http.StatusOK, head.Code)
+	suite.Equal("7", head.Header().Get("Content-Length"))
+	suite.Empty(head.Body.String())
+}
+
+func (suite *StaticTestSuite) TestItCanBrowseDirectories() {
+	mux := NewServerMuxWrapper(nil)
+	mux.Static("/files/", suite.fsys, StaticOptions{Browse: true, DisableETag: true})
+
+	recorder := suite.serve(mux, httptest.NewRequest(http.MethodGet, "/files/images/", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Contains(recorder.Body.String(), `<a href="logo.svg">logo.svg</a>`)
+	suite.Contains(recorder.Body.String(), `<a href="nested/">nested/</a>`)
+
+	file := suite.serve(mux, httptest.NewRequest(http.MethodGet, "/files/images/logo.svg", nil))
+	suite.Empty(file.Header().Get("ETag"))
+}
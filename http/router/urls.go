@@ -0,0 +1,108 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrRouteNotFound is returned by URL when no route is registered under the name
+var ErrRouteNotFound = errors.New("route not found")
+
+// registerName records the pattern of a named route. Like conflicting ServeMux
+// patterns, a duplicate name is a programming error and panics.
+func (mux *ServerMuxWrapper) registerName(name string, pattern string) {
+	mux.routesMu.Lock()
+	defer mux.routesMu.Unlock()
+
+	if mux.namedRoutes == nil {
+		mux.namedRoutes = make(map[string]string)
+	}
+	if existing, exists := mux.namedRoutes[name]; exists {
+		panic(fmt.Sprintf("router: route name %q already registered for %q", name, existing))
+	}
+	mux.namedRoutes[name] = pattern
+}
+
+// URL builds the path of a named route. Params are key/value pairs: keys matching a
+// wildcard of the route pattern fill it (path escaped), the others are encoded as
+// query parameters, e.g. mux.URL("user.show", "id", 42, "tab", "orders").
+func (mux *ServerMuxWrapper) URL(name string, params ...interface{}) (string, error) {
+	mux.routesMu.RLock()
+	pattern, exists := mux.namedRoutes[name]
+	mux.routesMu.RUnlock()
+
+	if !exists {
+		return "", fmt.Errorf("%w: %q", ErrRouteNotFound, name)
+	}
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("route %q: params must be key/value pairs", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	keys := make([]string, 0, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return "", fmt.Errorf("route %q: param key %v is not a string", name, params[i])
+		}
+		values[key] = fmt.Sprint(params[i+1])
+		keys = append(keys, key)
+	}
+
+	_, _, path := splitPattern(pattern)
+	used := make(map[string]bool)
+	var builder strings.Builder
+
+	for path != "" {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			builder.WriteString(path)
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			builder.WriteString(path)
+			break
+		}
+		end += start
+
+		builder.WriteString(path[:start])
+		wildcard := path[start+1 : end]
+		path = path[end+1:]
+
+		if wildcard == "$" {
+			continue
+		}
+
+		key, remainder := strings.CutSuffix(wildcard, "...")
+		value, ok := values[key]
+		if !ok {
+			return "", fmt.Errorf("route %q: missing param %q", name, key)
+		}
+		used[key] = true
+
+		if remainder {
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = url.PathEscape(segment)
+			}
+			builder.WriteString(strings.Join(segments, "/"))
+		} else {
+			builder.WriteString(url.PathEscape(value))
+		}
+	}
+
+	query := url.Values{}
+	for _, key := range keys {
+		if !used[key] {
+			query.Add(key, values[key])
+		}
+	}
+
+	if len(query) > 0 {
+		return builder.String() + "?" + query.Encode(), nil
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type URLTestSuite struct {
+	suite.Suite
+}
+
+func TestURLSuite(t *testing.T) {
+	suite.Run(t, new(URLTestSuite))
+}
+
+func (suite *URLTestSuite) newMux() *ServerMuxWrapper {
+	mux := NewServerMuxWrapper(nil)
+	mux.Handle("GET /users/{id}", testHandler(), WithName("user.show"))
+	mux.Handle("GET /{$}", testHandler(), WithName("home"))
+	mux.Handle("GET example.com/files/{path...}", testHandler(), WithName("file.show"))
+	mux.Group("/api/v1").Handle("/orders/{id}/items/{item}", testHandler(), WithName("api.order.item"))
+	return mux
+}
+
+func (suite *URLTestSuite) TestItCanBuildURLsOfNamedRoutes() {
+	testCases := map[string]struct {
+		name     string
+		params   []interface{}
+		expected string
+	}{
+		"path param": {
+			name:     "user.show",
+			params:   []interface{}{"id", 42},
+			expected: "/users/42",
+		},
+		"query params": {
+			name:     "user.show",
+			params:   []interface{}{"id", 42, "tab", "orders", "page", 2},
+			expected: "/users/42?page=2&tab=orders",
+		},
+		"escaped param": {
+			name:     "user.show",
+			params:   []interface{}{"id", "a b/c"},
+			expected: "/users/a%20b%2Fc",
+		},
+		"exact match": {
+			name:     "home",
+			expected: "/",
+		},
+		"remainder param": {
+			name:     "file.show",
+			params:   []interface{}{"path", "docs/read me.txt"},
+			expected: "/files/docs/read%20me.txt",
+		},
+		"group route": {
+			name:     "api.order.item",
+			params:   []interface{}{"id", 1, "item", 2},
+			expected: "/api/v1/orders/1/items/2",
+		},
+	}
+
+	mux := suite.newMux()
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				url, err := mux.URL(testCase.name, testCase.params...)
+
+				suite.Require().NoError(err)
+				suite.Equal(testCase.expected, url)
+			},
+		)
+	}
+}
+
+func (suite *URLTestSuite) TestItFailsOnInvalidURLRequests() {
+	mux := suite.newMux()
+
+	_, err := mux.URL("unknown")
+	suite.True(errors.Is(err, ErrRouteNotFound))
+
+	_, err = mux.URL("user.show")
+	suite.EqualError(err, `route "user.show": missing param "id"`)
+
+	_, err = mux.URL("user.show", "id")
+	suite.EqualError(err, `route "user.show": params must be key/value pairs`)
+
+	suite.Panics(
+		func() {
+			mux.Handle("GET /accounts/{id}", testHandler(), WithName("user.show"))
+		},
+	)
+}
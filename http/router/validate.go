@@ -0,0 +1,133 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidMiddlewareChain = errors.New("invalid middleware chain")
+
+// OrderingRule requires the Outer middleware to wrap the Inner one whenever a chain
+// contains both of them
+type OrderingRule struct {
+	Outer  string
+	Inner  string
+	Reason string
+}
+
+// DefaultOrderingRules are the ordering rules checked by ServerMuxWrapper.Validate.
+// They rely on the middleware names of the predefined stacks.
+var DefaultOrderingRules = []OrderingRule{
+	{
+		Outer:  MiddlewareAccess,
+		Inner:  MiddlewareRecoverer,
+		Reason: "panics unwinding through the access logger leave the request unlogged",
+	},
+	{
+		Outer:  MiddlewareRequestID,
+		Inner:  MiddlewareRecoverer,
+		Reason: "recovered panics are logged without the request id",
+	},
+	{
+		Outer:  MiddlewareRequestID,
+		Inner:  MiddlewareAccess,
+		Reason: "access logs miss the request id",
+	},
+}
+
+// Validate checks the default chain and the chain of every registered route against
+// DefaultOrderingRules. See ValidateWithRules.
+func (mux *ServerMuxWrapper) Validate() error {
+	return mux.ValidateWithRules(DefaultOrderingRules)
+}
+
+// ValidateWithRules reports, wrapping ErrInvalidMiddlewareChain, duplicate middleware
+// names, overrides disabling middlewares absent from the default chain and chains
+// breaking one of the ordering rules. It is meant to be called once all routes are
+// registered, typically at startup.
+func (mux *ServerMuxWrapper) ValidateWithRules(rules []OrderingRule) error {
+	defaultNames := middlewareNames(mux.defaultNamedMiddlewares, nil)
+	var errs []error
+	errs = append(errs, checkDuplicateNames("default chain", mux.defaultNamedMiddlewares)...)
+	errs = append(errs, checkOrdering("default chain", defaultNames, rules)...)
+
+	// Routes are only checked against the rules the default chain satisfies, so that
+	// a broken default ordering is reported once
+	var routeRules []OrderingRule
+	for _, rule := range rules {
+		if len(checkOrdering("", defaultNames, []OrderingRule{rule})) == 0 {
+			routeRules = append(routeRules, rule)
+		}
+	}
+
+	mux.routesMu.RLock()
+	defer mux.routesMu.RUnlock()
+
+	for i, route := range mux.routes {
+		overrides := mux.routeOverrides[i]
+		if len(overrides) == 0 {
+			continue
+		}
+
+		subject := fmt.Sprintf("route %q", route.Pattern)
+		errs = append(errs, checkDuplicateNames(subject, overrides)...)
+		for _, override := range overrides {
+			if override.Middleware == nil && Chain(mux.defaultNamedMiddlewares).index(override.Name) < 0 {
+				errs = append(
+					errs, fmt.Errorf(
+						"%w: %s disables unknown middleware %q",
+						ErrInvalidMiddlewareChain, subject, override.Name,
+					),
+				)
+			}
+		}
+
+		errs = append(errs, checkOrdering(subject, route.Middlewares, routeRules)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkDuplicateNames(subject string, middlewares []NamedMiddleware) []error {
+	var errs []error
+	seen := make(map[string]bool, len(middlewares))
+	for _, namedMw := range middlewares {
+		if seen[namedMw.Name] {
+			errs = append(
+				errs, fmt.Errorf(
+					"%w: %s has duplicate middleware %q",
+					ErrInvalidMiddlewareChain, subject, namedMw.Name,
+				),
+			)
+		}
+		seen[namedMw.Name] = true
+	}
+	return errs
+}
+
+// checkOrdering checks the names of a chain, innermost first, against the rules
+func checkOrdering(subject string, names []string, rules []OrderingRule) []error {
+	var errs []error
+	for _, rule := range rules {
+		outer, inner := indexOf(names, rule.Outer), indexOf(names, rule.Inner)
+		if outer < 0 || inner < 0 || outer > inner {
+			continue
+		}
+		errs = append(
+			errs, fmt.Errorf(
+				"%w: %s has %q wrapped by %q, %s",
+				ErrInvalidMiddlewareChain, subject, rule.Outer, rule.Inner, rule.Reason,
+			),
+		)
+	}
+	return errs
+}
+
+func indexOf(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+	return -1
+}
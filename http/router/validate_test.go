@@ -0,0 +1,117 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (suite *ValidateTestSuite) TestItCanAcceptValidChains() {
+	mux := NewServerMuxWrapper(
+		NewChain(
+			testNamedMiddleware("recoverer"),
+			testNamedMiddleware("access"),
+			testNamedMiddleware("requestid"),
+		),
+	)
+	mux.Handle("/", http.NotFoundHandler())
+	mux.HandleWithCustomMiddlewares(
+		"/quiet",
+		http.NotFoundHandler(),
+		[]NamedMiddleware{Disable("access"), testNamedMiddleware("csrf")},
+	)
+
+	suite.NoError(mux.Validate())
+}
+
+func (suite *ValidateTestSuite) TestItCanReportInvalidChains() {
+	testCases := map[string]struct {
+		defaults      []NamedMiddleware
+		overrides     []NamedMiddleware
+		expectedError string
+	}{
+		"duplicate default": {
+			defaults: []NamedMiddleware{
+				testNamedMiddleware("recoverer"),
+				testNamedMiddleware("recoverer"),
+			},
+			expectedError: `invalid middleware chain: default chain has duplicate middleware "recoverer"`,
+		},
+		"duplicate override": {
+			defaults:      []NamedMiddleware{testNamedMiddleware("recoverer")},
+			overrides:     []NamedMiddleware{testNamedMiddleware("csrf"), testNamedMiddleware("csrf")},
+			expectedError: `invalid middleware chain: route "/" has duplicate middleware "csrf"`,
+		},
+		"unknown disabled middleware": {
+			defaults:      []NamedMiddleware{testNamedMiddleware("recoverer")},
+			overrides:     []NamedMiddleware{Disable("acess")},
+			expectedError: `invalid middleware chain: route "/" disables unknown middleware "acess"`,
+		},
+		"default ordering": {
+			defaults: []NamedMiddleware{
+				testNamedMiddleware("access"),
+				testNamedMiddleware("recoverer"),
+			},
+			overrides: []NamedMiddleware{testNamedMiddleware("csrf")},
+			expectedError: `invalid middleware chain: default chain has "access" wrapped by "recoverer", ` +
+				`panics unwinding through the access logger leave the request unlogged`,
+		},
+		"valid override ordering": {
+			defaults: []NamedMiddleware{testNamedMiddleware("recoverer")},
+			overrides: []NamedMiddleware{
+				testNamedMiddleware("requestid"),
+			},
+			expectedError: "",
+		},
+		"route override ordering": {
+			defaults:  []NamedMiddleware{testNamedMiddleware("access"), testNamedMiddleware("requestid")},
+			overrides: []NamedMiddleware{testNamedMiddleware("recoverer")},
+			expectedError: `invalid middleware chain: route "/" has "access" wrapped by "recoverer", ` +
+				`panics unwinding through the access logger leave the request unlogged` + "\n" +
+				`invalid middleware chain: route "/" has "requestid" wrapped by "recoverer", ` +
+				`recovered panics are logged without the request id`,
+		},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				mux := NewServerMuxWrapper(tc.defaults)
+				mux.HandleWithCustomMiddlewares("/", http.NotFoundHandler(), tc.overrides)
+
+				err := mux.Validate()
+				if tc.expectedError == "" {
+					suite.NoError(err)
+					return
+				}
+				suite.True(errors.Is(err, ErrInvalidMiddlewareChain))
+				suite.EqualError(err, tc.expectedError)
+			},
+		)
+	}
+}
+
+func (suite *ValidateTestSuite) TestItCanValidateWithCustomRules() {
+	mux := NewServerMuxWrapper(
+		[]NamedMiddleware{testNamedMiddleware("session"), testNamedMiddleware("csrf")},
+	)
+
+	suite.NoError(mux.Validate())
+	suite.EqualError(
+		mux.ValidateWithRules(
+			[]OrderingRule{{Outer: "session", Inner: "csrf", Reason: "csrf tokens are stored in the session"}},
+		),
+		`invalid middleware chain: default chain has "session" wrapped by "csrf", `+
+			`csrf tokens are stored in the session`,
+	)
+}
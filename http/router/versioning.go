@@ -0,0 +1,193 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	httpInternal "github.com/golibry/go-http/http"
+)
+
+// ErrUnsupportedAPIVersion is rendered with 400 when a request asks for an unknown version
+var ErrUnsupportedAPIVersion = errors.New("unsupported API version")
+
+// VersionStrategy selects how the API version of a request is resolved
+type VersionStrategy int
+
+const (
+	// VersionByPrefix routes by URL prefix (/v1/users, /v2/users)
+	VersionByPrefix VersionStrategy = iota
+	// VersionByHeader routes by the version header or the Accept version parameter
+	// (Accept: application/json; version=2) of the preferred media range
+	VersionByHeader
+)
+
+// VersioningOptions configures API versioning
+//
+// Strategy: URL prefix (default) or header based resolution
+// Prefix: path prefix placed before the version segment or the routes (e.g. "/api")
+// HeaderName: version header for VersionByHeader (default: "Accept-Version")
+// Default: version used when the request names none (default: the latest version)
+type VersioningOptions struct {
+	Strategy   VersionStrategy
+	Prefix     string
+	HeaderName string
+	Default    string
+}
+
+// APIVersions registers routes for an ordered list of API versions. A handler
+// registered since a version serves that version and every later one, until a handler
+// is registered for the same pattern in a later version, so unchanged endpoints are
+// written once.
+type APIVersions struct {
+	mux      *ServerMuxWrapper
+	versions []string
+	options  VersioningOptions
+
+	mu         sync.RWMutex
+	handlers   map[string]map[int]http.Handler
+	registered map[string]bool
+}
+
+type apiVersionContextKey struct{}
+
+// Versions creates an API versioning helper for the versions, ordered oldest first
+func (mux *ServerMuxWrapper) Versions(versions []string, options VersioningOptions) *APIVersions {
+	if options.HeaderName == "" {
+		options.HeaderName = "Accept-Version"
+	}
+	if options.Default == "" && len(versions) > 0 {
+		options.Default = versions[len(versions)-1]
+	}
+	options.Prefix = cleanPrefix(options.Prefix)
+
+	return &APIVersions{
+		mux:        mux,
+		versions:   versions,
+		options:    options,
+		handlers:   make(map[string]map[int]http.Handler),
+		registered: make(map[string]bool),
+	}
+}
+
+// APIVersionFromContext returns the API version resolved for the request
+func APIVersionFromContext(ctx context.Context) (string, bool) {
+	version, ok := ctx.Value(apiVersionContextKey{}).(string)
+	return version, ok
+}
+
+// Handle registers the handler for the pattern, from the since version onwards.
+// It panics when the version is unknown.
+func (av *APIVersions) Handle(pattern string, since string, handler http.Handler) {
+	sinceIndex := av.versionIndex(since)
+	if sinceIndex < 0 {
+		panic("router: unknown API version " + since)
+	}
+
+	av.mu.Lock()
+	if av.handlers[pattern] == nil {
+		av.handlers[pattern] = make(map[int]http.Handler)
+	}
+	av.handlers[pattern][sinceIndex] = handler
+	av.mu.Unlock()
+
+	if av.options.Strategy == VersionByHeader {
+		av.register(prefixPattern(av.options.Prefix, pattern), pattern, -1)
+		return
+	}
+
+	for index := sinceIndex; index < len(av.versions); index++ {
+		versionPrefix := av.options.Prefix + "/" + av.versions[index]
+		av.register(prefixPattern(versionPrefix, pattern), pattern, index)
+	}
+}
+
+// register adds the dispatcher of a versioned pattern to the mux once. A fixed index
+// is used by the prefix strategy, -1 resolves the version from the request.
+func (av *APIVersions) register(muxPattern string, pattern string, index int) {
+	av.mu.Lock()
+	if av.registered[muxPattern] {
+		av.mu.Unlock()
+		return
+	}
+	av.registered[muxPattern] = true
+	av.mu.Unlock()
+
+	av.mux.Handle(
+		muxPattern, http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				versionIndex := index
+				if versionIndex < 0 {
+					w.Header().Add("Vary", av.options.HeaderName)
+					w.Header().Add("Vary", "Accept")
+					versionIndex = av.requestedVersion(r)
+					if versionIndex < 0 {
+						builder := httpInternal.NewResponseBuilder(w)
+						builder.Status(http.StatusBadRequest)
+						_ = builder.Error().WithError(ErrUnsupportedAPIVersion).DisableLogging().Send()
+						return
+					}
+				}
+
+				handler := av.resolve(pattern, versionIndex)
+				if handler == nil {
+					av.mux.serveNotFound(w, r)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), apiVersionContextKey{}, av.versions[versionIndex])
+				handler.ServeHTTP(w, r.WithContext(ctx))
+			},
+		),
+	)
+}
+
+// resolve returns the handler registered for the latest version not after the index
+func (av *APIVersions) resolve(pattern string, index int) http.Handler {
+	av.mu.RLock()
+	defer av.mu.RUnlock()
+
+	for ; index >= 0; index-- {
+		if handler, exists := av.handlers[pattern][index]; exists {
+			return handler
+		}
+	}
+	return nil
+}
+
+// requestedVersion resolves the version index from the header or the Accept version
+// parameter, falling back to the default version. It returns -1 for unknown versions.
+func (av *APIVersions) requestedVersion(r *http.Request) int {
+	version := strings.TrimSpace(r.Header.Get(av.options.HeaderName))
+	if version == "" {
+		version = acceptedVersion(r)
+	}
+	if version == "" {
+		version = av.options.Default
+	}
+	return av.versionIndex(version)
+}
+
+// acceptedVersion returns the version parameter of the Accept media range with the
+// highest non-zero quality carrying one, the earliest on ties
+func acceptedVersion(r *http.Request) string {
+	version, bestQuality := "", 0.0
+	for _, mediaRange := range httpInternal.ParseAccept(r.Header.Values("Accept")) {
+		if mediaRange.Params["version"] != "" && mediaRange.Quality > bestQuality {
+			version, bestQuality = mediaRange.Params["version"], mediaRange.Quality
+		}
+	}
+	return version
+}
+
+// versionIndex finds the version, accepting it with or without the "v" prefix
+func (av *APIVersions) versionIndex(version string) int {
+	for i, candidate := range av.versions {
+		if candidate == version || strings.TrimPrefix(candidate, "v") == strings.TrimPrefix(version, "v") {
+			return i
+		}
+	}
+	return -1
+}
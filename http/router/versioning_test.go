@@ -0,0 +1,169 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VersioningTestSuite struct {
+	suite.Suite
+}
+
+func TestVersioningSuite(t *testing.T) {
+	suite.Run(t, new(VersioningTestSuite))
+}
+
+func versionedHandler(name string) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			version, _ := APIVersionFromContext(r.Context())
+			_, _ = w.Write([]byte(name + "@" + version))
+		},
+	)
+}
+
+func (suite *VersioningTestSuite) TestItCanVersionByPrefix() {
+	mux := NewServerMuxWrapper(nil)
+	versions := mux.Versions([]string{"v1", "v2", "v3"}, VersioningOptions{Prefix: "/api"})
+	versions.Handle("GET /users", "v1", versionedHandler("users1"))
+	versions.Handle("GET /users", "v3", versionedHandler("users3"))
+	versions.Handle("GET /orders", "v2", versionedHandler("orders2"))
+
+	testCases := map[string]struct {
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"original handler":    {target: "/api/v1/users", expectedStatus: http.StatusOK, expectedBody: "users1@v1"},
+		"shared handler":      {target: "/api/v2/users", expectedStatus: http.StatusOK, expectedBody: "users1@v2"},
+		"replaced handler":    {target: "/api/v3/users", expectedStatus: http.StatusOK, expectedBody: "users3@v3"},
+		"introduced later":    {target: "/api/v3/orders", expectedStatus: http.StatusOK, expectedBody: "orders2@v3"},
+		"before introduction": {target: "/api/v1/orders", expectedStatus: http.StatusNotFound},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testCase.target, nil))
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				}
+			},
+		)
+	}
+}
+
+func (suite *VersioningTestSuite) TestItCanVersionByHeader() {
+	mux := NewServerMuxWrapper(nil)
+	versions := mux.Versions([]string{"v1", "v2"}, VersioningOptions{Strategy: VersionByHeader})
+	versions.Handle("GET /users", "v1", versionedHandler("users1"))
+	versions.Handle("GET /users", "v2", versionedHandler("users2"))
+	versions.Handle("GET /orders", "v2", versionedHandler("orders2"))
+
+	testCases := map[string]struct {
+		target         string
+		headers        map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"default latest": {
+			target:         "/users",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "users2@v2",
+		},
+		"version header": {
+			target:         "/users",
+			headers:        map[string]string{"Accept-Version": "v1"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "users1@v1",
+		},
+		"accept parameter": {
+			target:         "/users",
+			headers:        map[string]string{"Accept": "application/json; version=1"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "users1@v1",
+		},
+		"unknown version": {
+			target:         "/users",
+			headers:        map[string]string{"Accept-Version": "9"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "unsupported API version",
+		},
+		"before introduction": {
+			target:         "/orders",
+			headers:        map[string]string{"Accept-Version": "1"},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, testCase.target, nil)
+				for key, value := range testCase.headers {
+					request.Header.Set(key, value)
+				}
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, request)
+
+				suite.Equal(testCase.expectedStatus, recorder.Code)
+				if testCase.expectedBody != "" {
+					suite.Equal(testCase.expectedBody, recorder.Body.String())
+				}
+			},
+		)
+	}
+}
+
+func (suite *VersioningTestSuite) TestItHonorsAcceptQualityForVersions() {
+	mux := NewServerMuxWrapper(nil)
+	versions := mux.Versions([]string{"v1", "v2"}, VersioningOptions{Strategy: VersionByHeader})
+	versions.Handle("GET /users", "v1", versionedHandler("users1"))
+	versions.Handle("GET /users", "v2", versionedHandler("users2"))
+
+	testCases := map[string]struct {
+		accept       []string
+		expectedBody string
+	}{
+		"highest quality": {
+			accept:       []string{"application/json; version=2; q=0.5, application/json; version=1"},
+			expectedBody: "users1@v1",
+		},
+		"excluded version": {
+			accept:       []string{"application/json; version=1; q=0"},
+			expectedBody: "users2@v2",
+		},
+		"second header line": {
+			accept:       []string{"text/html", "application/json; version=1"},
+			expectedBody: "users1@v1",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/users", nil)
+				for _, accept := range testCase.accept {
+					request.Header.Add("Accept", accept)
+				}
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, request)
+
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *VersioningTestSuite) TestItPanicsOnUnknownVersions() {
+	versions := NewServerMuxWrapper(nil).Versions([]string{"v1"}, VersioningOptions{})
+
+	suite.Panics(func() { versions.Handle("GET /users", "v2", testHandler()) })
+}
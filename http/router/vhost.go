@@ -0,0 +1,95 @@
+package router
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultHost is the VirtualHosts key of the handler serving requests for unknown hosts
+const DefaultHost = "*"
+
+// VirtualHostRouter dispatches requests by host to a handler per host, typically a
+// ServerMuxWrapper with its own default middleware stack. Hosts are matched case
+// insensitively, without port, either exactly or through "*.example.com" patterns
+// matching any subdomain (the longest pattern wins). Requests for unknown hosts are
+// served by the DefaultHost handler, or answered with 404 Not Found.
+type VirtualHostRouter struct {
+	mu    sync.RWMutex
+	hosts map[string]http.Handler
+}
+
+// VirtualHosts creates a router serving the handlers by host, DefaultHost being the
+// fallback:
+//
+//	router.VirtualHosts(map[string]http.Handler{
+//		"api.example.com":  apiMux,
+//		"*.example.com":    tenantsMux,
+//		router.DefaultHost: http.RedirectHandler("https://example.com", http.StatusFound),
+//	})
+func VirtualHosts(hosts map[string]http.Handler) *VirtualHostRouter {
+	vh := &VirtualHostRouter{hosts: make(map[string]http.Handler, len(hosts))}
+	for host, handler := range hosts {
+		vh.Handle(host, handler)
+	}
+	return vh
+}
+
+// Handle serves the host, a "*.domain" pattern or DefaultHost with the handler,
+// replacing any handler registered for it
+func (vh *VirtualHostRouter) Handle(host string, handler http.Handler) {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+
+	vh.hosts[normalizeHost(host)] = handler
+}
+
+// Host creates a mux with its own default middlewares and serves the host with it
+func (vh *VirtualHostRouter) Host(host string, namedMiddlewares []NamedMiddleware) *ServerMuxWrapper {
+	mux := NewServerMuxWrapper(namedMiddlewares)
+	vh.Handle(host, mux)
+	return mux
+}
+
+// ServeHTTP dispatches the request to the handler of its host
+func (vh *VirtualHostRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler := vh.handler(r.Host); handler != nil {
+		handler.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// handler resolves the handler of the host: exact match, then the longest matching
+// subdomain pattern, then the default host
+func (vh *VirtualHostRouter) handler(host string) http.Handler {
+	vh.mu.RLock()
+	defer vh.mu.RUnlock()
+
+	host = normalizeHost(host)
+	if handler, exists := vh.hosts[host]; exists {
+		return handler
+	}
+
+	for domain := host; ; {
+		index := strings.IndexByte(domain, '.')
+		if index < 0 {
+			break
+		}
+		domain = domain[index+1:]
+		if handler, exists := vh.hosts["*."+domain]; exists {
+			return handler
+		}
+	}
+
+	return vh.hosts[DefaultHost]
+}
+
+// normalizeHost lowercases the host and strips its port and trailing dot
+func normalizeHost(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VirtualHostsTestSuite struct {
+	suite.Suite
+}
+
+func TestVirtualHostsSuite(t *testing.T) {
+	suite.Run(t, new(VirtualHostsTestSuite))
+}
+
+func (suite *VirtualHostsTestSuite) TestItCanDispatchByHost() {
+	vh := VirtualHosts(
+		map[string]http.Handler{
+			"API.example.com":        namedHandler("api"),
+			"*.example.com":          namedHandler("tenant"),
+			"*.partners.example.com": namedHandler("partner"),
+		},
+	)
+	admin := vh.Host("admin.example.com", NewChain(testNamedMiddleware("admin-auth")))
+	admin.Handle("/", namedHandler("admin"))
+
+	testCases := map[string]struct {
+		host               string
+		expectedCode       int
+		expectedBody       string
+		expectedMiddleware string
+	}{
+		"exact host":         {host: "api.example.com", expectedCode: http.StatusOK, expectedBody: "api"},
+		"host with port":     {host: "api.example.com:8443", expectedCode: http.StatusOK, expectedBody: "api"},
+		"fully qualified":    {host: "api.example.com.", expectedCode: http.StatusOK, expectedBody: "api"},
+		"subdomain":          {host: "acme.example.com", expectedCode: http.StatusOK, expectedBody: "tenant"},
+		"longest subdomain":  {host: "acme.partners.example.com", expectedCode: http.StatusOK, expectedBody: "partner"},
+		"host mux":           {host: "admin.example.com", expectedCode: http.StatusOK, expectedBody: "admin", expectedMiddleware: "admin-auth"},
+		"unknown host":       {host: "example.org", expectedCode: http.StatusNotFound, expectedBody: "404 page not found\n"},
+		"apex without entry": {host: "example.com", expectedCode: http.StatusNotFound, expectedBody: "404 page not found\n"},
+	}
+
+	for name, tc := range testCases {
+		suite.Run(
+			name, func() {
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Host = tc.host
+				recorder := httptest.NewRecorder()
+				vh.ServeHTTP(recorder, request)
+
+				suite.Equal(tc.expectedCode, recorder.Code)
+				suite.Equal(tc.expectedBody, recorder.Body.String())
+				suite.Equal(tc.expectedMiddleware, recorder.Header().Get("X-Middleware"))
+			},
+		)
+	}
+}
+
+func (suite *VirtualHostsTestSuite) TestItCanFallBackToTheDefaultHost() {
+	vh := VirtualHosts(
+		map[string]http.Handler{
+			"api.example.com": namedHandler("api"),
+			DefaultHost:       namedHandler("default"),
+		},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Host = "unknown.example.org"
+	recorder := httptest.NewRecorder()
+	vh.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("default", recorder.Body.String())
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ACMEChallengePath is the path prefix of ACME HTTP-01 challenge requests
+const ACMEChallengePath = "/.well-known/acme-challenge/"
+
+// AutocertOptions configures automatic certificates from an ACME CA (Let's Encrypt)
+//
+// Hosts: hosts certificates may be requested for (required unless HostPolicy is set)
+// HostPolicy: custom host policy, taking precedence over Hosts
+// CacheDir: directory storing certificates and the account key
+// Cache: custom certificate storage, taking precedence over CacheDir
+// Email: contact address registered with the CA
+// DirectoryURL: ACME directory, e.g. the staging environment (default: Let's Encrypt)
+type AutocertOptions struct {
+	Hosts        []string
+	HostPolicy   autocert.HostPolicy
+	CacheDir     string
+	Cache        autocert.Cache
+	Email        string
+	DirectoryURL string
+}
+
+// WithAutocert serves TLS with certificates obtained and renewed automatically. The
+// CA validates domains through TLS-ALPN on the server port and through HTTP-01 on
+// port 80, served by ACMEChallengeHandler.
+func WithAutocert(options AutocertOptions) Option {
+	return func(server *Server) {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: options.HostPolicy,
+			Cache:      options.Cache,
+			Email:      options.Email,
+		}
+		if manager.HostPolicy == nil {
+			manager.HostPolicy = autocert.HostWhitelist(options.Hosts...)
+		}
+		if manager.Cache == nil && options.CacheDir != "" {
+			manager.Cache = autocert.DirCache(options.CacheDir)
+		}
+		if options.DirectoryURL != "" {
+			manager.Client = &acme.Client{DirectoryURL: options.DirectoryURL}
+		}
+
+		server.autocertManager = manager
+		server.TLSConfig = manager.TLSConfig()
+	}
+}
+
+// AutocertManager returns the certificate manager configured by WithAutocert, or nil
+func (server *Server) AutocertManager() *autocert.Manager {
+	return server.autocertManager
+}
+
+// ACMEChallengeHandler answers HTTP-01 challenges and passes other requests to the
+// fallback (nil redirects them to HTTPS). Mount it on the port 80 router:
+//
+//	mux.Handle(server.ACMEChallengePath, srv.ACMEChallengeHandler(nil))
+//
+// Without WithAutocert, it only serves the fallback.
+func (server *Server) ACMEChallengeHandler(fallback http.Handler) http.Handler {
+	if server.autocertManager == nil {
+		if fallback == nil {
+			return http.NotFoundHandler()
+		}
+		return fallback
+	}
+	return server.autocertManager.HTTPHandler(fallback)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AutocertTestSuite struct {
+	suite.Suite
+}
+
+func TestAutocertSuite(t *testing.T) {
+	suite.Run(t, new(AutocertTestSuite))
+}
+
+func (suite *AutocertTestSuite) TestItCanConfigureAutocert() {
+	server := New(
+		http.NotFoundHandler(),
+		WithAutocert(
+			AutocertOptions{
+				Hosts:        []string{"example.com"},
+				CacheDir:     suite.T().TempDir(),
+				Email:        "ops@example.com",
+				DirectoryURL: "https://acme-staging-v02.api.letsencrypt.org/directory",
+			},
+		),
+	)
+
+	manager := server.AutocertManager()
+	suite.Require().NotNil(manager)
+	suite.Require().NotNil(server.TLSConfig)
+	suite.NotNil(server.TLSConfig.GetCertificate)
+	suite.Contains(server.TLSConfig.NextProtos, "acme-tls/1")
+	suite.Equal("ops@example.com", manager.Email)
+	suite.NotNil(manager.Cache)
+	suite.Equal("https://acme-staging-v02.api.letsencrypt.org/directory", manager.Client.DirectoryURL)
+
+	suite.NoError(manager.HostPolicy(context.Background(), "example.com"))
+	suite.Error(manager.HostPolicy(context.Background(), "attacker.example"))
+}
+
+func (suite *AutocertTestSuite) TestItCanServeChallengeHandler() {
+	server := New(
+		http.NotFoundHandler(),
+		WithAutocert(AutocertOptions{Hosts: []string{"example.com"}, CacheDir: suite.T().TempDir()}),
+	)
+	handler := server.ACMEChallengeHandler(nil)
+
+	challenge := httptest.NewRecorder()
+	handler.ServeHTTP(
+		challenge,
+		httptest.NewRequest(http.MethodGet, "http://example.com"+ACMEChallengePath+"token", nil),
+	)
+	suite.Equal(http.StatusNotFound, challenge.Code)
+
+	redirect := httptest.NewRecorder()
+	handler.ServeHTTP(redirect, httptest.NewRequest(http.MethodGet, "http://example.com/users", nil))
+	suite.Equal(http.StatusFound, redirect.Code)
+	suite.Equal("https://example.com/users", redirect.Header().Get("Location"))
+}
+
+func (suite *AutocertTestSuite) TestChallengeHandlerFallsBackWithoutAutocert() {
+	server := New(http.NotFoundHandler())
+	suite.Nil(server.AutocertManager())
+
+	recorder := httptest.NewRecorder()
+	server.ACMEChallengeHandler(nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
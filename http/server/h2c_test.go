@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type H2CTestSuite struct {
+	suite.Suite
+}
+
+func TestH2CSuite(t *testing.T) {
+	suite.Run(t, new(H2CTestSuite))
+}
+
+func (suite *H2CTestSuite) TestItCanServeHTTP2OverCleartext() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	hijackErr := make(chan error, 1)
+	server := New(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				controller := http.NewResponseController(w)
+				if r.ProtoMajor == 2 {
+					_, _, err := controller.Hijack()
+					hijackErr <- err
+				}
+
+				_, _ = w.Write([]byte(r.Proto))
+				suite.NoError(controller.Flush())
+			},
+		),
+		WithListener(listener),
+		WithH2C(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = server.Run(ctx) }()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}, Timeout: 2 * time.Second}
+
+	response, err := client.Get("http://" + listener.Addr().String())
+	suite.Require().NoError(err)
+	body, _ := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+
+	suite.Equal(2, response.ProtoMajor)
+	suite.Equal("HTTP/2.0", string(body))
+	suite.True(errors.Is(<-hijackErr, http.ErrNotSupported))
+
+	http1Response, err := http.Get("http://" + listener.Addr().String())
+	suite.Require().NoError(err)
+	_ = http1Response.Body.Close()
+	suite.Equal(1, http1Response.ProtoMajor)
+}
+
+func (suite *H2CTestSuite) TestItKeepsHTTP2OverTLSRegardlessOfOptionOrder() {
+	testCases := map[string]struct {
+		options       []Option
+		expectedHTTP2 bool
+	}{
+		"cleartext only": {
+			options: []Option{WithH2C()},
+		},
+		"tls certificates after h2c": {
+			options:       []Option{WithH2C(), WithTLSCertificates("cert.pem", "key.pem")},
+			expectedHTTP2: true,
+		},
+		"tls certificates before h2c": {
+			options:       []Option{WithTLSCertificates("cert.pem", "key.pem"), WithH2C()},
+			expectedHTTP2: true,
+		},
+		"autocert after h2c": {
+			options: []Option{
+				WithH2C(),
+				WithAutocert(AutocertOptions{Hosts: []string{"example.com"}}),
+			},
+			expectedHTTP2: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				server := New(http.NotFoundHandler(), testCase.options...)
+
+				suite.Require().NotNil(server.Protocols)
+				suite.True(server.Protocols.HTTP1())
+				suite.True(server.Protocols.UnencryptedHTTP2())
+				suite.Equal(testCase.expectedHTTP2, server.Protocols.HTTP2())
+			},
+		)
+	}
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// DefaultAddr is the listen address used when none is configured
+	DefaultAddr = ":8080"
+	// DefaultReadHeaderTimeout bounds the time to read request headers (slowloris)
+	DefaultReadHeaderTimeout = 5 * time.Second
+	// DefaultReadTimeout bounds the time to read a whole request
+	DefaultReadTimeout = 30 * time.Second
+	// DefaultWriteTimeout bounds the time to write a response
+	DefaultWriteTimeout = 60 * time.Second
+	// DefaultIdleTimeout bounds the time keep-alive connections stay idle
+	DefaultIdleTimeout = 120 * time.Second
+	// DefaultMaxHeaderBytes bounds the size of request headers
+	DefaultMaxHeaderBytes = 64 << 10
+	// DefaultShutdownTimeout bounds the graceful shutdown performed by Run
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Server is an http.Server configured with safe defaults. The embedded server can be
+// tuned further before it starts.
+type Server struct {
+	*http.Server
+	logger          *slog.Logger
+	listener        net.Listener
+	certFile        string
+	keyFile         string
+	shutdownTimeout time.Duration
+	autocertManager *autocert.Manager
+	h2c             bool
+}
+
+// Option configures the server
+type Option func(*Server)
+
+// New creates a server for the handler with safe defaults, adjusted by the options
+func New(handler http.Handler, options ...Option) *Server {
+	server := &Server{
+		Server: &http.Server{
+			Addr:              DefaultAddr,
+			Handler:           handler,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			ReadTimeout:       DefaultReadTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+			MaxHeaderBytes:    DefaultMaxHeaderBytes,
+		},
+		shutdownTimeout: DefaultShutdownTimeout,
+	}
+
+	for _, option := range options {
+		option(server)
+	}
+
+	if server.h2c {
+		server.Protocols = h2cProtocols(server.TLSConfig != nil)
+	}
+
+	if server.logger != nil {
+		server.ErrorLog = slog.NewLogLogger(server.logger.Handler(), slog.LevelError)
+	}
+
+	return server
+}
+
+// WithAddr sets the listen address
+func WithAddr(addr string) Option {
+	return func(server *Server) {
+		server.Addr = addr
+	}
+}
+
+// WithLogger wires the logger as the server error log and for lifecycle messages
+func WithLogger(logger *slog.Logger) Option {
+	return func(server *Server) {
+		server.logger = logger
+	}
+}
+
+// WithReadHeaderTimeout sets the time allowed to read request headers
+func WithReadHeaderTimeout(timeout time.Duration) Option {
+	return func(server *Server) {
+		server.ReadHeaderTimeout = timeout
+	}
+}
+
+// WithReadTimeout sets the time allowed to read a whole request, body included
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(server *Server) {
+		server.ReadTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the time allowed to write a response (0 disables it, e.g. for
+// long-lived streaming responses)
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(server *Server) {
+		server.WriteTimeout = timeout
+	}
+}
+
+// WithIdleTimeout sets the time keep-alive connections may stay idle
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(server *Server) {
+		server.IdleTimeout = timeout
+	}
+}
+
+// WithMaxHeaderBytes sets the maximum size of request headers
+func WithMaxHeaderBytes(maxHeaderBytes int) Option {
+	return func(server *Server) {
+		server.MaxHeaderBytes = maxHeaderBytes
+	}
+}
+
+// WithShutdownTimeout sets the time Run waits for in-flight requests on shutdown
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(server *Server) {
+		server.shutdownTimeout = timeout
+	}
+}
+
+// WithListener serves on the given listener instead of listening on the address,
+// e.g. for socket activation or tests
+func WithListener(listener net.Listener) Option {
+	return func(server *Server) {
+		server.listener = listener
+	}
+}
+
+// WithTLSConfig serves TLS with the configuration. Certificates come from the
+// configuration (Certificates, GetCertificate) or from WithTLSCertificates.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(server *Server) {
+		server.TLSConfig = config
+	}
+}
+
+// WithTLSCertificates serves TLS with the certificate and key files. Without an explicit
+// configuration, TLS 1.2 is the minimum version.
+func WithTLSCertificates(certFile, keyFile string) Option {
+	return func(server *Server) {
+		server.certFile = certFile
+		server.keyFile = keyFile
+		if server.TLSConfig == nil {
+			server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+	}
+}
+
+// WithHTTP2 sets the HTTP/2 settings (stream limits, frame sizes, timeouts)
+func WithHTTP2(config http.HTTP2Config) Option {
+	return func(server *Server) {
+		server.HTTP2 = &config
+	}
+}
+
+// WithH2C additionally serves HTTP/2 over cleartext connections, for deployments behind
+// TLS terminating load balancers multiplexing requests to the backend. HTTP/2
+// connections cannot be hijacked: http.ResponseController.Hijack reports
+// http.ErrNotSupported, while flushing keeps working.
+func WithH2C() Option {
+	return func(server *Server) {
+		server.h2c = true
+	}
+}
+
+// h2cProtocols returns the protocols served with h2c, keeping HTTP/2 over TLS when the
+// server serves TLS. They are built once every option applied, as TLS may be configured
+// by an option following WithH2C.
+func h2cProtocols(tls bool) *http.Protocols {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	protocols.SetHTTP2(tls)
+	return protocols
+}
+
+// ListenAndServe listens on the configured listener or address and serves requests,
+// over TLS when configured. Like http.Server, it returns http.ErrServerClosed after
+// Shutdown or Close.
+func (server *Server) ListenAndServe() error {
+	listener := server.listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", server.Addr)
+		if err != nil {
+			return err
+		}
+	}
+
+	if server.logger != nil {
+		server.logger.Info(
+			"HTTP server listening",
+			slog.String("addr", listener.Addr().String()),
+			slog.Bool("tls", server.TLSConfig != nil),
+		)
+	}
+
+	if server.TLSConfig != nil {
+		return server.ServeTLS(listener, server.certFile, server.keyFile)
+	}
+	return server.Serve(listener)
+}
+
+// Run serves requests until the context is canceled, then shuts down gracefully within
+// the shutdown timeout. It returns nil after a graceful shutdown.
+func (server *Server) Run(ctx context.Context) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	if server.logger != nil {
+		server.logger.Info("HTTP server shutting down")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), server.shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
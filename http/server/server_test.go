@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (suite *ServerTestSuite) TestItSetsSafeDefaults() {
+	server := New(http.NotFoundHandler())
+
+	suite.Equal(DefaultAddr, server.Addr)
+	suite.Equal(DefaultReadHeaderTimeout, server.ReadHeaderTimeout)
+	suite.Equal(DefaultReadTimeout, server.ReadTimeout)
+	suite.Equal(DefaultWriteTimeout, server.WriteTimeout)
+	suite.Equal(DefaultIdleTimeout, server.IdleTimeout)
+	suite.Equal(DefaultMaxHeaderBytes, server.MaxHeaderBytes)
+	suite.Nil(server.TLSConfig)
+	suite.Nil(server.ErrorLog)
+}
+
+func (suite *ServerTestSuite) TestItCanApplyOptions() {
+	server := New(
+		http.NotFoundHandler(),
+		WithAddr(":9090"),
+		WithReadHeaderTimeout(time.Second),
+		WithReadTimeout(2*time.Second),
+		WithWriteTimeout(0),
+		WithIdleTimeout(3*time.Second),
+		WithMaxHeaderBytes(1024),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithTLSCertificates("cert.pem", "key.pem"),
+		WithHTTP2(http.HTTP2Config{MaxConcurrentStreams: 50}),
+	)
+
+	suite.Equal(":9090", server.Addr)
+	suite.Equal(time.Second, server.ReadHeaderTimeout)
+	suite.Equal(2*time.Second, server.ReadTimeout)
+	suite.Equal(time.Duration(0), server.WriteTimeout)
+	suite.Equal(3*time.Second, server.IdleTimeout)
+	suite.Equal(1024, server.MaxHeaderBytes)
+	suite.NotNil(server.ErrorLog)
+	suite.Require().NotNil(server.TLSConfig)
+	suite.Equal("cert.pem", server.certFile)
+	suite.Require().NotNil(server.HTTP2)
+	suite.Equal(50, server.HTTP2.MaxConcurrentStreams)
+}
+
+func (suite *ServerTestSuite) TestItRunsUntilContextIsCanceled() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	logs := new(bytes.Buffer)
+	server := New(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) }),
+		WithListener(listener),
+		WithLogger(slog.New(slog.NewTextHandler(logs, nil))),
+		WithShutdownTimeout(time.Second),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run(ctx) }()
+
+	response, err := http.Get("http://" + listener.Addr().String())
+	suite.Require().NoError(err)
+	body, _ := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	suite.Equal("ok", string(body))
+
+	cancel()
+	select {
+	case err := <-runErr:
+		suite.NoError(err)
+	case <-time.After(2 * time.Second):
+		suite.Fail("server did not shut down")
+	}
+
+	suite.Contains(logs.String(), "HTTP server listening")
+	suite.Contains(logs.String(), "HTTP server shutting down")
+}
+
+func (suite *ServerTestSuite) TestRunReturnsListenErrors() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer func() { _ = listener.Close() }()
+
+	server := New(http.NotFoundHandler(), WithAddr(listener.Addr().String()))
+
+	suite.Error(server.Run(context.Background()))
+}
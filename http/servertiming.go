@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Names of the timings recorded by the components of this module
+const (
+	TimingSession  = "session"
+	TimingTemplate = "template"
+)
+
+// ServerTimings collects the durations contributed by the components serving a request
+// (session load, template render, queries...), reported in the Server-Timing header by
+// the ServerTiming middleware. Durations added under the same name are summed.
+// It is safe for concurrent use and a nil collector discards the timings.
+type ServerTimings struct {
+	mu      sync.Mutex
+	entries []ServerTiming
+}
+
+// ServerTiming is a named duration. The name must be a token, e.g. "db" or "cache-miss".
+type ServerTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+type serverTimingsContextKey struct{}
+
+// WithServerTimings returns a context carrying the timings collector of the request
+func WithServerTimings(ctx context.Context, timings *ServerTimings) context.Context {
+	return context.WithValue(ctx, serverTimingsContextKey{}, timings)
+}
+
+// ServerTimingsFromContext returns the timings collector stored in the context or nil
+func ServerTimingsFromContext(ctx context.Context) *ServerTimings {
+	if ctx == nil {
+		return nil
+	}
+	timings, _ := ctx.Value(serverTimingsContextKey{}).(*ServerTimings)
+	return timings
+}
+
+// StartTiming starts timing name on the collector stored in the context and returns the
+// function stopping it, e.g. defer StartTiming(ctx, "db")(). It is a no-op when the
+// context carries no collector.
+func StartTiming(ctx context.Context, name string) func() {
+	return ServerTimingsFromContext(ctx).Start(name)
+}
+
+// Add adds the duration to the timing name
+func (st *ServerTimings) Add(name string, duration time.Duration) {
+	if st == nil {
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for i := range st.entries {
+		if st.entries[i].Name == name {
+			st.entries[i].Duration += duration
+			return
+		}
+	}
+	st.entries = append(st.entries, ServerTiming{Name: name, Duration: duration})
+}
+
+// Start starts timing name and returns the function stopping it, adding the elapsed time
+func (st *ServerTimings) Start(name string) func() {
+	if st == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		st.Add(name, time.Since(start))
+	}
+}
+
+// Entries returns a copy of the collected timings, in the order they were first added
+func (st *ServerTimings) Entries() []ServerTiming {
+	if st == nil {
+		return nil
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	entries := make([]ServerTiming, len(st.entries))
+	copy(entries, st.entries)
+	return entries
+}
+
+// requestTimings returns the timings collector of the request, nil when it has none
+func requestTimings(r *http.Request) *ServerTimings {
+	if r == nil {
+		return nil
+	}
+	return ServerTimingsFromContext(r.Context())
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTimingsSuite struct {
+	suite.Suite
+}
+
+func TestServerTimingsSuite(t *testing.T) {
+	suite.Run(t, new(ServerTimingsSuite))
+}
+
+func (suite *ServerTimingsSuite) TestItCanCollectTimings() {
+	timings := &ServerTimings{}
+	timings.Add("db", 2*time.Millisecond)
+	timings.Add("cache", time.Millisecond)
+	timings.Add("db", 3*time.Millisecond)
+	timings.Start("render")()
+
+	entries := timings.Entries()
+	suite.Len(entries, 3)
+	suite.Equal(ServerTiming{Name: "db", Duration: 5 * time.Millisecond}, entries[0])
+	suite.Equal(ServerTiming{Name: "cache", Duration: time.Millisecond}, entries[1])
+	suite.Equal("render", entries[2].Name)
+}
+
+func (suite *ServerTimingsSuite) TestItCanStoreTheCollectorInTheContext() {
+	suite.Nil(ServerTimingsFromContext(context.Background()))
+	suite.NotPanics(StartTiming(context.Background(), "db"))
+
+	timings := &ServerTimings{}
+	ctx := WithServerTimings(context.Background(), timings)
+	suite.Same(timings, ServerTimingsFromContext(ctx))
+
+	StartTiming(ctx, "db")()
+	suite.Equal("db", timings.Entries()[0].Name)
+}
+
+func (suite *ServerTimingsSuite) TestItCanTimeTemplateRendering() {
+	renderer := NewTemplateRenderer(TemplateRendererOptions{})
+	suite.Require().NoError(renderer.Add("hello", template.Must(template.New("hello").Parse("hi"))))
+
+	timings := &ServerTimings{}
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request = request.WithContext(WithServerTimings(request.Context(), timings))
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		WithRequest(request).
+		Template().
+		Renderer(renderer).
+		Name("hello").
+		Send()
+
+	suite.NoError(err)
+	suite.Equal("hi", recorder.Body.String())
+	suite.Len(timings.Entries(), 1)
+	suite.Equal(TimingTemplate, timings.Entries()[0].Name)
+}
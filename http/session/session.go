@@ -14,6 +14,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/golibry/go-http/http/metrics"
 )
 
 // Errors
@@ -42,6 +44,13 @@ type Storage interface {
 	Exists(ctx context.Context, sessionID string) bool
 }
 
+// Counter is implemented by the storages able to count the live sessions, e.g. for
+// the debug handler
+type Counter interface {
+	// Count returns the number of non-expired sessions
+	Count(ctx context.Context) (int, error)
+}
+
 // Session represents a user session
 type Session interface {
 	// ID returns the session ID
@@ -151,6 +160,9 @@ type Options struct {
 	// Garbage collection
 	GCInterval time.Duration
 
+	// Metrics, when set, observes the garbage collection runs as metrics.SessionGCDuration
+	Metrics metrics.Recorder
+
 	// Security
 	SecureRandom bool
 }
@@ -339,9 +351,7 @@ func (m *ManagerImpl) StartGC(ctx context.Context) {
 		for {
 			select {
 			case <-m.gcTicker.C:
-				if err := m.storage.Cleanup(ctx); err != nil && m.logger != nil {
-					m.logger.ErrorContext(ctx, "Session garbage collection failed", "error", err)
-				}
+				m.collectGarbage(ctx)
 			case <-m.gcStop:
 				return
 			}
@@ -349,6 +359,27 @@ func (m *ManagerImpl) StartGC(ctx context.Context) {
 	}()
 }
 
+// collectGarbage runs a garbage collection, logging failures and observing its duration
+func (m *ManagerImpl) collectGarbage(ctx context.Context) {
+	start := time.Now()
+	err := m.storage.Cleanup(ctx)
+	if err != nil && m.logger != nil {
+		m.logger.ErrorContext(ctx, "Session garbage collection failed", "error", err)
+	}
+
+	if m.options.Metrics != nil {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		m.options.Metrics.ObserveHistogram(
+			metrics.SessionGCDuration,
+			time.Since(start).Seconds(),
+			metrics.Labels{"result": result},
+		)
+	}
+}
+
 // StopGC stops garbage collection
 func (m *ManagerImpl) StopGC() {
 	m.mu.Lock()
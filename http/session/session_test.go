@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golibry/go-http/http/metrics"
 	"github.com/golibry/go-http/http/session/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -303,6 +304,20 @@ func (suite *SessionTestSuite) TestItCanStartAndStopGarbageCollection() {
 	suite.False(managerImpl.gcRunning)
 }
 
+func (suite *SessionTestSuite) TestItCanObserveGarbageCollectionRuns() {
+	recorder := metrics.NewMemory()
+	options := DefaultOptions()
+	options.Metrics = recorder
+	manager := NewManager(suite.storage, suite.ctx, suite.logger, options)
+
+	manager.collectGarbage(suite.ctx)
+
+	suite.Len(
+		recorder.Observations(metrics.SessionGCDuration, metrics.Labels{"result": "success"}),
+		1,
+	)
+}
+
 func (suite *SessionTestSuite) TestMemoryStorageCanCleanupExpiredSessions() {
 	// Arrange
 	memStorage := storage.NewMemoryStorage()
@@ -326,6 +341,17 @@ func (suite *SessionTestSuite) TestMemoryStorageCanCleanupExpiredSessions() {
 	suite.True(memStorage.Exists(suite.ctx, "valid_session"))
 }
 
+func (suite *SessionTestSuite) TestMemoryStorageCanCountLiveSessions() {
+	memStorage := storage.NewMemoryStorage()
+	suite.NoError(memStorage.Set(suite.ctx, "expired_session", []byte("expired"), -time.Hour))
+	suite.NoError(memStorage.Set(suite.ctx, "valid_session", []byte("valid"), time.Hour))
+
+	count, err := memStorage.Count(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
 // Additional unit tests
 func TestDefaultOptions(t *testing.T) {
 	options := DefaultOptions()
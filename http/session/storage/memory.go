@@ -96,3 +96,18 @@ func (ms *MemoryStorage) Exists(_ context.Context, sessionID string) bool {
 	}
 	return time.Now().Before(s.expiresAt)
 }
+
+// Count returns the number of non-expired sessions
+func (ms *MemoryStorage) Count(_ context.Context) (int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	now := time.Now()
+	count := 0
+	for _, s := range ms.sessions {
+		if now.Before(s.expiresAt) {
+			count++
+		}
+	}
+	return count, nil
+}
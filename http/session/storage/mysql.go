@@ -114,6 +114,17 @@ func (ms *MySQLStorage) Exists(ctx context.Context, sessionID string) bool {
 	return true
 }
 
+// Count returns the number of non-expired sessions.
+func (ms *MySQLStorage) Count(ctx context.Context) (int, error) {
+	nowSec := time.Now().UTC().Unix()
+	query := "SELECT COUNT(*) FROM `" + ms.tableName + "` WHERE `expires_at` > ?"
+	var count int
+	if err := ms.db.QueryRowContext(ctx, query, nowSec).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // Init creates the sessions' table if it does not exist using BIGINT unix timestamps.
 func (ms *MySQLStorage) Init(ctx context.Context) error {
 	if ms.db == nil || ms.tableName == "" {
@@ -149,6 +149,10 @@ func (s *MySQLStorageIntegrationSuite) TestItExpiresAndCleansUp() {
 	s.Require().NoError(s.store.Set(s.ctx, id1, []byte("short"), 1*time.Second))
 	s.Require().NoError(s.store.Set(s.ctx, id2, []byte("short2"), 1*time.Second))
 
+	live, err := s.store.Count(s.ctx)
+	s.Require().NoError(err)
+	s.GreaterOrEqual(live, 2)
+
 	// Wait to expire
 	time.Sleep(1500 * time.Millisecond)
 
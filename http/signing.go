@@ -0,0 +1,61 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// SignatureHeader is the header carrying the HMAC signature of signed response bodies
+const SignatureHeader = "X-Signature"
+
+// SigningKey is a shared secret used to sign response bodies, identified by an ID so
+// consumers can pick the right secret and keys can be rotated
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
+// Sign adds the HMAC-SHA256 signature of the body in the SignatureHeader, for webhook
+// style endpoints whose consumers verify the payload integrity (see VerifySignature).
+// The body is signed before compression. It applies to JSON, text, HTML, template,
+// negotiated and error responses.
+func (rb *ResponseBuilder) Sign(key SigningKey) *ResponseBuilder {
+	rb.signingKey = &key
+	return rb
+}
+
+// Signature returns the signature header value of the body:
+// keyId="<id>",algorithm="hmac-sha256",signature="<base64 HMAC-SHA256>"
+func Signature(key SigningKey, body []byte) string {
+	return `keyId="` + key.ID + `",algorithm="hmac-sha256",signature="` +
+		base64.StdEncoding.EncodeToString(computeHMAC(key.Secret, body)) + `"`
+}
+
+// VerifySignature tells whether the signature header value was produced for the body
+// with the key, comparing the HMACs in constant time
+func VerifySignature(key SigningKey, body []byte, signature string) bool {
+	params := make(map[string]string, 3)
+	for _, param := range strings.Split(signature, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found {
+			params[name] = strings.Trim(value, `"`)
+		}
+	}
+	if params["keyId"] != key.ID || params["algorithm"] != "hmac-sha256" {
+		return false
+	}
+
+	mac, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac, computeHMAC(key.Secret, body))
+}
+
+func computeHMAC(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
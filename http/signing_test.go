@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SigningSuite struct {
+	suite.Suite
+}
+
+func TestSigningSuite(t *testing.T) {
+	suite.Run(t, new(SigningSuite))
+}
+
+var testSigningKey = SigningKey{ID: "key-1", Secret: []byte("webhook secret")}
+
+func (suite *SigningSuite) TestItCanSignResponseBodies() {
+	recorder := httptest.NewRecorder()
+
+	err := NewResponseBuilder(recorder).
+		Sign(testSigningKey).
+		JSON().
+		Data(map[string]string{"event": "order.paid"}).
+		Send()
+
+	suite.Require().NoError(err)
+	signature := recorder.Header().Get(SignatureHeader)
+	suite.Equal(
+		`keyId="key-1",algorithm="hmac-sha256",signature="`+
+			`u0B9s8W1boCiprq5/LxMtD3n85040hasjM9Xhyxmk9Q="`,
+		signature,
+	)
+	suite.True(VerifySignature(testSigningKey, recorder.Body.Bytes(), signature))
+}
+
+func (suite *SigningSuite) TestItCanVerifySignatures() {
+	body := []byte(`{"event":"order.paid"}`)
+	signature := Signature(testSigningKey, body)
+
+	testCases := map[string]struct {
+		key       SigningKey
+		body      []byte
+		signature string
+		expected  bool
+	}{
+		"valid":             {testSigningKey, body, signature, true},
+		"tampered body":     {testSigningKey, []byte(`{"event":"order.refunded"}`), signature, false},
+		"other secret":      {SigningKey{ID: "key-1", Secret: []byte("other")}, body, signature, false},
+		"other key id":      {SigningKey{ID: "key-2", Secret: testSigningKey.Secret}, body, signature, false},
+		"malformed":         {testSigningKey, body, `keyId="key-1",algorithm="hmac-sha256",signature="%%"`, false},
+		"unknown algorithm": {testSigningKey, body, `keyId="key-1",algorithm="md5",signature=""`, false},
+		"empty":             {testSigningKey, body, "", false},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				suite.Equal(
+					testCase.expected,
+					VerifySignature(testCase.key, testCase.body, testCase.signature),
+				)
+			},
+		)
+	}
+}
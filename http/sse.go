@@ -0,0 +1,270 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrStreamClosed is returned when writing to a closed event stream
+var ErrStreamClosed = errors.New("event stream closed")
+
+// SSEEvent is a single Server-Sent Event
+//
+// ID: event id, sent back by browsers in Last-Event-ID when reconnecting
+// Event: event name (browsers dispatch unnamed events as "message")
+// Data: payload; strings and byte slices are sent as is, other values JSON encoded
+// Retry: reconnection delay requested from the client (0 = not sent)
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  interface{}
+	Retry time.Duration
+}
+
+// SSEResponseBuilder streams Server-Sent Events (text/event-stream). Every event is
+// flushed to the client as soon as it is written. Writes are safe for concurrent use
+// and fail with a WriteAbortedError once the context (see WithContext) is done, which
+// also stops the heartbeat.
+type SSEResponseBuilder struct {
+	*ResponseBuilder
+	ctx        context.Context
+	heartbeat  time.Duration
+	controller *http.ResponseController
+
+	mu     sync.Mutex
+	opened bool
+	closed bool
+	stop   chan struct{}
+}
+
+// SSE creates a new Server-Sent Events response builder
+func (rb *ResponseBuilder) SSE() *SSEResponseBuilder {
+	rb.Header("Content-Type", "text/event-stream")
+	rb.Header("Cache-Control", "no-cache")
+	// Disables response buffering in reverse proxies such as nginx
+	rb.Header("X-Accel-Buffering", "no")
+	return &SSEResponseBuilder{
+		ResponseBuilder: rb,
+		controller:      http.NewResponseController(rb.writer),
+		stop:            make(chan struct{}),
+	}
+}
+
+// WithContext ties the stream to the context, so writes stop once the client
+// disconnected. It defaults to the request context when a request was set.
+func (sb *SSEResponseBuilder) WithContext(ctx context.Context) *SSEResponseBuilder {
+	sb.ctx = ctx
+	return sb
+}
+
+// WithHeartbeat sends a comment line at the given interval while the stream is open,
+// keeping idle connections alive through proxies and detecting gone clients
+func (sb *SSEResponseBuilder) WithHeartbeat(interval time.Duration) *SSEResponseBuilder {
+	sb.heartbeat = interval
+	return sb
+}
+
+// Open writes the response headers and starts the heartbeat. It is called by the first
+// write and only needs to be called explicitly to start the stream without an event.
+func (sb *SSEResponseBuilder) Open() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return sb.open()
+}
+
+// Event sends a named event
+func (sb *SSEResponseBuilder) Event(name string, data interface{}) error {
+	return sb.Send(SSEEvent{Event: name, Data: data})
+}
+
+// Data sends an unnamed event
+func (sb *SSEResponseBuilder) Data(data interface{}) error {
+	return sb.Send(SSEEvent{Data: data})
+}
+
+// Comment sends a comment line, ignored by clients
+func (sb *SSEResponseBuilder) Comment(text string) error {
+	var frame strings.Builder
+	for _, line := range splitLines(text) {
+		frame.WriteString(": " + line + "\n")
+	}
+	return sb.write(frame.String() + "\n")
+}
+
+// Retry sets the reconnection delay used by clients after a disconnection
+func (sb *SSEResponseBuilder) Retry(delay time.Duration) error {
+	return sb.Send(SSEEvent{Retry: delay})
+}
+
+// Send writes the event
+func (sb *SSEResponseBuilder) Send(event SSEEvent) error {
+	var frame strings.Builder
+	if event.ID != "" {
+		frame.WriteString("id: " + singleLine(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		frame.WriteString("event: " + singleLine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		frame.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	if event.Data != nil {
+		data, err := sseData(event.Data)
+		if err != nil {
+			return err
+		}
+		for _, line := range splitLines(data) {
+			frame.WriteString("data: " + line + "\n")
+		}
+	}
+	return sb.write(frame.String() + "\n")
+}
+
+// Stream sends the events received from the channel until it is closed or the context
+// is done, then closes the stream
+func (sb *SSEResponseBuilder) Stream(events <-chan SSEEvent) error {
+	defer sb.Close()
+
+	if err := sb.Open(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-sb.context().Done():
+			return aborted(sb.context())
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := sb.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close stops the heartbeat; later writes fail with ErrStreamClosed. The response ends
+// when the handler returns, which must not happen before Close with a heartbeat set.
+func (sb *SSEResponseBuilder) Close() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if !sb.closed {
+		sb.closed = true
+		close(sb.stop)
+	}
+}
+
+// context returns the context bounding the stream
+func (sb *SSEResponseBuilder) context() context.Context {
+	if sb.ctx != nil {
+		return sb.ctx
+	}
+	return sb.writeContext()
+}
+
+// write sends the frame and flushes it, opening the stream first when needed
+func (sb *SSEResponseBuilder) write(frame string) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if err := sb.open(); err != nil {
+		return err
+	}
+	return sb.writeFrame(frame)
+}
+
+// open must be called with the mutex held
+func (sb *SSEResponseBuilder) open() error {
+	if sb.closed {
+		return ErrStreamClosed
+	}
+	if err := aborted(sb.context()); err != nil {
+		return err
+	}
+	if sb.opened {
+		return nil
+	}
+
+	sb.opened = true
+	sb.writeHeaders()
+	if err := sb.flush(); err != nil {
+		return err
+	}
+	if sb.heartbeat > 0 {
+		go sb.sendHeartbeats()
+	}
+	return nil
+}
+
+// writeFrame must be called with the mutex held
+func (sb *SSEResponseBuilder) writeFrame(frame string) error {
+	if _, err := sb.writer.Write([]byte(frame)); err != nil {
+		return err
+	}
+	return sb.flush()
+}
+
+// flush sends the buffered data, tolerating writers unable to flush
+func (sb *SSEResponseBuilder) flush() error {
+	if err := sb.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func (sb *SSEResponseBuilder) sendHeartbeats() {
+	ticker := time.NewTicker(sb.heartbeat)
+	defer ticker.Stop()
+
+	ctx := sb.context()
+	for {
+		select {
+		case <-sb.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sb.mu.Lock()
+			var err error
+			if !sb.closed {
+				err = sb.writeFrame(": heartbeat\n\n")
+			}
+			sb.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sseData renders the event payload
+func sseData(data interface{}) (string, error) {
+	switch value := data.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	default:
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	}
+}
+
+// splitLines splits on any line terminator allowed by the event stream format
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+}
+
+// singleLine drops line terminators from fields that cannot span lines
+func singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
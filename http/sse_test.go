@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SSESuite struct {
+	suite.Suite
+}
+
+func TestSSESuite(t *testing.T) {
+	suite.Run(t, new(SSESuite))
+}
+
+func (suite *SSESuite) TestItCanWriteEvents() {
+	testCases := map[string]struct {
+		write    func(stream *SSEResponseBuilder) error
+		expected string
+	}{
+		"named event": {
+			write:    func(stream *SSEResponseBuilder) error { return stream.Event("update", "hello") },
+			expected: "event: update\ndata: hello\n\n",
+		},
+		"unnamed event": {
+			write:    func(stream *SSEResponseBuilder) error { return stream.Data([]byte("hello")) },
+			expected: "data: hello\n\n",
+		},
+		"multi-line data": {
+			write:    func(stream *SSEResponseBuilder) error { return stream.Data("first\r\nsecond\nthird") },
+			expected: "data: first\ndata: second\ndata: third\n\n",
+		},
+		"json data": {
+			write: func(stream *SSEResponseBuilder) error {
+				return stream.Event("user", map[string]int{"id": 7})
+			},
+			expected: "event: user\ndata: {\"id\":7}\n\n",
+		},
+		"full event": {
+			write: func(stream *SSEResponseBuilder) error {
+				return stream.Send(SSEEvent{ID: "42", Event: "tick\n", Data: "1", Retry: 3 * time.Second})
+			},
+			expected: "id: 42\nevent: tick\nretry: 3000\ndata: 1\n\n",
+		},
+		"comment": {
+			write:    func(stream *SSEResponseBuilder) error { return stream.Comment("keep\nalive") },
+			expected: ": keep\n: alive\n\n",
+		},
+		"retry": {
+			write:    func(stream *SSEResponseBuilder) error { return stream.Retry(1500 * time.Millisecond) },
+			expected: "retry: 1500\n\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				stream := NewResponseBuilder(recorder).SSE()
+
+				suite.Require().NoError(testCase.write(stream))
+				suite.Equal(http.StatusOK, recorder.Code)
+				suite.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+				suite.Equal("no-cache", recorder.Header().Get("Cache-Control"))
+				suite.True(recorder.Flushed)
+				suite.Equal(testCase.expected, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *SSESuite) TestItStopsWritingOnceTheContextIsDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := httptest.NewRecorder()
+	stream := NewResponseBuilder(recorder).SSE().WithContext(ctx)
+
+	suite.Require().NoError(stream.Data("first"))
+	cancel()
+
+	suite.ErrorIs(stream.Data("second"), context.Canceled)
+	suite.Equal("data: first\n\n", recorder.Body.String())
+}
+
+func (suite *SSESuite) TestItCanStreamEventsFromChannel() {
+	events := make(chan SSEEvent, 2)
+	events <- SSEEvent{Event: "a", Data: "1"}
+	events <- SSEEvent{Event: "b", Data: "2"}
+	close(events)
+
+	recorder := httptest.NewRecorder()
+	stream := NewResponseBuilder(recorder).SSE()
+
+	suite.NoError(stream.Stream(events))
+	suite.Equal("event: a\ndata: 1\n\nevent: b\ndata: 2\n\n", recorder.Body.String())
+	suite.ErrorIs(stream.Data("late"), ErrStreamClosed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream = NewResponseBuilder(httptest.NewRecorder()).SSE().WithContext(ctx)
+	suite.ErrorIs(stream.Stream(make(chan SSEEvent)), context.Canceled)
+}
+
+func (suite *SSESuite) TestItCanSendHeartbeats() {
+	recorder := httptest.NewRecorder()
+	stream := NewResponseBuilder(recorder).SSE().WithHeartbeat(5 * time.Millisecond)
+
+	suite.Require().NoError(stream.Open())
+	time.Sleep(30 * time.Millisecond)
+	stream.Close()
+
+	suite.GreaterOrEqual(strings.Count(recorder.Body.String(), ": heartbeat\n\n"), 1)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *SSESuite) TestItStopsHeartbeatsOnceTheRequestContextIsDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	stream := NewResponseBuilder(recorder).WithRequest(request).SSE().
+		WithHeartbeat(5 * time.Millisecond)
+	body := func() string {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		return recorder.Body.String()
+	}
+
+	suite.Require().NoError(stream.Open())
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	written := body()
+	time.Sleep(30 * time.Millisecond)
+
+	suite.Equal(written, body())
+	suite.ErrorIs(stream.Data("late"), context.Canceled)
+}
+
+func (suite *SSESuite) TestItCanStreamOverHTTP() {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				stream := NewResponseBuilder(w).SSE().WithContext(r.Context())
+				_ = stream.Event("greeting", "hello")
+				<-r.Context().Done()
+			},
+		),
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.Require().NoError(err)
+	defer func() { _ = response.Body.Close() }()
+
+	// The event arrives while the handler is still running, thanks to the flush
+	buffer := make([]byte, len("event: greeting\ndata: hello\n\n"))
+	_, err = io.ReadFull(response.Body, buffer)
+	suite.Require().NoError(err)
+	suite.Equal("event: greeting\ndata: hello\n\n", string(buffer))
+}
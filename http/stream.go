@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// valueStream writes streamed values to the response, flushing after every write or,
+// with a flush interval, periodically while values are pending
+type valueStream struct {
+	ctx           context.Context
+	controller    *http.ResponseController
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending bool
+}
+
+func newValueStream(ctx context.Context, w http.ResponseWriter, flushInterval time.Duration) *valueStream {
+	return &valueStream{ctx: ctx, controller: http.NewResponseController(w), flushInterval: flushInterval}
+}
+
+// run writes open, every value and closing, in that order. It stops early with a
+// WriteAbortedError once the context is done, or with the first write error.
+func (vs *valueStream) run(
+	values iter.Seq[any],
+	open func() error,
+	write func(index int, value any) error,
+	closing func() error,
+) error {
+	if vs.flushInterval > 0 {
+		stop, stopped := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(stopped)
+			vs.flushPeriodically(stop)
+		}()
+		// The response must not be flushed anymore once the handler returned
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+	}
+
+	// The headers and opening are flushed right away, so clients see the response start
+	if err := vs.write(open); err != nil {
+		return err
+	}
+	vs.mu.Lock()
+	err := vs.flush()
+	vs.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if values != nil {
+		index := 0
+		for value := range values {
+			if err := aborted(vs.ctx); err != nil {
+				return err
+			}
+			if err := vs.write(func() error { return write(index, value) }); err != nil {
+				return err
+			}
+			index++
+		}
+	}
+
+	if err := aborted(vs.ctx); err != nil {
+		return err
+	}
+	if err := vs.write(closing); err != nil {
+		return err
+	}
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if vs.pending {
+		return vs.flush()
+	}
+	return nil
+}
+
+// write runs the write function, flushing right away unless flushes are periodic
+func (vs *valueStream) write(fn func() error) error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	vs.pending = true
+	if vs.flushInterval <= 0 {
+		return vs.flush()
+	}
+	return nil
+}
+
+// flush must be called with the mutex held; writers unable to flush are tolerated
+func (vs *valueStream) flush() error {
+	vs.pending = false
+	if err := vs.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func (vs *valueStream) flushPeriodically(stop <-chan struct{}) {
+	ticker := time.NewTicker(vs.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			vs.mu.Lock()
+			if vs.pending {
+				_ = vs.flush()
+			}
+			vs.mu.Unlock()
+		}
+	}
+}
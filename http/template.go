@@ -0,0 +1,332 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrTemplateNotFound is returned when rendering an unregistered template
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrLayoutNotFound is returned when rendering with an unregistered layout
+	ErrLayoutNotFound = errors.New("layout not found")
+)
+
+// DefaultTemplateRenderer is the renderer used by template responses not given one
+var DefaultTemplateRenderer = NewTemplateRenderer(TemplateRendererOptions{})
+
+// TemplateRendererOptions configures a TemplateRenderer
+//
+// Funcs: functions available to every template and layout
+// DefaultLayout: layout wrapping templates rendered without an explicit layout
+// ("" = templates are rendered on their own)
+// Reload: reparse the templates and layouts registered with ParseFS and ParseLayoutFS
+// when their files changed, checked on every render (development only)
+type TemplateRendererOptions struct {
+	Funcs         template.FuncMap
+	DefaultLayout string
+	Reload        bool
+}
+
+// TemplateRenderer holds named html/template sets. Layouts are template sets executed
+// around a page: the page templates are added to a copy of the layout, so a layout
+// calling {{template "content" .}} or declaring {{block "content" .}} renders the
+// "content" template defined by the page. Sets are parsed once and compositions cached
+// on first use; see TemplateRendererOptions.Reload for development.
+type TemplateRenderer struct {
+	mu        sync.RWMutex
+	options   TemplateRendererOptions
+	templates map[string]*template.Template
+	sources   map[string]*template.Template
+	layouts   map[string]*template.Template
+	composed  map[[2]string]*template.Template
+	files     map[templateKey]*templateFiles
+}
+
+// templateKey identifies a registered template or layout set
+type templateKey struct {
+	name   string
+	layout bool
+}
+
+// templateFiles remembers the files a set was parsed from, to reload it when they change
+type templateFiles struct {
+	fsys     fs.FS
+	patterns []string
+	stamp    string
+}
+
+// NewTemplateRenderer creates an empty template renderer
+func NewTemplateRenderer(options TemplateRendererOptions) *TemplateRenderer {
+	return &TemplateRenderer{
+		options:   options,
+		templates: make(map[string]*template.Template),
+		sources:   make(map[string]*template.Template),
+		layouts:   make(map[string]*template.Template),
+		composed:  make(map[[2]string]*template.Template),
+		files:     make(map[templateKey]*templateFiles),
+	}
+}
+
+// Add registers a parsed template set under the name, replacing any previous one. The
+// set must not have been executed yet.
+func (tr *TemplateRenderer) Add(name string, tmpl *template.Template) error {
+	// Executing a set escapes its trees in place, so layouts are composed from a copy
+	// kept unexecuted
+	source, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.templates[name] = tmpl
+	tr.sources[name] = source
+	delete(tr.files, templateKey{name: name})
+	tr.resetComposed()
+	return nil
+}
+
+// AddLayout registers a parsed layout set under the name, replacing any previous one
+func (tr *TemplateRenderer) AddLayout(name string, layout *template.Template) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.layouts[name] = layout
+	delete(tr.files, templateKey{name: name, layout: true})
+	tr.resetComposed()
+}
+
+// ParseFS parses the files matching the patterns into a template set registered
+// under the name
+func (tr *TemplateRenderer) ParseFS(name string, fsys fs.FS, patterns ...string) error {
+	return tr.parseFiles(templateKey{name: name}, fsys, patterns)
+}
+
+// ParseLayoutFS parses the files matching the patterns into a layout set registered
+// under the name. The first file is the layout entry point.
+func (tr *TemplateRenderer) ParseLayoutFS(name string, fsys fs.FS, patterns ...string) error {
+	return tr.parseFiles(templateKey{name: name, layout: true}, fsys, patterns)
+}
+
+// parseFiles parses and registers a set, remembering its files when reloading is enabled
+func (tr *TemplateRenderer) parseFiles(key templateKey, fsys fs.FS, patterns []string) error {
+	var stamp string
+	if tr.options.Reload {
+		var err error
+		if stamp, err = filesStamp(fsys, patterns); err != nil {
+			return err
+		}
+	}
+
+	tmpl, err := tr.parseFS(key.name, fsys, patterns)
+	if err != nil {
+		return err
+	}
+	if key.layout {
+		tr.AddLayout(key.name, tmpl)
+	} else if err := tr.Add(key.name, tmpl); err != nil {
+		return err
+	}
+
+	if tr.options.Reload {
+		tr.mu.Lock()
+		tr.files[key] = &templateFiles{fsys: fsys, patterns: patterns, stamp: stamp}
+		tr.mu.Unlock()
+	}
+	return nil
+}
+
+// reload reparses the set when its files changed since it was parsed
+func (tr *TemplateRenderer) reload(key templateKey) error {
+	tr.mu.RLock()
+	files := tr.files[key]
+	tr.mu.RUnlock()
+	if files == nil {
+		return nil
+	}
+
+	stamp, err := filesStamp(files.fsys, files.patterns)
+	if err != nil || stamp == files.stamp {
+		return err
+	}
+	return tr.parseFiles(key, files.fsys, files.patterns)
+}
+
+// filesStamp fingerprints the files matching the patterns by name, size and
+// modification time
+func filesStamp(fsys fs.FS, patterns []string) (string, error) {
+	var stamp strings.Builder
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, match := range matches {
+			info, err := fs.Stat(fsys, match)
+			if err != nil {
+				return "", err
+			}
+			stamp.WriteString(match + ":" + strconv.FormatInt(info.Size(), 10) + ":" +
+				strconv.FormatInt(info.ModTime().UnixNano(), 10) + ";")
+		}
+	}
+	return stamp.String(), nil
+}
+
+// Render executes the named template, wrapped by the layout when one is given or set
+// as default. The "-" layout renders the template on its own.
+func (tr *TemplateRenderer) Render(w io.Writer, name, layout string, data interface{}) error {
+	if layout == "" {
+		layout = tr.options.DefaultLayout
+	}
+	if layout == "-" {
+		layout = ""
+	}
+
+	if tr.options.Reload {
+		if err := tr.reload(templateKey{name: name}); err != nil {
+			return err
+		}
+		if err := tr.reload(templateKey{name: layout, layout: true}); err != nil {
+			return err
+		}
+	}
+
+	tmpl, err := tr.lookup(name, layout)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+// lookup returns the template to execute, composing it with the layout on first use
+func (tr *TemplateRenderer) lookup(name, layout string) (*template.Template, error) {
+	key := [2]string{name, layout}
+
+	tr.mu.RLock()
+	tmpl, ok := tr.templates[name]
+	composed := tr.composed[key]
+	tr.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	if layout == "" {
+		return tmpl, nil
+	}
+	if composed != nil {
+		return composed, nil
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if composed := tr.composed[key]; composed != nil {
+		return composed, nil
+	}
+	base, ok := tr.layouts[layout]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLayoutNotFound, layout)
+	}
+
+	composed, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	for _, page := range tr.sources[name].Templates() {
+		if page.Tree == nil {
+			continue
+		}
+		if _, err := composed.AddParseTree(page.Name(), page.Tree.Copy()); err != nil {
+			return nil, err
+		}
+	}
+	tr.composed[key] = composed
+	return composed, nil
+}
+
+func (tr *TemplateRenderer) parseFS(name string, fsys fs.FS, patterns []string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(tr.options.Funcs).ParseFS(fsys, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	// The set is executed through its first file, as with template.ParseFS
+	if len(patterns) > 0 {
+		if matches, _ := fs.Glob(fsys, patterns[0]); len(matches) > 0 {
+			if first := tmpl.Lookup(path.Base(matches[0])); first != nil {
+				return first, nil
+			}
+		}
+	}
+	return tmpl, nil
+}
+
+// resetComposed drops the layout compositions; must be called with the mutex held
+func (tr *TemplateRenderer) resetComposed() {
+	tr.composed = make(map[[2]string]*template.Template)
+}
+
+// TemplateResponseBuilder builds HTML responses rendered from registered templates
+type TemplateResponseBuilder struct {
+	*ResponseBuilder
+	renderer *TemplateRenderer
+	name     string
+	layout   string
+	data     interface{}
+}
+
+// Template creates a new template response builder using DefaultTemplateRenderer
+func (rb *ResponseBuilder) Template() *TemplateResponseBuilder {
+	rb.Header("Content-Type", "text/html; charset=utf-8")
+	return &TemplateResponseBuilder{ResponseBuilder: rb, renderer: DefaultTemplateRenderer}
+}
+
+// Renderer sets the renderer holding the templates
+func (trb *TemplateResponseBuilder) Renderer(renderer *TemplateRenderer) *TemplateResponseBuilder {
+	trb.renderer = renderer
+	return trb
+}
+
+// Name sets the name of the rendered template
+func (trb *TemplateResponseBuilder) Name(name string) *TemplateResponseBuilder {
+	trb.name = name
+	return trb
+}
+
+// Layout sets the layout wrapping the template instead of the renderer default; "-"
+// renders the template without a layout
+func (trb *TemplateResponseBuilder) Layout(layout string) *TemplateResponseBuilder {
+	trb.layout = layout
+	return trb
+}
+
+// Data sets the data passed to the template
+func (trb *TemplateResponseBuilder) Data(data interface{}) *TemplateResponseBuilder {
+	trb.data = data
+	return trb
+}
+
+// Send renders the template and writes the response. Rendering happens before anything
+// is written, so a failing template can still be answered with an error response.
+// Given the request (WithRequest), the render time is added to its TimingTemplate timing.
+func (trb *TemplateResponseBuilder) Send() error {
+	var body bytes.Buffer
+	stop := requestTimings(trb.request).Start(TimingTemplate)
+	err := trb.renderer.Render(&body, trb.name, trb.layout, trb.data)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	return trb.writeBody(body.Bytes())
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateSuite struct {
+	suite.Suite
+	renderer *TemplateRenderer
+}
+
+func TestTemplateSuite(t *testing.T) {
+	suite.Run(t, new(TemplateSuite))
+}
+
+func (suite *TemplateSuite) SetupTest() {
+	views := fstest.MapFS{
+		"layouts/main.html": {
+			Data: []byte(`<html><title>{{block "title" .}}Site{{end}}</title>{{template "content" .}}</html>`),
+		},
+		"layouts/bare.html":   {Data: []byte(`<main>{{template "content" .}}</main>`)},
+		"user/show.html":      {Data: []byte(`{{define "title"}}{{.Name}}{{end}}{{define "content"}}<p>{{upper .Name}}</p>{{end}}`)},
+		"user/list.html":      {Data: []byte(`{{define "content"}}<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}`)},
+		"partials/badge.html": {Data: []byte(`<b>{{.}}</b>`)},
+	}
+
+	suite.renderer = NewTemplateRenderer(
+		TemplateRendererOptions{
+			Funcs:         template.FuncMap{"upper": strings.ToUpper},
+			DefaultLayout: "main",
+		},
+	)
+	suite.Require().NoError(suite.renderer.ParseLayoutFS("main", views, "layouts/main.html"))
+	suite.Require().NoError(suite.renderer.ParseLayoutFS("bare", views, "layouts/bare.html"))
+	suite.Require().NoError(suite.renderer.ParseFS("user/show", views, "user/show.html"))
+	suite.Require().NoError(suite.renderer.ParseFS("user/list", views, "user/list.html"))
+	suite.Require().NoError(suite.renderer.ParseFS("badge", views, "partials/badge.html"))
+}
+
+func (suite *TemplateSuite) TestItCanRenderTemplates() {
+	testCases := map[string]struct {
+		name         string
+		layout       string
+		data         interface{}
+		expectedBody string
+	}{
+		"default layout": {
+			name:         "user/show",
+			data:         map[string]string{"Name": "<ana>"},
+			expectedBody: `<html><title>&lt;ana&gt;</title><p>&lt;ANA&gt;</p></html>`,
+		},
+		"layout default block": {
+			name:         "user/list",
+			data:         []string{"a", "b"},
+			expectedBody: `<html><title>Site</title><ul><li>a</li><li>b</li></ul></html>`,
+		},
+		"explicit layout": {
+			name:         "user/list",
+			layout:       "bare",
+			data:         []string{"a"},
+			expectedBody: `<main><ul><li>a</li></ul></main>`,
+		},
+		"without layout": {
+			name:         "badge",
+			layout:       "-",
+			data:         "new",
+			expectedBody: `<b>new</b>`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := NewResponseBuilder(recorder).
+					Status(http.StatusCreated).
+					Template().
+					Renderer(suite.renderer).
+					Name(testCase.name).
+					Layout(testCase.layout).
+					Data(testCase.data).
+					Send()
+
+				suite.Require().NoError(err)
+				suite.Equal(http.StatusCreated, recorder.Code)
+				suite.Equal("text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *TemplateSuite) TestItCanRenderWithAndWithoutLayoutInAnyOrder() {
+	data := map[string]string{"Name": "<ana>"}
+
+	// Executing the template on its own first must not leak escaping into layouts
+	var body strings.Builder
+	suite.Require().NoError(suite.renderer.Render(&body, "user/show", "-", data))
+	suite.Require().NoError(suite.renderer.Render(&body, "user/show", "bare", data))
+	suite.Require().NoError(suite.renderer.Render(&body, "user/show", "-", data))
+
+	suite.Equal(`<main><p>&lt;ANA&gt;</p></main>`, body.String())
+}
+
+func (suite *TemplateSuite) TestItDoesNotWriteOnRenderingErrors() {
+	testCases := map[string]struct {
+		name        string
+		layout      string
+		expectedErr error
+	}{
+		"unknown template": {name: "missing", expectedErr: ErrTemplateNotFound},
+		"unknown layout":   {name: "badge", layout: "missing", expectedErr: ErrLayoutNotFound},
+		"execution error":  {name: "user/show"},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				err := NewResponseBuilder(recorder).
+					Template().
+					Renderer(suite.renderer).
+					Name(testCase.name).
+					Layout(testCase.layout).
+					Data(42).
+					Send()
+
+				suite.Error(err)
+				if testCase.expectedErr != nil {
+					suite.ErrorIs(err, testCase.expectedErr)
+				}
+				suite.Zero(recorder.Body.Len())
+				suite.Empty(recorder.Header())
+			},
+		)
+	}
+}
+
+func (suite *TemplateSuite) TestItCanReloadChangedTemplates() {
+	views := fstest.MapFS{
+		"layout.html": {Data: []byte(`<main>{{template "content" .}}</main>`)},
+		"page.html":   {Data: []byte(`{{define "content"}}v1{{end}}`)},
+	}
+
+	testCases := map[string]struct {
+		reload       bool
+		expectedBody string
+	}{
+		"cached":    {reload: false, expectedBody: `<main>v1</main><main>v1</main>`},
+		"reloading": {reload: true, expectedBody: `<main>v1</main><section>v2</section>`},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				renderer := NewTemplateRenderer(
+					TemplateRendererOptions{DefaultLayout: "main", Reload: testCase.reload},
+				)
+				suite.Require().NoError(renderer.ParseLayoutFS("main", views, "layout.html"))
+				suite.Require().NoError(renderer.ParseFS("page", views, "page.html"))
+				var body strings.Builder
+				suite.Require().NoError(renderer.Render(&body, "page", "", nil))
+
+				layout, page := views["layout.html"], views["page.html"]
+				defer func() { views["layout.html"], views["page.html"] = layout, page }()
+				views["layout.html"] = &fstest.MapFile{
+					Data:    []byte(`<section>{{template "content" .}}</section>`),
+					ModTime: time.Unix(1, 0),
+				}
+				views["page.html"] = &fstest.MapFile{
+					Data:    []byte(`{{define "content"}}v2{{end}}`),
+					ModTime: time.Unix(1, 0),
+				}
+				suite.Require().NoError(renderer.Render(&body, "page", "", nil))
+
+				suite.Equal(testCase.expectedBody, body.String())
+			},
+		)
+	}
+}
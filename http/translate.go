@@ -0,0 +1,86 @@
+package http
+
+import "strings"
+
+// Translator localizes error messages. It returns the message identified by messageID in
+// the locale, and false when it has no translation.
+type Translator interface {
+	Translate(locale, messageID string) (string, bool)
+}
+
+// TranslatorFunc adapts a function to the Translator interface
+type TranslatorFunc func(locale, messageID string) (string, bool)
+
+// Translate calls f(locale, messageID)
+func (f TranslatorFunc) Translate(locale, messageID string) (string, bool) {
+	return f(locale, messageID)
+}
+
+// MessageCatalog is an in-memory Translator holding the messages of every locale keyed by
+// message ID. Regional locales without the message fall back to their base language
+// ("pt-BR" to "pt").
+type MessageCatalog map[string]map[string]string
+
+// Translate implements the Translator interface
+func (mc MessageCatalog) Translate(locale, messageID string) (string, bool) {
+	for locale != "" {
+		if message, ok := mc[locale][messageID]; ok {
+			return message, true
+		}
+		index := strings.LastIndexByte(locale, '-')
+		if index < 0 {
+			break
+		}
+		locale = locale[:index]
+	}
+	return "", false
+}
+
+// WithTranslator localizes the error message: the message ID (see WithMessageID, falling
+// back to the error code) is translated into the locale (see WithLocale, falling back to
+// the request or builder context locale) and replaces the message unless one was set with
+// WithMessage. Translated responses carry a Content-Language header.
+func (erb *ErrorResponseBuilder) WithTranslator(translator Translator) *ErrorResponseBuilder {
+	erb.translator = translator
+	return erb
+}
+
+// WithLocale sets the locale error messages are translated into
+func (erb *ErrorResponseBuilder) WithLocale(locale string) *ErrorResponseBuilder {
+	erb.locale = locale
+	return erb
+}
+
+// WithMessageID sets the ID of the message translated by the translator
+func (erb *ErrorResponseBuilder) WithMessageID(messageID string) *ErrorResponseBuilder {
+	erb.messageID = messageID
+	return erb
+}
+
+// translate returns the message translated into the resolved locale
+func (erb *ErrorResponseBuilder) translate(code string) (string, bool) {
+	if erb.translator == nil || erb.message != "" {
+		return "", false
+	}
+
+	messageID := erb.messageID
+	if messageID == "" {
+		messageID = code
+	}
+	locale := erb.locale
+	if locale == "" && erb.request != nil {
+		locale = LocaleFromContext(erb.request.Context())
+	}
+	if locale == "" {
+		locale = LocaleFromContext(erb.ctx)
+	}
+	if messageID == "" || locale == "" {
+		return "", false
+	}
+
+	message, ok := erb.translator.Translate(locale, messageID)
+	if ok {
+		erb.Header("Content-Language", locale)
+	}
+	return message, ok
+}
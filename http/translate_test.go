@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TranslateSuite struct {
+	suite.Suite
+}
+
+func TestTranslateSuite(t *testing.T) {
+	suite.Run(t, new(TranslateSuite))
+}
+
+var testCatalog = MessageCatalog{
+	"fr":    {"order.locked": "commande verrouillée", "generic": "erreur"},
+	"pt":    {"order.locked": "pedido bloqueado"},
+	"pt-BR": {"generic": "erro"},
+}
+
+func (suite *TranslateSuite) TestItCanLookUpCatalogMessages() {
+	testCases := map[string]struct {
+		locale     string
+		messageID  string
+		expected   string
+		expectedOk bool
+	}{
+		"exact locale":        {"fr", "order.locked", "commande verrouillée", true},
+		"regional locale":     {"pt-BR", "generic", "erro", true},
+		"base fallback":       {"pt-BR", "order.locked", "pedido bloqueado", true},
+		"unknown message":     {"fr", "missing", "", false},
+		"unknown locale":      {"de", "generic", "", false},
+		"empty locale":        {"", "generic", "", false},
+		"no region separator": {"fr-", "generic", "erreur", true},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				message, ok := testCatalog.Translate(testCase.locale, testCase.messageID)
+
+				suite.Equal(testCase.expected, message)
+				suite.Equal(testCase.expectedOk, ok)
+			},
+		)
+	}
+}
+
+func (suite *TranslateSuite) TestItCanTranslateErrorMessages() {
+	locked := CustomHTTPError{message: "order is locked", statusCode: http.StatusConflict}
+	frenchRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+	frenchRequest = frenchRequest.WithContext(WithLocale(frenchRequest.Context(), "fr"))
+
+	testCases := map[string]struct {
+		configure        func(builder *ErrorResponseBuilder)
+		expectedMessage  string
+		expectedLanguage string
+	}{
+		"code from request locale": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(frenchRequest).WithCode("order.locked")
+			},
+			expectedMessage:  "commande verrouillée",
+			expectedLanguage: "fr",
+		},
+		"message id from context locale": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithContext(WithLocale(context.Background(), "pt-BR")).WithMessageID("generic")
+			},
+			expectedMessage:  "erro",
+			expectedLanguage: "pt-BR",
+		},
+		"explicit locale": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(frenchRequest).WithLocale("pt").WithCode("order.locked")
+			},
+			expectedMessage:  "pedido bloqueado",
+			expectedLanguage: "pt",
+		},
+		"explicit message": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(frenchRequest).WithCode("order.locked").WithMessage("Locked")
+			},
+			expectedMessage: "Locked",
+		},
+		"untranslated": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithRequest(frenchRequest).WithCode("order.unknown")
+			},
+			expectedMessage: "order is locked",
+		},
+		"no locale": {
+			configure: func(builder *ErrorResponseBuilder) {
+				builder.WithCode("order.locked")
+			},
+			expectedMessage: "order is locked",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				builder := NewResponseBuilder(recorder).
+					Error().
+					WithError(locked).
+					WithTranslator(testCatalog).
+					DisableLogging()
+				testCase.configure(builder)
+
+				suite.Require().NoError(builder.Send())
+				suite.Equal(testCase.expectedMessage, recorder.Body.String())
+				suite.Equal(testCase.expectedLanguage, recorder.Header().Get("Content-Language"))
+			},
+		)
+	}
+
+	recorder := httptest.NewRecorder()
+	err := NewResponseBuilder(recorder).
+		Error().
+		WithError(errors.New("failed")).
+		WithTranslator(TranslatorFunc(func(locale, messageID string) (string, bool) { return locale + ":" + messageID, true })).
+		WithLocale("fr").
+		WithMessageID("generic").
+		DisableLogging().
+		Send()
+
+	suite.Require().NoError(err)
+	suite.Equal("fr:generic", recorder.Body.String())
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type ResponseWriter struct {
+	http.ResponseWriter
+	statusCode   int
+	wroteHeader  bool
+	header       http.Header
+	onFirstWrite []func(header http.Header)
+	started      time.Time
+	firstByte    time.Time
+	finished     time.Time
+	logger       *slog.Logger
+	captureLimit int
+	captured     bytes.Buffer
+	truncated    bool
+	pooled       bool
+}
+
+var responseWriterPool = sync.Pool{
+	New: func() any {
+		return &ResponseWriter{pooled: true}
+	},
+}
+
+// AcquireResponseWriter returns a writer from a shared pool, to avoid an allocation per
+// request in middlewares wrapping every response. Call Release once the wrapped handler
+// returned; the writer must not be used afterwards.
+func AcquireResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	rw := responseWriterPool.Get().(*ResponseWriter)
+	rw.Reset(w)
+	return rw
+}
+
+// Release returns a writer obtained from AcquireResponseWriter to the pool. It is a
+// no-op for writers created with NewResponseWriter.
+func (rw *ResponseWriter) Release() {
+	if !rw.pooled {
+		return
+	}
+	rw.Reset(nil)
+	responseWriterPool.Put(rw)
+}
+
+// Reset clears the writer state so it can wrap another response, keeping the allocated
+// capture buffer and hook slice
+func (rw *ResponseWriter) Reset(w http.ResponseWriter) {
+	rw.ResponseWriter = w
+	rw.statusCode = http.StatusOK
+	rw.wroteHeader = false
+	rw.header = nil
+	clear(rw.onFirstWrite)
+	rw.onFirstWrite = rw.onFirstWrite[:0]
+	rw.started = time.Now()
+	rw.firstByte = time.Time{}
+	rw.finished = time.Time{}
+	rw.logger = nil
+	rw.captureLimit = 0
+	rw.captured.Reset()
+	rw.truncated = false
+}
+
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w, statusCode: http.StatusOK, started: time.Now()}
+}
+
+// WriteHeader sends the status code. Duplicate calls are dropped with a warning naming
+// the caller, instead of the "superfluous WriteHeader call" message of net/http.
+// Informational (1xx) codes other than 101 may precede the final status code.
+func (rw *ResponseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		rw.warnSuperfluousWriteHeader(code)
+		return
+	}
+	if code >= 100 && code <= 199 && code != http.StatusSwitchingProtocols {
+		rw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	rw.statusCode = code
+	rw.headerWritten()
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// headerWritten marks the header as written, snapshotting it the first time
+func (rw *ResponseWriter) headerWritten() {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+	rw.firstByte = time.Now()
+	for _, hook := range rw.onFirstWrite {
+		hook(rw.ResponseWriter.Header())
+	}
+	rw.header = rw.ResponseWriter.Header().Clone()
+}
+
+// OnFirstWrite registers a hook changing the header right before it is written, for
+// headers known late (Server-Timing, trailer declarations, session cookies). Hooks run
+// in registration order; those registered after the response started never run.
+func (rw *ResponseWriter) OnFirstWrite(hook func(header http.Header)) *ResponseWriter {
+	rw.onFirstWrite = append(rw.onFirstWrite, hook)
+	return rw
+}
+
+// WrittenHeader returns a copy of the header as it was when the response started, for
+// logging the final Content-Type or cache headers whatever the handler changed later.
+// It is nil while the header was not written.
+func (rw *ResponseWriter) WrittenHeader() http.Header {
+	return rw.header
+}
+
+// WroteHeader tells whether the status code was sent, explicitly or by a first write,
+// after which the response can no longer be replaced (e.g. by an error response)
+func (rw *ResponseWriter) WroteHeader() bool {
+	return rw.wroteHeader
+}
+
+// WithLogger sets the logger warning about duplicate WriteHeader calls (default:
+// slog.Default())
+func (rw *ResponseWriter) WithLogger(logger *slog.Logger) *ResponseWriter {
+	rw.logger = logger
+	return rw
+}
+
+// warnSuperfluousWriteHeader logs a duplicate WriteHeader call with its caller
+func (rw *ResponseWriter) warnSuperfluousWriteHeader(code int) {
+	logger := rw.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := []slog.Attr{slog.Int("StatusCode", rw.statusCode), slog.Int("IgnoredStatusCode", code)}
+	if _, file, line, ok := runtime.Caller(2); ok {
+		attrs = append(attrs, slog.String("Caller", file+":"+strconv.Itoa(line)))
+	}
+	logger.LogAttrs(context.Background(), slog.LevelWarn, "Superfluous WriteHeader call", attrs...)
+}
+
+// Finish records the completion of the response, called once the handler returned
+func (rw *ResponseWriter) Finish() {
+	rw.finished = time.Now()
+}
+
+// TimeToFirstByte returns the time from the writer creation until the response started,
+// i.e. the handler latency. It is zero while the response did not start.
+func (rw *ResponseWriter) TimeToFirstByte() time.Duration {
+	if rw.firstByte.IsZero() {
+		return 0
+	}
+	return rw.firstByte.Sub(rw.started)
+}
+
+// Duration returns the time from the writer creation until Finish, or until now before
+// Finish. Without the TimeToFirstByte part, it is the time spent writing the body.
+func (rw *ResponseWriter) Duration() time.Duration {
+	if rw.finished.IsZero() {
+		return time.Since(rw.started)
+	}
+	return rw.finished.Sub(rw.started)
+}
+
+func (rw *ResponseWriter) StatusCode() int {
+	return rw.statusCode
+}
+
+// CaptureBody tees up to limit bytes of the response body into a buffer readable with
+// CapturedBody once the handler returned, for body logging, caching or entity tags. The
+// body keeps going to the client untouched; copies through ReadFrom lose the sendfile
+// optimization while capturing.
+func (rw *ResponseWriter) CaptureBody(limit int) *ResponseWriter {
+	rw.captureLimit = limit
+	return rw
+}
+
+// CapturedBody returns the captured start of the body, see CaptureBody. The slice is
+// only valid until the next write.
+func (rw *ResponseWriter) CapturedBody() []byte {
+	return rw.captured.Bytes()
+}
+
+// BodyTruncated tells whether the body exceeded the capture limit
+func (rw *ResponseWriter) BodyTruncated() bool {
+	return rw.truncated
+}
+
+// Write writes the body, capturing it when asked to
+func (rw *ResponseWriter) Write(b []byte) (int, error) {
+	rw.headerWritten()
+	n, err := rw.ResponseWriter.Write(b)
+	if rw.captureLimit > 0 {
+		rw.capture(b[:n])
+	}
+	return n, err
+}
+
+// capture keeps the written bytes fitting under the capture limit
+func (rw *ResponseWriter) capture(b []byte) {
+	room := rw.captureLimit - rw.captured.Len()
+	if len(b) > room {
+		b = b[:room]
+		rw.truncated = true
+	}
+	rw.captured.Write(b)
+}
+
+// Flush sends the buffered data to the client when the underlying writer supports it
+func (rw *ResponseWriter) Flush() {
+	rw.headerWritten()
+	Flush(rw.ResponseWriter)
+}
+
+// Hijack takes over the connection, e.g. for websockets, when the underlying writer
+// supports it. It fails with http.ErrNotSupported otherwise.
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return Hijack(rw.ResponseWriter)
+}
+
+// Push initiates an HTTP/2 server push when the underlying writer supports it. It fails
+// with http.ErrNotSupported otherwise.
+func (rw *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return Push(rw.ResponseWriter, target, opts)
+}
+
+// ReadFrom copies the reader into the response, through the underlying writer
+// io.ReaderFrom implementation when it has one (sendfile on plain connections)
+func (rw *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if rw.captureLimit > 0 {
+		return io.Copy(WriterOnly{rw}, r)
+	}
+	rw.headerWritten()
+	return ReadFrom(rw.ResponseWriter, r)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
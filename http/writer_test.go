@@ -0,0 +1,306 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WriterSuite struct {
+	suite.Suite
+}
+
+func TestWriterSuite(t *testing.T) {
+	suite.Run(t, new(WriterSuite))
+}
+
+// capableRecorder implements the optional writer interfaces, recording their use
+type capableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	pushed   string
+	readFrom bool
+	deadline time.Time
+}
+
+func (cr *capableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	cr.hijacked = true
+	return nil, nil, nil
+}
+
+func (cr *capableRecorder) Push(target string, _ *http.PushOptions) error {
+	cr.pushed = target
+	return nil
+}
+
+func (cr *capableRecorder) SetWriteDeadline(deadline time.Time) error {
+	cr.deadline = deadline
+	return nil
+}
+
+func (cr *capableRecorder) ReadFrom(r io.Reader) (int64, error) {
+	cr.readFrom = true
+	return io.Copy(cr.ResponseRecorder, r)
+}
+
+func (suite *WriterSuite) TestItPassesOptionalInterfacesThrough() {
+	recorder := &capableRecorder{ResponseRecorder: httptest.NewRecorder()}
+	writer := NewResponseWriter(recorder)
+
+	writer.Flush()
+	_, _, err := writer.Hijack()
+	suite.Require().NoError(err)
+	suite.Require().NoError(writer.Push("/app.css", nil))
+	n, err := writer.ReadFrom(strings.NewReader("body"))
+
+	suite.Require().NoError(err)
+	suite.Equal(int64(4), n)
+	suite.True(recorder.Flushed)
+	suite.True(recorder.hijacked)
+	suite.Equal("/app.css", recorder.pushed)
+	suite.True(recorder.readFrom)
+	suite.Equal("body", recorder.Body.String())
+	suite.Equal(http.StatusOK, writer.StatusCode())
+}
+
+func (suite *WriterSuite) TestItCanBeUnwrappedByResponseControllers() {
+	recorder := &capableRecorder{ResponseRecorder: httptest.NewRecorder()}
+	deadline := time.Now().Add(time.Minute)
+
+	err := http.NewResponseController(NewResponseWriter(recorder)).SetWriteDeadline(deadline)
+
+	suite.Require().NoError(err)
+	suite.Equal(deadline, recorder.deadline)
+}
+
+func (suite *WriterSuite) TestItReportsUnsupportedInterfaces() {
+	// Only the http.ResponseWriter methods of the recorder are visible
+	recorder := httptest.NewRecorder()
+	writer := NewResponseWriter(struct{ http.ResponseWriter }{recorder})
+
+	writer.Flush()
+	_, _, hijackErr := writer.Hijack()
+	pushErr := writer.Push("/app.css", nil)
+	_, copyErr := writer.ReadFrom(strings.NewReader("body"))
+
+	suite.False(recorder.Flushed)
+	suite.ErrorIs(hijackErr, http.ErrNotSupported)
+	suite.ErrorIs(pushErr, http.ErrNotSupported)
+	suite.NoError(copyErr)
+	suite.Equal("body", recorder.Body.String())
+}
+
+func (suite *WriterSuite) TestItCanCaptureTheBody() {
+	testCases := map[string]struct {
+		limit             int
+		write             func(writer *ResponseWriter)
+		expectedBody      string
+		expectedCaptured  string
+		expectedTruncated bool
+	}{
+		"disabled": {
+			write:        func(writer *ResponseWriter) { _, _ = writer.Write([]byte("hello")) },
+			expectedBody: "hello",
+		},
+		"under the limit": {
+			limit: 16,
+			write: func(writer *ResponseWriter) {
+				_, _ = writer.Write([]byte("hello "))
+				_, _ = writer.Write([]byte("world"))
+			},
+			expectedBody:     "hello world",
+			expectedCaptured: "hello world",
+		},
+		"over the limit": {
+			limit: 8,
+			write: func(writer *ResponseWriter) {
+				_, _ = writer.Write([]byte("hello "))
+				_, _ = writer.Write([]byte("world"))
+			},
+			expectedBody:      "hello world",
+			expectedCaptured:  "hello wo",
+			expectedTruncated: true,
+		},
+		"read from": {
+			limit: 16,
+			write: func(writer *ResponseWriter) {
+				_, _ = writer.ReadFrom(strings.NewReader("hello world"))
+			},
+			expectedBody:     "hello world",
+			expectedCaptured: "hello world",
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				writer := NewResponseWriter(recorder).CaptureBody(testCase.limit)
+
+				testCase.write(writer)
+
+				suite.Equal(testCase.expectedCaptured, string(writer.CapturedBody()))
+				suite.Equal(testCase.expectedTruncated, writer.BodyTruncated())
+				suite.Equal(testCase.expectedBody, recorder.Body.String())
+			},
+		)
+	}
+}
+
+func (suite *WriterSuite) TestItTracksWhetherTheHeaderWasWritten() {
+	testCases := map[string]struct {
+		write               func(writer *ResponseWriter)
+		expectedWroteHeader bool
+	}{
+		"nothing written": {
+			write: func(writer *ResponseWriter) {},
+		},
+		"status code": {
+			write:               func(writer *ResponseWriter) { writer.WriteHeader(http.StatusCreated) },
+			expectedWroteHeader: true,
+		},
+		"informational status code": {
+			write: func(writer *ResponseWriter) { writer.WriteHeader(http.StatusEarlyHints) },
+		},
+		"body": {
+			write:               func(writer *ResponseWriter) { _, _ = writer.Write([]byte("hello")) },
+			expectedWroteHeader: true,
+		},
+		"flush": {
+			write:               func(writer *ResponseWriter) { writer.Flush() },
+			expectedWroteHeader: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				writer := NewResponseWriter(httptest.NewRecorder())
+
+				testCase.write(writer)
+
+				suite.Equal(testCase.expectedWroteHeader, writer.WroteHeader())
+			},
+		)
+	}
+}
+
+func (suite *WriterSuite) TestItIgnoresDuplicateWriteHeaderCalls() {
+	outputBuffer := new(bytes.Buffer)
+	recorder := httptest.NewRecorder()
+	writer := NewResponseWriter(recorder).
+		WithLogger(slog.New(slog.NewJSONHandler(outputBuffer, &slog.HandlerOptions{})))
+
+	writer.WriteHeader(http.StatusCreated)
+	writer.WriteHeader(http.StatusInternalServerError)
+
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal(http.StatusCreated, writer.StatusCode())
+	suite.Contains(outputBuffer.String(), `"msg":"Superfluous WriteHeader call"`)
+	suite.Contains(outputBuffer.String(), `"IgnoredStatusCode":500`)
+	suite.Contains(outputBuffer.String(), "writer_test.go")
+}
+
+func (suite *WriterSuite) TestItSnapshotsTheHeaderWhenTheResponseStarts() {
+	testCases := map[string]struct {
+		write func(writer *ResponseWriter)
+	}{
+		"status code": {
+			write: func(writer *ResponseWriter) { writer.WriteHeader(http.StatusCreated) },
+		},
+		"body": {
+			write: func(writer *ResponseWriter) { _, _ = writer.Write([]byte("hello")) },
+		},
+		"flush": {
+			write: func(writer *ResponseWriter) { writer.Flush() },
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				writer := NewResponseWriter(httptest.NewRecorder())
+				writer.Header().Set("Content-Type", "application/json")
+				suite.Nil(writer.WrittenHeader())
+
+				testCase.write(writer)
+				writer.Header().Set("Content-Type", "text/plain")
+				writer.Header().Set("X-Late", "1")
+
+				suite.Equal(
+					http.Header{"Content-Type": {"application/json"}},
+					writer.WrittenHeader(),
+				)
+			},
+		)
+	}
+}
+
+func (suite *WriterSuite) TestItTimesTheFirstByteAndTheCompletion() {
+	writer := NewResponseWriter(httptest.NewRecorder())
+	suite.Zero(writer.TimeToFirstByte())
+
+	time.Sleep(20 * time.Millisecond)
+	_, _ = writer.Write([]byte("hello"))
+	time.Sleep(20 * time.Millisecond)
+	writer.Finish()
+	duration := writer.Duration()
+
+	suite.GreaterOrEqual(writer.TimeToFirstByte(), 20*time.Millisecond)
+	suite.GreaterOrEqual(duration-writer.TimeToFirstByte(), 20*time.Millisecond)
+	suite.Equal(duration, writer.Duration())
+}
+
+func (suite *WriterSuite) TestItRunsFirstWriteHooksBeforeTheHeaderIsWritten() {
+	testCases := map[string]struct {
+		write func(writer *ResponseWriter)
+	}{
+		"status code": {
+			write: func(writer *ResponseWriter) { writer.WriteHeader(http.StatusCreated) },
+		},
+		"body": {
+			write: func(writer *ResponseWriter) { _, _ = writer.Write([]byte("hello")) },
+		},
+		"read from": {
+			write: func(writer *ResponseWriter) { _, _ = writer.ReadFrom(strings.NewReader("hello")) },
+		},
+	}
+
+	for name, testCase := range testCases {
+		suite.Run(
+			name, func() {
+				recorder := httptest.NewRecorder()
+				calls := 0
+				writer := NewResponseWriter(recorder).
+					OnFirstWrite(
+						func(header http.Header) {
+							calls++
+							header.Add("Server-Timing", "app;dur=1")
+						},
+					).
+					OnFirstWrite(
+						func(header http.Header) { header.Add("Server-Timing", "db;dur=2") },
+					)
+
+				testCase.write(writer)
+				_, _ = writer.Write([]byte("more"))
+
+				suite.Equal(1, calls)
+				suite.Equal(
+					[]string{"app;dur=1", "db;dur=2"},
+					recorder.Result().Header.Values("Server-Timing"),
+				)
+				suite.Equal([]string{"app;dur=1", "db;dur=2"}, writer.WrittenHeader().Values("Server-Timing"))
+			},
+		)
+	}
+}